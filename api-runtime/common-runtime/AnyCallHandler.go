@@ -29,6 +29,7 @@ func AnyCall(connectionName string, reqInfo cres.AnyCallInfo) (*cres.AnyCallInfo
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateAnyCallHandler()
         if err != nil {
@@ -0,0 +1,74 @@
+// Call-Log History Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"time"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+)
+
+// List the CSP API call records of the call-log files, the newest first.
+// connectionName, resourceType: "" for all
+// since: zero for all
+// limit: <= 0 for all
+// It returns the records of the page and the total count of the matched records.
+func ListCallLog(connectionName string, resourceType string, since time.Time, errorOnly bool,
+	offset int, limit int) ([]*call.CALLLOGRECORD, int, error) {
+	cblog.Info("call ListCallLog()")
+
+	filter := call.CALLLOGFILTER{
+		ConnectionName: connectionName,
+		ResourceType:   call.RES_TYPE(resourceType),
+		Since:          since,
+		ErrorOnly:      errorOnly,
+	}
+
+	// The drivers do not know the connection name,
+	// so their records are matched by the CloudOS and the Region of the connection.
+	if connectionName != "" {
+		providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, 0, err
+		}
+		regionName, _, err := ccm.GetRegionNameByConnectionName(connectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, 0, err
+		}
+		filter.CloudOS = call.CLOUD_OS(providerName)
+		filter.RegionName = regionName
+	}
+
+	recordList, total, err := call.ListCallLog(filter, offset, limit)
+	if err != nil {
+		cblog.Error(err)
+		return nil, 0, err
+	}
+	return recordList, total, nil
+}
+
+// info | error
+func GetCallLogLevel() string {
+	return call.GetLevel()
+}
+
+// info | error, the level is kept until the config file(calllog_conf.yaml) is changed.
+func SetCallLogLevel(level string) error {
+	cblog.Info("call SetCallLogLevel()")
+
+	err := call.SetLevel(level)
+	if err != nil {
+		cblog.Error(err)
+		return err
+	}
+	return nil
+}
@@ -9,6 +9,7 @@
 package commonruntime
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"strconv"
@@ -18,6 +19,8 @@ import (
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
 	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
 )
 
 
@@ -46,6 +49,7 @@ func GetClusterOwnerVPC(connectionName string, cspID string) (owerVPC cres.IID,
                 cblog.Error(err)
                 return cres.IID{}, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -129,8 +133,9 @@ func GetClusterOwnerVPC(connectionName string, cspID string) (owerVPC cres.IID,
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterCluster(connectionName string, vpcUserID string, userIID cres.IID) (*cres.ClusterInfo, error) {
+func RegisterCluster(connectionName string, vpcUserID string, userIID cres.IID) (_ *cres.ClusterInfo, retErr error) {
         cblog.Info("call RegisterCluster()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsCluster, userIID.NameId, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -161,6 +166,7 @@ func RegisterCluster(connectionName string, vpcUserID string, userIID cres.IID)
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -272,8 +278,9 @@ func registerNodeGroupList(connectionName string, info *cres.ClusterInfo) error
 // (5) insert spiderIID
 // (6) create userIID
 // (7) set used Resources's userIID
-func CreateCluster(connectionName string, rsType string, reqInfo cres.ClusterInfo) (*cres.ClusterInfo, error) { 
+func CreateCluster(ctx context.Context, connectionName string, rsType string, reqInfo cres.ClusterInfo) (_ *cres.ClusterInfo, retErr error) {
 	cblog.Info("call CreateCluster()")
+	defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -356,11 +363,12 @@ func CreateCluster(connectionName string, rsType string, reqInfo cres.ClusterInf
 	}
         //+++++++++++++++++++++++++++++++++++++++++++
 
-	cldConn, err := ccm.GetCloudConnection(connectionName)
+	cldConn, err := ccm.GetCloudConnectionWithContext(ctx, connectionName)
 	if err != nil {
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateClusterHandler()
 	if err != nil {
@@ -371,6 +379,12 @@ func CreateCluster(connectionName string, rsType string, reqInfo cres.ClusterInf
 clusterSPLock.Lock(connectionName, reqInfo.IId.NameId)
 defer clusterSPLock.Unlock(connectionName, reqInfo.IId.NameId)
 
+	// the request can be canceled while waiting for the lock
+	err = checkCanceled(ctx, connectionName, call.CLUSTER, reqInfo.IId.NameId, "CB-Spider:CreateCluster()")
+	if err != nil {
+		return nil, err
+	}
+
 	// (1) check exist(NameID)
 	iidInfoList, err := getAllClusterIIDInfoList(connectionName)
 	if err != nil {
@@ -603,6 +617,7 @@ func ListCluster(connectionName string, nameSpace string, rsType string) ([]*cre
                 cblog.Error(err)
                 return nil, err
         }
+	defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -706,6 +721,7 @@ func GetCluster(connectionName string, rsType string, clusterName string) (*cres
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateClusterHandler()
 	if err != nil {
@@ -804,6 +820,7 @@ func AddNodeGroup(connectionName string, rsType string, clusterName string, reqI
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -985,6 +1002,7 @@ func SetNodeGroupAutoScaling(connectionName string, clusterName string, nodeGrou
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -1123,6 +1141,7 @@ func ChangeNodeGroupScaling(connectionName string, clusterName string, nodeGroup
                 cblog.Error(err)
                 return cres.NodeGroupInfo{}, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -1197,6 +1216,7 @@ func RemoveNodeGroup(connectionName string, clusterName string, nodeGroupName st
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -1275,6 +1295,7 @@ func RemoveCSPNodeGroup(connectionName string, clusterName string, systemID stri
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
@@ -1322,6 +1343,7 @@ func UpgradeCluster(connectionName string, clusterName string, newVersion string
                 cblog.Error(err)
                 return cres.ClusterInfo{}, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateClusterHandler()
         if err != nil {
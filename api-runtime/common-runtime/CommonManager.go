@@ -9,6 +9,7 @@
 package commonruntime
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -68,15 +69,15 @@ func RsTypeString(rsType string) string {
 }
 
 // definition of SPLock for each Resource Ops
-var imgSPLock = splock.New()
-var vpcSPLock = splock.New()
-var sgSPLock = splock.New()
-var keySPLock = splock.New()
-var vmSPLock = splock.New()
-var nlbSPLock = splock.New()
-var diskSPLock = splock.New()
-var myImageSPLock = splock.New()
-var clusterSPLock = splock.New()
+var imgSPLock = splock.New(rsImage)
+var vpcSPLock = splock.New(rsVPC)
+var sgSPLock = splock.New(rsSG)
+var keySPLock = splock.New(rsKey)
+var vmSPLock = splock.New(rsVM)
+var nlbSPLock = splock.New(rsNLB)
+var diskSPLock = splock.New(rsDisk)
+var myImageSPLock = splock.New(rsMyImage)
+var clusterSPLock = splock.New(rsCluster)
 
 // definition of IIDManager RWLock
 var iidRWLock = new(iidm.IIDRWLOCK)
@@ -132,6 +133,41 @@ func checkNotFoundError(err error) bool {
 
 
 
+// return an error if the request is canceled by the client or by the deadline,
+// and leave the cancellation in the call-log.
+func checkCanceled(ctx context.Context, connectionName string, rsType call.RES_TYPE, rsName string, apiName string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	err := fmt.Errorf("[%s] %s %s: the request is canceled: %w", connectionName, apiName, rsName, ctx.Err())
+	cblog.Error(err)
+
+	providerName, _ := ccm.GetProviderNameByConnectionName(connectionName)
+	regionName, zoneName, _ := ccm.GetRegionNameByConnectionName(connectionName)
+	callInfo := call.CLOUDLOGSCHEMA {
+		CloudOS: call.CLOUD_OS(providerName),
+		RegionZone: regionName + "/" + zoneName,
+		ResourceType: rsType,
+		ResourceName: rsName,
+		CloudOSAPI: apiName,
+		ElapsedTime: "",
+		ErrorMSG: err.Error(),
+	}
+	callogger.WithFields(call.Fields(connectionName, getRequestID(ctx))).Info(call.String(callInfo))
+
+	return err
+}
+
+// The request ID is left in the call-logs of the request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return call.WithRequestID(ctx, requestID)
+}
+
+func getRequestID(ctx context.Context) string {
+	return call.RequestID(ctx)
+}
+
 func getUserIIDList(iidInfoList []*iidm.IIDInfo) []*cres.IID {
 	iidList := []*cres.IID{}
 	for _, iidInfo := range iidInfoList {
@@ -223,8 +259,9 @@ func uniqueNameList(vpcNameList []string) []string {
 // This API just unregister the resource from Spider.
 // (1) check exist(NameID)
 // (2) delete SpiderIID
-func UnregisterResource(connectionName string, rsType string, nameId string) (bool, error) {
+func UnregisterResource(connectionName string, rsType string, nameId string) (_ bool, retErr error) {
         cblog.Info("call UnregisterResource()")
+        defer func() { emitResourceEvent(EVENT_UNREGISTERED, connectionName, rsType, nameId, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -384,6 +421,9 @@ func UnregisterResource(connectionName string, rsType string, nameId string) (bo
 			cblog.Error(err)
 			return false, err
 		}
+		if rsType == rsVM {
+			deleteVMHostKey(connectionName, nameId)
+		}
 	} // end of switch
 
 	return true, nil
@@ -409,6 +449,7 @@ func ListAllResource(connectionName string, rsType string) (AllResourceList, err
 	if err != nil {
 		return AllResourceList{}, err
 	}
+	defer cldConn.Close()
 
 	var handler interface{}
 
@@ -647,8 +688,9 @@ func ListAllResource(connectionName string, rsType string) (AllResourceList, err
 // (1) get spiderIID
 // (2) delete Resource(SystemId)
 // (3) delete IID
-func DeleteResource(connectionName string, rsType string, nameID string, force string) (bool, cres.VMStatus, error) {
+func DeleteResource(ctx context.Context, connectionName string, rsType string, nameID string, force string) (_ bool, _ cres.VMStatus, retErr error) {
 	cblog.Info("call DeleteResource()")
+	defer func() { emitResourceEvent(EVENT_DELETED, connectionName, rsType, nameID, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -663,11 +705,12 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
                 return false, "", err
         }
 
-	cldConn, err := ccm.GetCloudConnection(connectionName)
+	cldConn, err := ccm.GetCloudConnectionWithContext(ctx, connectionName)
 	if err != nil {
 		cblog.Error(err)
 		return false, "", err
 	}
+	defer cldConn.Close()
 
 	var handler interface{}
 
@@ -856,7 +899,7 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
                         cblog.Error(err)
                         if force != "true" {
 				callInfo.ErrorMSG = err.Error()
-				callogger.Info(call.String(callInfo))
+				callogger.WithFields(call.Fields(connectionName, getRequestID(ctx))).Info(call.String(callInfo))
                                 return false, vmStatus, err
                         }else {
 				break
@@ -868,7 +911,7 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
 		}
 
 		// Check Sync Called
-		waiter := NewWaiter(5, 240) // (sleep, timeout)
+		waiter := NewWaiterWithContext(ctx, 5, 240) // (sleep, timeout)
 
 		for {
 			status, err := handler.(cres.VMHandler).GetVMStatus(driverIId)
@@ -885,7 +928,7 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
 				cblog.Error(err)
 				if force != "true" {
 					callInfo.ErrorMSG = err.Error()
-					callogger.Info(call.String(callInfo))
+					callogger.WithFields(call.Fields(connectionName, getRequestID(ctx))).Info(call.String(callInfo))
 					return false, status, err
 				}else {
 					break
@@ -898,9 +941,12 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
 
 			if !waiter.Wait() {
 				err := fmt.Errorf("[%s] Failed to terminate VM %s. (Timeout=%v)", connectionName, driverIId.NameId, waiter.Timeout)
+				if waiter.Canceled() {
+					err = fmt.Errorf("[%s] Canceled to wait for VM %s to be terminated. (%v)", connectionName, driverIId.NameId, waiter.Err())
+				}
 				if force != "true" {
 					callInfo.ErrorMSG = err.Error()
-					callogger.Info(call.String(callInfo))
+					callogger.WithFields(call.Fields(connectionName, getRequestID(ctx))).Info(call.String(callInfo))
 					return false, status, err
 				}else {
 					break
@@ -909,7 +955,7 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
 		}
 
 		callInfo.ElapsedTime = call.Elapsed(start)
-		callogger.Info(call.String(callInfo))
+		callogger.WithFields(call.Fields(connectionName, getRequestID(ctx))).Info(call.String(callInfo))
         case rsNLB:
                 result, err = handler.(cres.NLBHandler).DeleteNLB(driverIId)
                 if err != nil {
@@ -1004,6 +1050,7 @@ func DeleteResource(connectionName string, rsType string, nameID string, force s
                                 return false, "", err
                         }
                 }
+		deleteVMHostKey(connectionName, nameID)
 		return result, vmStatus, nil
         case rsNLB:
                 _, err = iidRWLock.DeleteIID(iidm.NLBGROUP, connectionName, iidInfo.ResourceType/*vpcName*/, cres.IID{nameID, ""})
@@ -1079,6 +1126,7 @@ func DeleteCSPResource(connectionName string, rsType string, systemID string) (b
 		cblog.Error(err)
 		return false, "", err
 	}
+	defer cldConn.Close()
 
 	var handler interface{}
 
@@ -25,8 +25,9 @@ import (
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterDisk(connectionName string, userIID cres.IID) (*cres.DiskInfo, error) {
+func RegisterDisk(connectionName string, userIID cres.IID) (_ *cres.DiskInfo, retErr error) {
         cblog.Info("call RegisterDisk()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsDisk, userIID.NameId, retErr) }()
 
         // check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -51,6 +52,7 @@ func RegisterDisk(connectionName string, userIID cres.IID) (*cres.DiskInfo, erro
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -109,8 +111,9 @@ func RegisterDisk(connectionName string, userIID cres.IID) (*cres.DiskInfo, erro
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func CreateDisk(connectionName string, rsType string, reqInfo cres.DiskInfo) (*cres.DiskInfo, error) {
+func CreateDisk(connectionName string, rsType string, reqInfo cres.DiskInfo) (_ *cres.DiskInfo, retErr error) {
         cblog.Info("call CreateDisk()")
+        defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
         // check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -122,6 +125,7 @@ func CreateDisk(connectionName string, rsType string, reqInfo cres.DiskInfo) (*c
         emptyPermissionList := []string{
                 "resources.IID:SystemId",
                 "resources.DiskInfo:Status",
+                "resources.KeyValue:Value", // because a tag can have an empty value
         }
 
         err = ValidateStruct(reqInfo, emptyPermissionList)
@@ -135,6 +139,7 @@ func CreateDisk(connectionName string, rsType string, reqInfo cres.DiskInfo) (*c
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -230,6 +235,7 @@ func ListDisk(connectionName string, rsType string) ([]*cres.DiskInfo, error) {
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -314,6 +320,7 @@ func GetDisk(connectionName string, rsType string, nameID string) (*cres.DiskInf
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -381,6 +388,7 @@ func ChangeDiskSize(connectionName string, diskName string, size string) (bool,
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -438,6 +446,7 @@ func AttachDisk(connectionName string, diskName string, ownerVMName string) (*cr
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -501,6 +510,7 @@ func DetachDisk(connectionName string, diskName string, ownerVMName string) (boo
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateDiskHandler()
         if err != nil {
@@ -0,0 +1,175 @@
+// Resource Event Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+//================ Resource Lifecycle Event
+
+// the types of the resource events
+const (
+	EVENT_CREATED        = "created"
+	EVENT_DELETED        = "deleted"
+	EVENT_STATUS_CHANGED = "status-changed" // VM only
+	EVENT_REGISTERED     = "registered"
+	EVENT_UNREGISTERED   = "unregistered"
+	EVENT_FAILED         = "failed" // the failure of the create, delete, register or unregister
+)
+
+var eventTypeList = []string{EVENT_CREATED, EVENT_DELETED, EVENT_STATUS_CHANGED, EVENT_REGISTERED, EVENT_UNREGISTERED, EVENT_FAILED}
+
+// the operation of the failed event
+var eventOperationMap = map[string]string{
+	EVENT_CREATED:      "create",
+	EVENT_DELETED:      "delete",
+	EVENT_REGISTERED:   "register",
+	EVENT_UNREGISTERED: "unregister",
+}
+
+type ResourceEvent struct {
+	EventId        string // ordered by the time, ex) "1665968400000000000-000001"
+	EventType      string // EVENT_CREATED, EVENT_DELETED, ...
+	ConnectionName string
+	ResourceType   string // ex) "vm", "disk"
+	ResourceName   string // the NameId of the resource
+
+	OldStatus string `json:",omitempty"` // status-changed, ex) "Suspending"
+	Status    string `json:",omitempty"` // status-changed, ex) "Suspended"
+
+	Operation string `json:",omitempty"` // failed, "create" | "delete" | "register" | "unregister"
+	Message   string `json:",omitempty"` // failed, the error message
+
+	Time time.Time
+}
+
+// the interval and the timeout of the VM status tracking after StartVM and ControlVM
+var (
+	vmStatusTrackInterval = 5 * time.Second
+	vmStatusTrackTimeout  = 10 * time.Minute
+)
+
+// <ConnectionName>/<VM NameId> => the last seen VM status
+var vmStatusMap = map[string]cres.VMStatus{}
+
+// <ConnectionName>/<VM NameId> => true while tracking
+var vmStatusTrackingMap = map[string]bool{}
+
+var vmStatusMutex sync.Mutex
+
+var eventSequence uint64
+
+// emit the event of a create, delete, register or unregister.
+// The error of the operation emits a failed event.
+func emitResourceEvent(eventType string, connectionName string, rsType string, nameID string, opErr error) {
+	event := newResourceEvent(eventType, connectionName, rsType, nameID)
+	if opErr != nil {
+		event.EventType = EVENT_FAILED
+		event.Operation = eventOperationMap[eventType]
+		event.Message = opErr.Error()
+	}
+	if eventType == EVENT_DELETED && opErr == nil && rsType == rsVM {
+		forgetVMStatus(connectionName, nameID)
+	}
+	emitEvent(event)
+}
+
+// send the event to the subscribing webhooks in background.
+func emitEvent(event *ResourceEvent) {
+	for _, webhookInfo := range getEventWebhookList(event) {
+		go deliverWebhook(webhookInfo, event)
+	}
+}
+
+func newResourceEvent(eventType string, connectionName string, rsType string, nameID string) *ResourceEvent {
+	now := time.Now()
+	return &ResourceEvent{
+		EventId:        fmt.Sprintf("%019d-%06d", now.UnixNano(), atomic.AddUint64(&eventSequence, 1)%1000000),
+		EventType:      eventType,
+		ConnectionName: connectionName,
+		ResourceType:   rsType,
+		ResourceName:   nameID,
+		Time:           now,
+	}
+}
+
+// keep the seen VM status and emit the status-changed event if it is changed.
+// The first seen status of a VM is not an event.
+func observeVMStatus(connectionName string, nameID string, status cres.VMStatus) {
+	if status == "" {
+		return
+	}
+
+	key := connectionName + "/" + nameID
+	vmStatusMutex.Lock()
+	oldStatus, ok := vmStatusMap[key]
+	vmStatusMap[key] = status
+	vmStatusMutex.Unlock()
+
+	if !ok || oldStatus == status {
+		return
+	}
+	event := newResourceEvent(EVENT_STATUS_CHANGED, connectionName, rsVM, nameID)
+	event.OldStatus = string(oldStatus)
+	event.Status = string(status)
+	emitEvent(event)
+}
+
+func forgetVMStatus(connectionName string, nameID string) {
+	vmStatusMutex.Lock()
+	defer vmStatusMutex.Unlock()
+	delete(vmStatusMap, connectionName+"/"+nameID)
+}
+
+// track the VM status in background until it is stable, only with the webhooks.
+func trackVMStatus(connectionName string, nameID string) {
+	if !hasWebhook() {
+		return
+	}
+
+	key := connectionName + "/" + nameID
+	vmStatusMutex.Lock()
+	if vmStatusTrackingMap[key] {
+		vmStatusMutex.Unlock()
+		return
+	}
+	vmStatusTrackingMap[key] = true
+	vmStatusMutex.Unlock()
+
+	go func() {
+		defer func() {
+			vmStatusMutex.Lock()
+			delete(vmStatusTrackingMap, key)
+			vmStatusMutex.Unlock()
+		}()
+
+		deadline := time.Now().Add(vmStatusTrackTimeout)
+		for time.Now().Before(deadline) {
+			status, err := GetVMStatus(connectionName, rsVM, nameID)
+			if err != nil || isStableVMStatus(status) {
+				return
+			}
+			time.Sleep(vmStatusTrackInterval)
+		}
+	}()
+}
+
+func isStableVMStatus(status cres.VMStatus) bool {
+	switch status {
+	case cres.Running, cres.Suspended, cres.Terminated, cres.NotExist, cres.Failed:
+		return true
+	}
+	return false
+}
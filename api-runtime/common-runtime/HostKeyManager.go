@@ -0,0 +1,269 @@
+// SSH Host Key Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	sshrun "github.com/cloud-barista/cb-spider/cloud-control-manager/vm-ssh"
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+)
+
+//================ VM SSH Host Key (Trust On First Use)
+
+// The first SSH connection to a VM pins the host key, and the later connections
+// are verified strictly with the pinned key. The pinned key is removed with the VM's IID.
+// The jump hosts given by the ServerPort without the HostKey are pinned in the same way.
+type VMHostKeyInfo struct {
+	ConnectionName string
+	VMName         string // empty for a jump host
+	ServerPort     string // the address of the first connection, ex) "3.35.114.10:22"
+	KeyType        string // ex) "ssh-ed25519"
+	HostKey        string // authorized_keys format, ex) "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFe..."
+	Fingerprint    string // ex) "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"
+	PinnedTime     time.Time
+}
+
+// format: the same space of the VM's IID
+// /resource-info-spaces/hostkeys/<ConnectionName>/vm/<VM NameId> [VMHostKeyInfo(json)]
+// /resource-info-spaces/hostkeys/<ConnectionName>/jumphost/<ServerPort> [VMHostKeyInfo(json)]
+const hostKeyKeyPrefix = "/resource-info-spaces/hostkeys/"
+
+var hostKeyStore icbs.Store
+
+var hostKeyMutex sync.Mutex
+
+func init() {
+	hostKeyStore = cbstore.GetStore()
+}
+
+// get the pinned host key of a VM
+func GetVMHostKey(connectionName string, vmName string) (*VMHostKeyInfo, error) {
+	cblog.Info("call GetVMHostKey()")
+
+	connectionName, vmName, err := checkHostKeyVM(connectionName, vmName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	hostKeyMutex.Lock()
+	defer hostKeyMutex.Unlock()
+
+	hostKeyInfo, err := getVMHostKeyValue(connectionName, vmName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if hostKeyInfo == nil {
+		return nil, fmt.Errorf("The host key of VM '%s' is not pinned yet!", vmName)
+	}
+	return hostKeyInfo, nil
+}
+
+// reset the pinned host key of a VM, the next SSH connection pins the new host key.
+func ResetVMHostKey(connectionName string, vmName string) (bool, error) {
+	cblog.Info("call ResetVMHostKey()")
+
+	connectionName, vmName, err := checkHostKeyVM(connectionName, vmName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	hostKeyMutex.Lock()
+	defer hostKeyMutex.Unlock()
+
+	hostKeyInfo, err := getVMHostKeyValue(connectionName, vmName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	if hostKeyInfo == nil {
+		return false, fmt.Errorf("The host key of VM '%s' is not pinned yet!", vmName)
+	}
+	if err := hostKeyStore.Delete(hostKeyKey(connectionName, vmName)); err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// VMHostKeyCallback returns the host key callback and the host key algorithms for the SSH connection to a VM.
+// The presented key is pinned on the first connection, and verified with the pinned key after that.
+func VMHostKeyCallback(connectionName string, vmName string) (ssh.HostKeyCallback, []string, error) {
+	return pinnedHostKeyCallback(hostKeyKey(connectionName, vmName), connectionName, vmName)
+}
+
+// JumpHostKeyCallback returns the host key callback of a jump host given by the ServerPort, pinned like the VMs.
+func JumpHostKeyCallback(connectionName string, serverPort string) (ssh.HostKeyCallback, []string, error) {
+	return pinnedHostKeyCallback(jumpHostKeyKey(connectionName, serverPort), connectionName, "")
+}
+
+func pinnedHostKeyCallback(storeKey string, connectionName string, vmName string) (ssh.HostKeyCallback, []string, error) {
+	hostKeyMutex.Lock()
+	defer hostKeyMutex.Unlock()
+
+	hostKeyInfo, err := getHostKeyValue(storeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pinnedKey := ""
+	if hostKeyInfo != nil {
+		pinnedKey = hostKeyInfo.HostKey
+	}
+	pinFunc := func(hostname string, key ssh.PublicKey) error {
+		return pinHostKey(storeKey, connectionName, vmName, hostname, key)
+	}
+
+	hostKeyCallback, err := sshrun.PinnedHostKeyCallback(pinnedKey, pinFunc)
+	if err != nil {
+		return nil, nil, err
+	}
+	hostKeyAlgorithms, err := sshrun.PinnedHostKeyAlgorithms(pinnedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return hostKeyCallback, hostKeyAlgorithms, nil
+}
+
+// the first presented key wins, when the connections are concurrent before pinning.
+func pinHostKey(storeKey string, connectionName string, vmName string, serverPort string, key ssh.PublicKey) error {
+	hostKeyMutex.Lock()
+	defer hostKeyMutex.Unlock()
+
+	hostKeyInfo, err := getHostKeyValue(storeKey)
+	if err != nil {
+		return err
+	}
+	hostKey := sshrun.MarshalHostKey(key)
+	if hostKeyInfo != nil {
+		if hostKeyInfo.HostKey != hostKey {
+			return &sshrun.HostKeyChangedError{
+				Host:                 serverPort,
+				PinnedFingerprint:    hostKeyInfo.Fingerprint,
+				PresentedFingerprint: ssh.FingerprintSHA256(key),
+			}
+		}
+		return nil
+	}
+
+	hostKeyInfo = &VMHostKeyInfo{
+		ConnectionName: connectionName,
+		VMName:         vmName,
+		ServerPort:     serverPort,
+		KeyType:        key.Type(),
+		HostKey:        hostKey,
+		Fingerprint:    ssh.FingerprintSHA256(key),
+		PinnedTime:     time.Now(),
+	}
+	jsonValue, err := json.Marshal(hostKeyInfo)
+	if err != nil {
+		return err
+	}
+	if vmName != "" {
+		cblog.Infof("[%s] pinned the host key of VM %s: %s", connectionName, vmName, hostKeyInfo.Fingerprint)
+	} else {
+		cblog.Infof("[%s] pinned the host key of the jump host %s: %s", connectionName, serverPort, hostKeyInfo.Fingerprint)
+	}
+	return hostKeyStore.Put(storeKey, string(jsonValue))
+}
+
+// called with the VM's IID deletion, the error is logged only.
+func deleteVMHostKey(connectionName string, vmName string) {
+	hostKeyMutex.Lock()
+	defer hostKeyMutex.Unlock()
+
+	hostKeyInfo, err := getVMHostKeyValue(connectionName, vmName)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	if hostKeyInfo == nil {
+		return
+	}
+	if err := hostKeyStore.Delete(hostKeyKey(connectionName, vmName)); err != nil {
+		cblog.Error(err)
+	}
+}
+
+// called with the change of the jump hosts of a connection, the next connections pin the new host keys.
+func deleteJumpHostKeys(connectionName string) {
+	hostKeyMutex.Lock()
+	defer hostKeyMutex.Unlock()
+
+	keyValueList, err := hostKeyStore.GetList(jumpHostKeyKey(connectionName, ""), true)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, keyValue := range keyValueList {
+		if err := hostKeyStore.Delete(keyValue.Key); err != nil {
+			cblog.Error(err)
+		}
+	}
+}
+
+func checkHostKeyVM(connectionName string, vmName string) (string, string, error) {
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		return "", "", err
+	}
+	vmName, err = EmptyCheckAndTrim("vmName", vmName)
+	if err != nil {
+		return "", "", err
+	}
+
+	isExist, err := iidRWLock.IsExistIID(iidm.IIDSGROUP, connectionName, rsVM, cres.IID{NameId: vmName, SystemId: ""})
+	if err != nil {
+		return "", "", err
+	}
+	if !isExist {
+		return "", "", fmt.Errorf("The %s '%s' does not exist!", RsTypeString(rsVM), vmName)
+	}
+	return connectionName, vmName, nil
+}
+
+// nil if the host key is not pinned.
+func getVMHostKeyValue(connectionName string, vmName string) (*VMHostKeyInfo, error) {
+	return getHostKeyValue(hostKeyKey(connectionName, vmName))
+}
+
+func getHostKeyValue(storeKey string) (*VMHostKeyInfo, error) {
+	keyValue, err := hostKeyStore.Get(storeKey)
+	if err != nil {
+		return nil, err
+	}
+	if keyValue == nil {
+		return nil, nil
+	}
+	hostKeyInfo := &VMHostKeyInfo{}
+	if err := json.Unmarshal([]byte(keyValue.Value), hostKeyInfo); err != nil {
+		return nil, err
+	}
+	return hostKeyInfo, nil
+}
+
+func hostKeyKey(connectionName string, vmName string) string {
+	return hostKeyKeyPrefix + connectionName + "/" + rsVM + "/" + vmName
+}
+
+func jumpHostKeyKey(connectionName string, serverPort string) string {
+	return hostKeyKeyPrefix + connectionName + "/jumphost/" + serverPort
+}
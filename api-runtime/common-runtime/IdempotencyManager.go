@@ -0,0 +1,275 @@
+// Idempotency Key Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+)
+
+//================ Idempotency Key
+
+// A create request with a client-supplied idempotency key is run once.
+// The duplicate requests with the same key get the result of the first request,
+// or the InProgress status while the first request is running.
+// The failed request releases the key, so it can be retried with the same key.
+
+const (
+	IdempotencyInProgress = "InProgress"
+	IdempotencyCompleted  = "Completed"
+)
+
+var (
+	// the completed result is kept for the TTL.
+	IdempotencyTTL = 24 * time.Hour
+	// the InProgress key is released after this, when the server was stopped while running the request.
+	IdempotencyInProgressTimeout = time.Hour
+)
+
+const maxIdempotencyKeyLength = 255
+
+var (
+	// the key is empty or too long.
+	ErrIdempotencyKeyInvalid = errors.New("The Idempotency-Key is invalid")
+	// the key is reused with a different request.
+	ErrIdempotencyKeyMismatch = errors.New("The Idempotency-Key is already used by a different request!")
+)
+
+type IdempotencyRecord struct {
+	Scope       string // ex) "REST:POST /spider/vm", "GRPC:/cbspider.CCM/StartVM"
+	Key         string // the client-supplied key
+	RequestHash string // SHA256 of the request, the same key with a different request is rejected
+	Status      string // InProgress | Completed
+	StatusCode  int    // the status code of the result, ex) 200
+	Result      string // the result of the request, ex) json of VMInfo, encrypted in the store(ex. PrivateKey of KeyPairInfo)
+	CreatedTime time.Time
+	ExpireTime  time.Time
+}
+
+// format: the hash of the scope and the key, ex) users' keys can include '/'
+// /resource-info-spaces/idempotency/<SHA256(Scope + "\n" + Key)> [IdempotencyRecord(json)]
+const idempotencyKeyPrefix = "/resource-info-spaces/idempotency/"
+
+var idempotencyStore icbs.Store
+
+var idempotencyMutex sync.Mutex
+
+var lastIdempotencyPurge time.Time
+
+const idempotencyPurgeInterval = 10 * time.Minute
+
+func init() {
+	idempotencyStore = cbstore.GetStore()
+}
+
+// BeginIdempotentRequest reserves the key for a request.
+// If reserved, the request should be run, and the returned record is released with ReleaseIdempotentRequest() on a failure.
+// If the key is already used, the record of the first request is returned:
+// Completed with the result, or InProgress while the first request is running.
+func BeginIdempotentRequest(scope string, key string, requestHash string) (record *IdempotencyRecord, reserved bool, err error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, false, fmt.Errorf("%w: empty!", ErrIdempotencyKeyInvalid)
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, false, fmt.Errorf("%w: too long, max: %d!", ErrIdempotencyKeyInvalid, maxIdempotencyKeyLength)
+	}
+
+	idempotencyMutex.Lock()
+	defer idempotencyMutex.Unlock()
+
+	purgeExpiredIdempotencyRecords()
+
+	record, err = getIdempotencyRecord(scope, key)
+	if err != nil {
+		cblog.Error(err)
+		return nil, false, err
+	}
+	if record != nil && !isIdempotencyRecordExpired(record) {
+		if record.RequestHash != requestHash {
+			return nil, false, ErrIdempotencyKeyMismatch
+		}
+		if record.Result != "" {
+			result, err := cim.DecryptSecret(idempotencyResultAAD(scope, key), record.Result)
+			if err != nil {
+				cblog.Error(err)
+				return nil, false, err
+			}
+			record.Result = result
+		}
+		return record, false, nil
+	}
+
+	now := time.Now()
+	record = &IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      IdempotencyInProgress,
+		CreatedTime: now,
+		ExpireTime:  now.Add(IdempotencyTTL),
+	}
+	if err := putIdempotencyRecord(record); err != nil {
+		cblog.Error(err)
+		return nil, false, err
+	}
+	return record, true, nil
+}
+
+// CompleteIdempotentRequest keeps the result of the reserved request for the duplicate requests.
+// The result is not kept if the key is reserved again by another request after the InProgress timeout.
+func CompleteIdempotentRequest(reserved *IdempotencyRecord, statusCode int, result string) error {
+	idempotencyMutex.Lock()
+	defer idempotencyMutex.Unlock()
+
+	record, err := getIdempotencyRecord(reserved.Scope, reserved.Key)
+	if err != nil {
+		cblog.Error(err)
+		return err
+	}
+	if !isIdempotencyReservation(record, reserved) {
+		return errors.New("The Idempotency-Key is not reserved by the request: " + reserved.Key)
+	}
+
+	encryptedResult, err := cim.EncryptSecret(idempotencyResultAAD(reserved.Scope, reserved.Key), result)
+	if err != nil {
+		cblog.Error(err)
+		return err
+	}
+
+	now := time.Now()
+	record.Status = IdempotencyCompleted
+	record.StatusCode = statusCode
+	record.Result = encryptedResult
+	record.ExpireTime = now.Add(IdempotencyTTL)
+	if err := putIdempotencyRecord(record); err != nil {
+		cblog.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ReleaseIdempotentRequest releases the key of the failed request, the error is logged only.
+// Only the InProgress record of the reservation is deleted: the key may be reserved again
+// by another request after the InProgress timeout, or completed.
+func ReleaseIdempotentRequest(reserved *IdempotencyRecord) {
+	if reserved == nil {
+		return
+	}
+
+	idempotencyMutex.Lock()
+	defer idempotencyMutex.Unlock()
+
+	record, err := getIdempotencyRecord(reserved.Scope, reserved.Key)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	if !isIdempotencyReservation(record, reserved) {
+		return
+	}
+	if err := idempotencyStore.Delete(idempotencyStoreKey(reserved.Scope, reserved.Key)); err != nil {
+		cblog.Error(err)
+	}
+}
+
+// IdempotencyRequestHash returns the hash of the request parts, ex) method, path and body.
+func IdempotencyRequestHash(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// the stored record is still InProgress by the reservation.
+func isIdempotencyReservation(record *IdempotencyRecord, reserved *IdempotencyRecord) bool {
+	return record != nil && record.Status == IdempotencyInProgress &&
+		record.RequestHash == reserved.RequestHash && record.CreatedTime.Equal(reserved.CreatedTime)
+}
+
+func isIdempotencyRecordExpired(record *IdempotencyRecord) bool {
+	now := time.Now()
+	if record.Status == IdempotencyInProgress {
+		return now.After(record.CreatedTime.Add(IdempotencyInProgressTimeout))
+	}
+	return now.After(record.ExpireTime)
+}
+
+// delete the expired records, at most once in the purge interval.
+// called with the idempotencyMutex.
+func purgeExpiredIdempotencyRecords() {
+	if time.Since(lastIdempotencyPurge) < idempotencyPurgeInterval {
+		return
+	}
+	lastIdempotencyPurge = time.Now()
+
+	keyValueList, err := idempotencyStore.GetList(idempotencyKeyPrefix, true)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, kv := range keyValueList {
+		record := &IdempotencyRecord{}
+		if err := json.Unmarshal([]byte(kv.Value), record); err != nil {
+			cblog.Error(err)
+			continue
+		}
+		if isIdempotencyRecordExpired(record) {
+			if err := idempotencyStore.Delete(kv.Key); err != nil {
+				cblog.Error(err)
+			}
+		}
+	}
+}
+
+// nil if the key is not used.
+func getIdempotencyRecord(scope string, key string) (*IdempotencyRecord, error) {
+	keyValue, err := idempotencyStore.Get(idempotencyStoreKey(scope, key))
+	if err != nil {
+		return nil, err
+	}
+	if keyValue == nil {
+		return nil, nil
+	}
+	record := &IdempotencyRecord{}
+	if err := json.Unmarshal([]byte(keyValue.Value), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func putIdempotencyRecord(record *IdempotencyRecord) error {
+	jsonValue, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return idempotencyStore.Put(idempotencyStoreKey(record.Scope, record.Key), string(jsonValue))
+}
+
+// binds the encrypted result to the scope and the key.
+func idempotencyResultAAD(scope string, key string) string {
+	return "idempotency/" + strings.TrimPrefix(idempotencyStoreKey(scope, key), idempotencyKeyPrefix)
+}
+
+func idempotencyStoreKey(scope string, key string) string {
+	sum := sha256.Sum256([]byte(scope + "\n" + key))
+	return idempotencyKeyPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,1063 @@
+// Infra Plan/Apply/Destroy Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+)
+
+//================ Infra Document
+// The resources of one connection in a YAML or JSON document, ex)
+//
+// ConnectionName: aws-ohio-config
+// VPCList:
+//   - Name: vpc-01
+//     IPv4_CIDR: 192.168.0.0/16
+//     SubnetInfoList:
+//       - Name: subnet-01
+//         IPv4_CIDR: 192.168.1.0/24
+// SecurityGroupList:
+//   - Name: sg-01
+//     VPCName: vpc-01
+//     SecurityRules:
+//       - {Direction: inbound, IPProtocol: TCP, FromPort: "22", ToPort: "22", CIDR: 0.0.0.0/0}
+// KeyPairList:
+//   - Name: keypair-01
+// VMList:
+//   - Name: vm-01
+//     ImageName: ami-00978328f54e31526
+//     VMSpecName: t2.micro
+//     VPCName: vpc-01
+//     SubnetName: subnet-01
+//     SecurityGroupNames: [sg-01]
+//     KeyPairName: keypair-01
+
+type InfraDoc struct {
+	Name           string // optional, ex) "web-infra-01"
+	ConnectionName string
+
+	VPCList           []InfraVPCInfo
+	SecurityGroupList []InfraSecurityGroupInfo
+	KeyPairList       []InfraKeyPairInfo
+	DiskList          []InfraDiskInfo
+	VMList            []InfraVMInfo
+	NLBList           []InfraNLBInfo
+}
+
+type InfraVPCInfo struct {
+	Name           string
+	IPv4_CIDR      string
+	SubnetInfoList []InfraSubnetInfo
+	TagList        []cres.KeyValue
+}
+
+type InfraSubnetInfo struct {
+	Name      string
+	IPv4_CIDR string
+}
+
+type InfraSecurityGroupInfo struct {
+	Name          string
+	VPCName       string
+	SecurityRules []cres.SecurityRuleInfo
+	TagList       []cres.KeyValue
+}
+
+type InfraKeyPairInfo struct {
+	Name string
+}
+
+type InfraDiskInfo struct {
+	Name     string
+	DiskType string
+	DiskSize string
+	TagList  []cres.KeyValue
+}
+
+type InfraVMInfo struct {
+	Name               string
+	ImageType          string
+	ImageName          string
+	VPCName            string
+	SubnetName         string
+	SecurityGroupNames []string
+	VMSpecName         string
+	KeyPairName        string
+
+	RootDiskType string
+	RootDiskSize string
+
+	DataDiskNames []string
+
+	VMUserId     string
+	VMUserPasswd string
+
+	TagList []cres.KeyValue
+}
+
+type InfraNLBInfo struct {
+	Name    string
+	VPCName string
+	Type    string // PUBLIC(V) | INTERNAL
+	Scope   string // REGION(V) | GLOBAL
+
+	Listener      cres.ListenerInfo
+	VMGroup       InfraVMGroupInfo
+	HealthChecker cres.HealthCheckerInfo // Interval, Timeout, Threshold: 0 for the default
+
+	TagList []cres.KeyValue
+}
+
+type InfraVMGroupInfo struct {
+	Protocol string
+	Port     string
+	VMs      []string
+}
+
+//================ Infra Plan
+
+// mode of the plan
+const (
+	INFRA_APPLY   = "apply"
+	INFRA_DESTROY = "destroy"
+)
+
+// action of the plan item
+const (
+	INFRA_CREATE   = "create"
+	INFRA_EXISTS   = "exists"
+	INFRA_DELETE   = "delete"
+	INFRA_NOTFOUND = "not-found"
+	INFRA_NOTOWNED = "not-owned" // exists, but it was not created by the infra
+)
+
+// status of the plan item after apply or destroy
+const (
+	INFRA_DONE            = "done"
+	INFRA_FAILED          = "failed"
+	INFRA_SKIPPED         = "skipped"
+	INFRA_ROLLEDBACK      = "rolled-back"
+	INFRA_ROLLBACK_FAILED = "rollback-failed"
+)
+
+type InfraPlan struct {
+	Name           string
+	ConnectionName string
+	Mode           string // apply | destroy
+	Summary        string // ex) "create: 5, exists: 1"
+	ItemList       []*InfraPlanItem
+
+	// the private keys of the created KeyPairs, {KeyPair Name, PrivateKey}, not kept in the Job result
+	PrivateKeyList []cres.KeyValue `json:",omitempty"`
+}
+
+type InfraPlanItem struct {
+	ResourceType string   // vpc | subnet | sg | keypair | disk | vm | nlb
+	Name         string   // the subnet's name is {VPC Name}/{Subnet Name}
+	Action       string   // create | exists | delete | not-found | not-owned
+	DependsOn    []string `json:",omitempty"` // ex) ["vpc:vpc-01", "sg:sg-01"]
+	Status       string   `json:",omitempty"` // done | failed | skipped | rolled-back | rollback-failed
+	Message      string   `json:",omitempty"`
+
+	run   func(ctx context.Context) error
+	undo  func(ctx context.Context) error
+	exist func() (bool, error)
+}
+
+func (item *InfraPlanItem) key() string {
+	return item.ResourceType + ":" + item.Name
+}
+
+// YAML or JSON => InfraDoc
+func ParseInfraDoc(data []byte) (*InfraDoc, error) {
+	// YAML is a superset of JSON, and the field names are matched like JSON(case-insensitive)
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse the infra document: %v", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("the infra document is empty!")
+	}
+	jsonBytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the infra document: %v", err)
+	}
+
+	doc := &InfraDoc{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the infra document: %v", err)
+	}
+	return doc, nil
+}
+
+// compute the dependency-ordered plan against the IID Manager.
+// mode: apply(default) | destroy
+func PlanInfra(doc *InfraDoc, mode string) (*InfraPlan, error) {
+	cblog.Info("call PlanInfra()")
+
+	var err error
+	doc.ConnectionName, err = EmptyCheckAndTrim("ConnectionName", doc.ConnectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	builder, err := newInfraPlanBuilder(doc)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var plan *InfraPlan
+	switch strings.ToLower(mode) {
+	case "", INFRA_APPLY:
+		plan, err = builder.applyPlan()
+	case INFRA_DESTROY:
+		plan, err = builder.destroyPlan()
+	default:
+		err = fmt.Errorf("%s is not a plan mode, it should be %s or %s!", mode, INFRA_APPLY, INFRA_DESTROY)
+	}
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return plan, nil
+}
+
+// create the resources of the plan in order, and record them as the resources of the infra.
+// When a creation fails, the part of the failed resource and
+// the resources created before are deleted in reverse order.
+func ApplyInfra(ctx context.Context, doc *InfraDoc) (*InfraPlan, error) {
+	cblog.Info("call ApplyInfra()")
+
+	plan, err := PlanInfra(doc, INFRA_APPLY)
+	if err != nil {
+		return nil, err
+	}
+
+	doneList := []*InfraPlanItem{}
+	for idx, item := range plan.ItemList {
+		if item.Action != INFRA_CREATE {
+			continue
+		}
+
+		ran, created := false, false
+		err := ctx.Err()
+		if err == nil {
+			ran = true
+			err = item.run(ctx)
+			if err == nil {
+				created = true
+				err = putInfraRecord(plan, item)
+			}
+		}
+		if err == nil {
+			item.Status = INFRA_DONE
+			doneList = append(doneList, item)
+			continue
+		}
+
+		// failed: rollback the created resources
+		cblog.Error(err)
+		item.Status = INFRA_FAILED
+		item.Message = err.Error()
+		if ran {
+			rollbackFailedItem(plan, item, created)
+		}
+		for _, next := range plan.ItemList[idx+1:] {
+			if next.Action == INFRA_CREATE {
+				next.Status = INFRA_SKIPPED
+			}
+		}
+
+		rolledBackList := []string{}
+		for i := len(doneList) - 1; i >= 0; i-- {
+			done := doneList[i]
+			// the rollback should not be canceled by the canceled request
+			undoErr := deleteWithRetry(context.Background(), done.undo)
+			if undoErr != nil {
+				cblog.Error(undoErr)
+				done.Status = INFRA_ROLLBACK_FAILED
+				done.Message = undoErr.Error()
+				continue
+			}
+			done.Status = INFRA_ROLLEDBACK
+			deleteInfraRecord(plan, done.key())
+			rolledBackList = append(rolledBackList, done.key())
+		}
+		plan.PrivateKeyList = nil
+
+		return plan, fmt.Errorf("[%s] failed to create %s: %v (rolled back: %v)",
+			plan.ConnectionName, item.key(), err, rolledBackList)
+	}
+	return plan, nil
+}
+
+// delete the part of the failed resource, ex) a VM which is registered but failed to start.
+// created: the resource was created, but it was failed to record.
+func rollbackFailedItem(plan *InfraPlan, item *InfraPlanItem, created bool) {
+	if !created {
+		exist, err := item.exist()
+		if err != nil {
+			cblog.Error(err)
+			item.Message += " (failed to check the rollback: " + err.Error() + ")"
+			return
+		}
+		if !exist {
+			return
+		}
+	}
+
+	// the rollback should not be canceled by the canceled request
+	if err := deleteWithRetry(context.Background(), item.undo); err != nil {
+		cblog.Error(err)
+		item.Status = INFRA_ROLLBACK_FAILED
+		item.Message += " (rollback failed: " + err.Error() + ")"
+		return
+	}
+	deleteInfraRecord(plan, item.key())
+	item.Message += " (rolled back)"
+}
+
+// delete the resources of the plan in reverse order.
+// Only the resources created by ApplyInfra() of the infra are deleted,
+// the resources created by other requests are not touched(not-owned).
+func DestroyInfra(ctx context.Context, doc *InfraDoc) (*InfraPlan, error) {
+	cblog.Info("call DestroyInfra()")
+
+	plan, err := PlanInfra(doc, INFRA_DESTROY)
+	if err != nil {
+		return nil, err
+	}
+
+	// the VM and the NLB release their resources after the termination,
+	// so the deletion of the resources used by them is retried.
+	released := false
+	for idx, item := range plan.ItemList {
+		if item.Action != INFRA_DELETE {
+			continue
+		}
+
+		err := ctx.Err()
+		if err == nil {
+			if released {
+				err = deleteWithRetry(ctx, item.run)
+			} else {
+				err = item.run(ctx)
+			}
+		}
+		if err == nil {
+			item.Status = INFRA_DONE
+			deleteInfraRecord(plan, item.key())
+			if item.ResourceType == rsVPC {
+				// the Subnets are deleted with their VPC
+				deleteInfraRecord(plan, rsSubnet+":"+item.Name+"/")
+			}
+			if item.ResourceType == rsVM || item.ResourceType == rsNLB {
+				released = true
+			}
+			continue
+		}
+
+		cblog.Error(err)
+		item.Status = INFRA_FAILED
+		item.Message = err.Error()
+		for _, next := range plan.ItemList[idx+1:] {
+			if next.Action == INFRA_DELETE {
+				next.Status = INFRA_SKIPPED
+			}
+		}
+		return plan, fmt.Errorf("[%s] failed to delete %s: %v", plan.ConnectionName, item.key(), err)
+	}
+	return plan, nil
+}
+
+// retry the deletion until the resources using it(ex. terminating VMs) are released.
+func deleteWithRetry(ctx context.Context, deleteFunc func(ctx context.Context) error) error {
+	waiter := NewWaiterWithContext(ctx, 5, 240) // (sleep, timeout)
+	for {
+		err := deleteFunc(ctx)
+		if err == nil {
+			return nil
+		}
+		if !waiter.Wait() {
+			return err
+		}
+	}
+}
+
+//================ Infra Records
+
+// format: the resources created by an infra
+// /resource-info-spaces/infras/<ConnectionName>/<Infra Name>/<ResourceType>:<Name> [created time]
+const infraKeyPrefix = "/resource-info-spaces/infras/"
+
+// the Infra Name of the document without the Name
+const defaultInfraName = "default"
+
+var infraStore icbs.Store
+
+func init() {
+	infraStore = cbstore.GetStore()
+}
+
+func infraRecordKey(plan *InfraPlan, itemKey string) string {
+	infraName := plan.Name
+	if infraName == "" {
+		infraName = defaultInfraName
+	}
+	return infraKeyPrefix + plan.ConnectionName + "/" + infraName + "/" + itemKey
+}
+
+func putInfraRecord(plan *InfraPlan, item *InfraPlanItem) error {
+	return infraStore.Put(infraRecordKey(plan, item.key()), time.Now().Format(time.RFC3339))
+}
+
+// true if the resource was created by the infra.
+func hasInfraRecord(plan *InfraPlan, itemKey string) (bool, error) {
+	keyValue, err := infraStore.Get(infraRecordKey(plan, itemKey))
+	if err != nil {
+		return false, err
+	}
+	return keyValue != nil, nil
+}
+
+// delete the records of the key prefix, ex) "vpc:vpc-01", "subnet:vpc-01/"
+func deleteInfraRecord(plan *InfraPlan, keyPrefix string) {
+	recordKey := infraRecordKey(plan, keyPrefix)
+	keyValueList, err := infraStore.GetList(recordKey, true)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, keyValue := range keyValueList {
+		// "vpc:vpc-01" is a prefix of "vpc:vpc-010"
+		if !strings.HasSuffix(recordKey, "/") && keyValue.Key != recordKey {
+			continue
+		}
+		if err := infraStore.Delete(keyValue.Key); err != nil {
+			cblog.Error(err)
+		}
+	}
+}
+
+//================ Infra Plan Builder
+
+type infraPlanBuilder struct {
+	doc  *InfraDoc
+	plan *InfraPlan
+
+	// the resource names in the document, rsType => names
+	docNameMap map[string]map[string]bool
+	// the subnet names in the document, VPC Name => Subnet names
+	docSubnetMap map[string]map[string]bool
+}
+
+func newInfraPlanBuilder(doc *InfraDoc) (*infraPlanBuilder, error) {
+	builder := &infraPlanBuilder{
+		doc:          doc,
+		plan:         &InfraPlan{Name: doc.Name, ConnectionName: doc.ConnectionName, ItemList: []*InfraPlanItem{}},
+		docNameMap:   map[string]map[string]bool{},
+		docSubnetMap: map[string]map[string]bool{},
+	}
+
+	addName := func(rsType string, name string) error {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("the Name of a %s in the infra document is empty!", RsTypeString(rsType))
+		}
+		if builder.docNameMap[rsType] == nil {
+			builder.docNameMap[rsType] = map[string]bool{}
+		}
+		if builder.docNameMap[rsType][name] {
+			return fmt.Errorf("the %s '%s' is duplicated in the infra document!", RsTypeString(rsType), name)
+		}
+		builder.docNameMap[rsType][name] = true
+		return nil
+	}
+
+	for _, vpc := range doc.VPCList {
+		if err := addName(rsVPC, vpc.Name); err != nil {
+			return nil, err
+		}
+		builder.docSubnetMap[vpc.Name] = map[string]bool{}
+		for _, subnet := range vpc.SubnetInfoList {
+			if strings.TrimSpace(subnet.Name) == "" {
+				return nil, fmt.Errorf("the Name of a Subnet of the VPC '%s' is empty!", vpc.Name)
+			}
+			if builder.docSubnetMap[vpc.Name][subnet.Name] {
+				return nil, fmt.Errorf("the Subnet '%s' of the VPC '%s' is duplicated in the infra document!", subnet.Name, vpc.Name)
+			}
+			builder.docSubnetMap[vpc.Name][subnet.Name] = true
+		}
+	}
+	for _, sg := range doc.SecurityGroupList {
+		if err := addName(rsSG, sg.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range doc.KeyPairList {
+		if err := addName(rsKey, key.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, disk := range doc.DiskList {
+		if err := addName(rsDisk, disk.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, vm := range doc.VMList {
+		if err := addName(rsVM, vm.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, nlb := range doc.NLBList {
+		if err := addName(rsNLB, nlb.Name); err != nil {
+			return nil, err
+		}
+	}
+	return builder, nil
+}
+
+// true if the resource exists in the IID Manager.
+func isExistInfraResource(connectionName string, rsType string, nameID string) (bool, error) {
+	var iidInfoList []*iidm.IIDInfo
+	var err error
+	switch rsType {
+	case rsSG:
+		iidInfoList, err = getAllSGIIDInfoList(connectionName)
+	case rsNLB:
+		iidInfoList, err = getAllNLBIIDInfoList(connectionName)
+	default:
+		return iidRWLock.IsExistIID(iidm.IIDSGROUP, connectionName, rsType, cres.IID{nameID, ""})
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, iidInfo := range iidInfoList {
+		if iidInfo.IId.NameId == nameID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isExistInfraSubnet(connectionName string, vpcName string, subnetName string) (bool, error) {
+	// key-value structure: ~/{SUBNETGROUP}/{ConnectionName}/{VPC-NameId}/{Subnet-reqNameId} [subnet-driverNameId:subnet-driverSystemId]  # VPC NameId => rsType
+	iidInfoList, err := iidRWLock.ListIID(iidm.SUBNETGROUP, connectionName, vpcName)
+	if err != nil {
+		return false, err
+	}
+	for _, iidInfo := range iidInfoList {
+		if iidInfo.IId.NameId == subnetName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// check the reference of the resource, it should be in the document or in the IID Manager.
+func (builder *infraPlanBuilder) checkRef(owner string, rsType string, name string) (string, error) {
+	if builder.docNameMap[rsType][name] {
+		return rsType + ":" + name, nil
+	}
+	exist, err := isExistInfraResource(builder.doc.ConnectionName, rsType, name)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", fmt.Errorf("%s: the %s '%s' does not exist in the infra document or the connection!", owner, RsTypeString(rsType), name)
+	}
+	return "", nil
+}
+
+func (builder *infraPlanBuilder) checkSubnetRef(owner string, vpcName string, subnetName string) error {
+	if builder.docSubnetMap[vpcName][subnetName] {
+		return nil
+	}
+	exist, err := isExistInfraSubnet(builder.doc.ConnectionName, vpcName, subnetName)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("%s: the Subnet '%s' of the VPC '%s' does not exist in the infra document or the connection!", owner, subnetName, vpcName)
+	}
+	return nil
+}
+
+// the dependency list of the references in the document.
+func appendDependency(dependsOn []string, ref string) []string {
+	if ref == "" {
+		return dependsOn
+	}
+	for _, one := range dependsOn {
+		if one == ref {
+			return dependsOn
+		}
+	}
+	return append(dependsOn, ref)
+}
+
+func (builder *infraPlanBuilder) addItem(item *InfraPlanItem, exist bool) {
+	if builder.plan.Mode == INFRA_APPLY {
+		item.Action = INFRA_CREATE
+		if exist {
+			item.Action = INFRA_EXISTS
+		}
+	} else {
+		item.Action = INFRA_NOTFOUND
+		if exist {
+			item.Action = INFRA_DELETE
+		}
+	}
+	builder.plan.ItemList = append(builder.plan.ItemList, item)
+}
+
+func (builder *infraPlanBuilder) setSummary() {
+	countMap := map[string]int{}
+	actionList := []string{}
+	for _, item := range builder.plan.ItemList {
+		if countMap[item.Action] == 0 {
+			actionList = append(actionList, item.Action)
+		}
+		countMap[item.Action]++
+	}
+	summaryList := []string{}
+	for _, action := range actionList {
+		summaryList = append(summaryList, fmt.Sprintf("%s: %d", action, countMap[action]))
+	}
+	builder.plan.Summary = strings.Join(summaryList, ", ")
+}
+
+// VPC(Subnet) => SecurityGroup => KeyPair => Disk => VM => NLB
+func (builder *infraPlanBuilder) applyPlan() (*InfraPlan, error) {
+	doc := builder.doc
+	plan := builder.plan
+	plan.Mode = INFRA_APPLY
+	connectionName := doc.ConnectionName
+
+	// (1) VPC and Subnet
+	for _, one := range doc.VPCList {
+		vpc := one
+		exist, err := isExistInfraResource(connectionName, rsVPC, vpc.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &InfraPlanItem{ResourceType: rsVPC, Name: vpc.Name}
+		item.run = func(ctx context.Context) error {
+			subnetInfoList := []cres.SubnetInfo{}
+			for _, subnet := range vpc.SubnetInfoList {
+				subnetInfoList = append(subnetInfoList, cres.SubnetInfo{IId: cres.IID{subnet.Name, ""}, IPv4_CIDR: subnet.IPv4_CIDR})
+			}
+			reqInfo := cres.VPCReqInfo{
+				IId:            cres.IID{vpc.Name, ""},
+				IPv4_CIDR:      vpc.IPv4_CIDR,
+				SubnetInfoList: subnetInfoList,
+				TagList:        vpc.TagList,
+			}
+			_, err := CreateVPC(connectionName, rsVPC, reqInfo)
+			return err
+		}
+		item.undo = deleteInfraFunc(connectionName, rsVPC, vpc.Name)
+		item.exist = existInfraFunc(connectionName, rsVPC, vpc.Name)
+		builder.addItem(item, exist)
+
+		if !exist {
+			continue
+		}
+		// the new Subnets of the existing VPC
+		for _, oneSubnet := range vpc.SubnetInfoList {
+			subnet := oneSubnet
+			subnetExist, err := isExistInfraSubnet(connectionName, vpc.Name, subnet.Name)
+			if err != nil {
+				return nil, err
+			}
+			subnetItem := &InfraPlanItem{ResourceType: rsSubnet, Name: vpc.Name + "/" + subnet.Name}
+			subnetItem.run = func(ctx context.Context) error {
+				reqInfo := cres.SubnetInfo{IId: cres.IID{subnet.Name, ""}, IPv4_CIDR: subnet.IPv4_CIDR}
+				_, err := AddSubnet(connectionName, rsSubnet, vpc.Name, reqInfo)
+				return err
+			}
+			subnetItem.undo = func(ctx context.Context) error {
+				_, err := RemoveSubnet(connectionName, vpc.Name, subnet.Name, "false")
+				return err
+			}
+			subnetItem.exist = func() (bool, error) {
+				return isExistInfraSubnet(connectionName, vpc.Name, subnet.Name)
+			}
+			builder.addItem(subnetItem, subnetExist)
+		}
+	}
+
+	// (2) SecurityGroup
+	for _, one := range doc.SecurityGroupList {
+		sg := one
+		item := &InfraPlanItem{ResourceType: rsSG, Name: sg.Name}
+		ref, err := builder.checkRef(rsSG+":"+sg.Name, rsVPC, sg.VPCName)
+		if err != nil {
+			return nil, err
+		}
+		item.DependsOn = appendDependency(item.DependsOn, ref)
+
+		exist, err := isExistInfraResource(connectionName, rsSG, sg.Name)
+		if err != nil {
+			return nil, err
+		}
+		item.run = func(ctx context.Context) error {
+			securityRules := sg.SecurityRules
+			reqInfo := cres.SecurityReqInfo{
+				IId:           cres.IID{sg.Name, sg.Name},
+				VpcIID:        cres.IID{sg.VPCName, ""},
+				SecurityRules: &securityRules,
+				TagList:       sg.TagList,
+			}
+			_, err := CreateSecurity(connectionName, rsSG, reqInfo)
+			return err
+		}
+		item.undo = deleteInfraFunc(connectionName, rsSG, sg.Name)
+		item.exist = existInfraFunc(connectionName, rsSG, sg.Name)
+		builder.addItem(item, exist)
+	}
+
+	// (3) KeyPair
+	for _, one := range doc.KeyPairList {
+		key := one
+		exist, err := isExistInfraResource(connectionName, rsKey, key.Name)
+		if err != nil {
+			return nil, err
+		}
+		item := &InfraPlanItem{ResourceType: rsKey, Name: key.Name}
+		item.run = func(ctx context.Context) error {
+			keyInfo, err := CreateKey(connectionName, rsKey, cres.KeyPairReqInfo{IId: cres.IID{key.Name, ""}})
+			if err != nil {
+				return err
+			}
+			plan.PrivateKeyList = append(plan.PrivateKeyList, cres.KeyValue{key.Name, keyInfo.PrivateKey})
+			return nil
+		}
+		item.undo = deleteInfraFunc(connectionName, rsKey, key.Name)
+		item.exist = existInfraFunc(connectionName, rsKey, key.Name)
+		builder.addItem(item, exist)
+	}
+
+	// (4) Disk
+	for _, one := range doc.DiskList {
+		disk := one
+		exist, err := isExistInfraResource(connectionName, rsDisk, disk.Name)
+		if err != nil {
+			return nil, err
+		}
+		item := &InfraPlanItem{ResourceType: rsDisk, Name: disk.Name}
+		item.run = func(ctx context.Context) error {
+			reqInfo := cres.DiskInfo{
+				IId:      cres.IID{disk.Name, disk.Name},
+				DiskType: disk.DiskType,
+				DiskSize: disk.DiskSize,
+				TagList:  disk.TagList,
+			}
+			_, err := CreateDisk(connectionName, rsDisk, reqInfo)
+			return err
+		}
+		item.undo = deleteInfraFunc(connectionName, rsDisk, disk.Name)
+		item.exist = existInfraFunc(connectionName, rsDisk, disk.Name)
+		builder.addItem(item, exist)
+	}
+
+	// (5) VM
+	for _, one := range doc.VMList {
+		vm := one
+		item := &InfraPlanItem{ResourceType: rsVM, Name: vm.Name}
+		owner := rsVM + ":" + vm.Name
+
+		ref, err := builder.checkRef(owner, rsVPC, vm.VPCName)
+		if err != nil {
+			return nil, err
+		}
+		item.DependsOn = appendDependency(item.DependsOn, ref)
+		if err := builder.checkSubnetRef(owner, vm.VPCName, vm.SubnetName); err != nil {
+			return nil, err
+		}
+
+		sgIIDList := []cres.IID{}
+		for _, sgName := range vm.SecurityGroupNames {
+			ref, err := builder.checkRef(owner, rsSG, sgName)
+			if err != nil {
+				return nil, err
+			}
+			item.DependsOn = appendDependency(item.DependsOn, ref)
+			sgIIDList = append(sgIIDList, cres.IID{sgName, ""})
+		}
+
+		if vm.KeyPairName != "" {
+			ref, err := builder.checkRef(owner, rsKey, vm.KeyPairName)
+			if err != nil {
+				return nil, err
+			}
+			item.DependsOn = appendDependency(item.DependsOn, ref)
+		}
+
+		diskIIDList := []cres.IID{}
+		for _, diskName := range vm.DataDiskNames {
+			ref, err := builder.checkRef(owner, rsDisk, diskName)
+			if err != nil {
+				return nil, err
+			}
+			item.DependsOn = appendDependency(item.DependsOn, ref)
+			diskIIDList = append(diskIIDList, cres.IID{diskName, ""})
+		}
+
+		exist, err := isExistInfraResource(connectionName, rsVM, vm.Name)
+		if err != nil {
+			return nil, err
+		}
+		item.run = func(ctx context.Context) error {
+			reqInfo := cres.VMReqInfo{
+				IId:               cres.IID{vm.Name, ""},
+				ImageType:         cres.ImageType(vm.ImageType),
+				ImageIID:          cres.IID{vm.ImageName, ""},
+				VpcIID:            cres.IID{vm.VPCName, ""},
+				SubnetIID:         cres.IID{vm.SubnetName, ""},
+				SecurityGroupIIDs: sgIIDList,
+
+				VMSpecName: vm.VMSpecName,
+				KeyPairIID: cres.IID{vm.KeyPairName, ""},
+
+				RootDiskType: vm.RootDiskType,
+				RootDiskSize: vm.RootDiskSize,
+
+				DataDiskIIDs: diskIIDList,
+
+				VMUserId:     vm.VMUserId,
+				VMUserPasswd: vm.VMUserPasswd,
+
+				TagList: vm.TagList,
+			}
+			_, err := StartVM(ctx, connectionName, rsVM, reqInfo)
+			return err
+		}
+		item.undo = deleteInfraFunc(connectionName, rsVM, vm.Name)
+		item.exist = existInfraFunc(connectionName, rsVM, vm.Name)
+		builder.addItem(item, exist)
+	}
+
+	// (6) NLB
+	for _, one := range doc.NLBList {
+		nlb := one
+		item := &InfraPlanItem{ResourceType: rsNLB, Name: nlb.Name}
+		owner := rsNLB + ":" + nlb.Name
+
+		ref, err := builder.checkRef(owner, rsVPC, nlb.VPCName)
+		if err != nil {
+			return nil, err
+		}
+		item.DependsOn = appendDependency(item.DependsOn, ref)
+
+		vmIIDList := []cres.IID{}
+		for _, vmName := range nlb.VMGroup.VMs {
+			ref, err := builder.checkRef(owner, rsVM, vmName)
+			if err != nil {
+				return nil, err
+			}
+			item.DependsOn = appendDependency(item.DependsOn, ref)
+			vmIIDList = append(vmIIDList, cres.IID{vmName, ""})
+		}
+
+		exist, err := isExistInfraResource(connectionName, rsNLB, nlb.Name)
+		if err != nil {
+			return nil, err
+		}
+		item.run = func(ctx context.Context) error {
+			healthChecker := nlb.HealthChecker
+			// 0 => -1(default)
+			if healthChecker.Interval == 0 {
+				healthChecker.Interval = -1
+			}
+			if healthChecker.Timeout == 0 {
+				healthChecker.Timeout = -1
+			}
+			if healthChecker.Threshold == 0 {
+				healthChecker.Threshold = -1
+			}
+			reqInfo := cres.NLBInfo{
+				IId:           cres.IID{nlb.Name, nlb.Name},
+				VpcIID:        cres.IID{nlb.VPCName, ""},
+				Type:          nlb.Type,
+				Scope:         nlb.Scope,
+				Listener:      nlb.Listener,
+				VMGroup:       cres.VMGroupInfo{Protocol: nlb.VMGroup.Protocol, Port: nlb.VMGroup.Port, VMs: &vmIIDList},
+				HealthChecker: healthChecker,
+				TagList:       nlb.TagList,
+			}
+			_, err := CreateNLB(ctx, connectionName, rsNLB, reqInfo)
+			return err
+		}
+		item.undo = deleteInfraFunc(connectionName, rsNLB, nlb.Name)
+		item.exist = existInfraFunc(connectionName, rsNLB, nlb.Name)
+		builder.addItem(item, exist)
+	}
+
+	builder.setSummary()
+	return plan, nil
+}
+
+// NLB => VM => Disk => KeyPair => SecurityGroup => Subnet => VPC
+// The Subnets are deleted with their VPC,
+// and the Subnets added to a VPC not created by the infra are deleted one by one.
+func (builder *infraPlanBuilder) destroyPlan() (*InfraPlan, error) {
+	doc := builder.doc
+	plan := builder.plan
+	plan.Mode = INFRA_DESTROY
+	connectionName := doc.ConnectionName
+
+	addItem := func(item *InfraPlanItem, exist bool) error {
+		owned := false
+		if exist {
+			var err error
+			owned, err = hasInfraRecord(plan, item.key())
+			if err != nil {
+				return err
+			}
+		}
+		builder.addItem(item, exist)
+		if exist && !owned {
+			item.Action = INFRA_NOTOWNED
+		}
+		return nil
+	}
+
+	addDeleteItem := func(rsType string, name string, dependsOn []string) error {
+		exist, err := isExistInfraResource(connectionName, rsType, name)
+		if err != nil {
+			return err
+		}
+		item := &InfraPlanItem{ResourceType: rsType, Name: name, DependsOn: dependsOn}
+		item.run = deleteInfraFunc(connectionName, rsType, name)
+		return addItem(item, exist)
+	}
+
+	// the resources in the document which use the resource should be deleted before.
+	usedByMap := map[string][]string{}
+	addUsedBy := func(rsType string, name string, user string) {
+		if builder.docNameMap[rsType][name] {
+			key := rsType + ":" + name
+			usedByMap[key] = appendDependency(usedByMap[key], user)
+		}
+	}
+	for _, nlb := range doc.NLBList {
+		for _, vmName := range nlb.VMGroup.VMs {
+			addUsedBy(rsVM, vmName, rsNLB+":"+nlb.Name)
+		}
+		addUsedBy(rsVPC, nlb.VPCName, rsNLB+":"+nlb.Name)
+	}
+	for _, vm := range doc.VMList {
+		for _, diskName := range vm.DataDiskNames {
+			addUsedBy(rsDisk, diskName, rsVM+":"+vm.Name)
+		}
+		addUsedBy(rsKey, vm.KeyPairName, rsVM+":"+vm.Name)
+		for _, sgName := range vm.SecurityGroupNames {
+			addUsedBy(rsSG, sgName, rsVM+":"+vm.Name)
+		}
+		addUsedBy(rsVPC, vm.VPCName, rsVM+":"+vm.Name)
+	}
+	for _, sg := range doc.SecurityGroupList {
+		addUsedBy(rsVPC, sg.VPCName, rsSG+":"+sg.Name)
+	}
+
+	for _, nlb := range doc.NLBList {
+		if err := addDeleteItem(rsNLB, nlb.Name, usedByMap[rsNLB+":"+nlb.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, vm := range doc.VMList {
+		if err := addDeleteItem(rsVM, vm.Name, usedByMap[rsVM+":"+vm.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, disk := range doc.DiskList {
+		if err := addDeleteItem(rsDisk, disk.Name, usedByMap[rsDisk+":"+disk.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range doc.KeyPairList {
+		if err := addDeleteItem(rsKey, key.Name, usedByMap[rsKey+":"+key.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, sg := range doc.SecurityGroupList {
+		if err := addDeleteItem(rsSG, sg.Name, usedByMap[rsSG+":"+sg.Name]); err != nil {
+			return nil, err
+		}
+	}
+	for _, one := range doc.VPCList {
+		vpc := one
+		vpcKey := rsVPC + ":" + vpc.Name
+		exist, err := isExistInfraResource(connectionName, rsVPC, vpc.Name)
+		if err != nil {
+			return nil, err
+		}
+		owned, err := hasInfraRecord(plan, vpcKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// the Subnets created by the infra in the VPC not created by the infra
+		for _, oneSubnet := range vpc.SubnetInfoList {
+			if !exist || owned {
+				break
+			}
+			subnet := oneSubnet
+			subnetExist, err := isExistInfraSubnet(connectionName, vpc.Name, subnet.Name)
+			if err != nil {
+				return nil, err
+			}
+			subnetItem := &InfraPlanItem{ResourceType: rsSubnet, Name: vpc.Name + "/" + subnet.Name, DependsOn: usedByMap[vpcKey]}
+			subnetItem.run = func(ctx context.Context) error {
+				_, err := RemoveSubnet(connectionName, vpc.Name, subnet.Name, "false")
+				return err
+			}
+			if err := addItem(subnetItem, subnetExist); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := addDeleteItem(rsVPC, vpc.Name, usedByMap[vpcKey]); err != nil {
+			return nil, err
+		}
+	}
+
+	builder.setSummary()
+	return plan, nil
+}
+
+func deleteInfraFunc(connectionName string, rsType string, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, _, err := DeleteResource(ctx, connectionName, rsType, name, "false")
+		return err
+	}
+}
+
+func existInfraFunc(connectionName string, rsType string, name string) func() (bool, error) {
+	return func() (bool, error) {
+		return isExistInfraResource(connectionName, rsType, name)
+	}
+}
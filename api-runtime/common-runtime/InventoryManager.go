@@ -0,0 +1,278 @@
+// Multi-Cloud Inventory Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+)
+
+// the default and the maximum number of the connections called at the same time
+const (
+	defaultInventoryWorkers = 10
+	maxInventoryWorkers     = 50
+)
+
+// the resource types of the inventory, the order of the result
+var inventoryRsTypeList = []string{rsVPC, rsSG, rsKey, rsVM, rsDisk, rsNLB, rsMyImage, rsCluster}
+
+type InventoryReqInfo struct {
+	ConnectionNames []string // empty: all connections
+	ProviderNames   []string // ex) ["AWS", "GCP"]
+	RegionNames     []string // the region config name or the CSP region, ex) ["aws-ohio", "us-east1"]
+	ResourceTypes   []string // empty: all, ex) ["vm", "disk"]
+	MaxWorkers      int      // 0: default(10), max 50
+}
+
+type ConnectionInventory struct {
+	Connection string
+	Provider   string
+	Region     string // ex) "us-east-2"
+
+	ResourceCount map[string]int // ex) {"vm": 3, "disk": 2}
+
+	VPCList     []*cres.VPCInfo      `json:",omitempty"`
+	SGList      []*cres.SecurityInfo `json:",omitempty"`
+	KeyPairList []*cres.KeyPairInfo  `json:",omitempty"`
+	VMList      []*cres.VMInfo       `json:",omitempty"`
+	DiskList    []*cres.DiskInfo     `json:",omitempty"`
+	NLBList     []*cres.NLBInfo      `json:",omitempty"`
+	MyImageList []*cres.MyImageInfo  `json:",omitempty"`
+	ClusterList []*cres.ClusterInfo  `json:",omitempty"`
+}
+
+// the error of a connection or a resource type of a connection
+type ConnectionError struct {
+	Connection   string
+	Provider     string
+	ResourceType string `json:",omitempty"` // empty: the error of the connection
+	Error        string
+}
+
+type InventoryInfo struct {
+	InventoryList []*ConnectionInventory
+	ErrorList     []*ConnectionError
+}
+
+// list the resources of the connections in parallel.
+// The errors of a connection or a resource type do not stop the others.
+func ListInventory(ctx context.Context, reqInfo InventoryReqInfo) (*InventoryInfo, error) {
+	cblog.Info("call ListInventory()")
+
+	rsTypeList, err := getInventoryRsTypeList(reqInfo.ResourceTypes)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	connInfoList, err := getConnectionList(reqInfo.ConnectionNames, reqInfo.ProviderNames, reqInfo.RegionNames)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	inventoryInfo := &InventoryInfo{
+		InventoryList: []*ConnectionInventory{},
+		ErrorList:     []*ConnectionError{},
+	}
+	var mutex sync.Mutex
+	runOnConnections(ctx, connInfoList, reqInfo.MaxWorkers, func(connInfo *ccim.ConnectionConfigInfo) {
+		inventory, errorList := listConnectionInventory(ctx, connInfo, rsTypeList)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if inventory != nil {
+			inventoryInfo.InventoryList = append(inventoryInfo.InventoryList, inventory)
+		}
+		inventoryInfo.ErrorList = append(inventoryInfo.ErrorList, errorList...)
+	}, func(connInfo *ccim.ConnectionConfigInfo, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		inventoryInfo.ErrorList = append(inventoryInfo.ErrorList,
+			&ConnectionError{Connection: connInfo.ConfigName, Provider: connInfo.ProviderName, Error: err.Error()})
+	})
+
+	// the order of the connections is not the order of the finish
+	sort.SliceStable(inventoryInfo.InventoryList, func(i, j int) bool {
+		return inventoryInfo.InventoryList[i].Connection < inventoryInfo.InventoryList[j].Connection
+	})
+	sort.SliceStable(inventoryInfo.ErrorList, func(i, j int) bool {
+		return inventoryInfo.ErrorList[i].Connection < inventoryInfo.ErrorList[j].Connection
+	})
+
+	return inventoryInfo, nil
+}
+
+func getInventoryRsTypeList(resourceTypes []string) ([]string, error) {
+	if len(resourceTypes) == 0 {
+		return inventoryRsTypeList, nil
+	}
+
+	rsTypeList := []string{}
+	for _, rsType := range resourceTypes {
+		rsType = strings.ToLower(strings.TrimSpace(rsType))
+		found := false
+		for _, one := range inventoryRsTypeList {
+			if one == rsType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s is not a resource type of the inventory, it should be one of %v!", rsType, inventoryRsTypeList)
+		}
+		rsTypeList = append(rsTypeList, rsType)
+	}
+	return rsTypeList, nil
+}
+
+// the connections filtered by the providers and the regions.
+// connectionNames: empty for all connections
+// regionNames: the region config names or the CSP regions(zones)
+func getConnectionList(connectionNames []string, providerNames []string, regionNames []string) ([]*ccim.ConnectionConfigInfo, error) {
+	connInfoList := []*ccim.ConnectionConfigInfo{}
+	if len(connectionNames) == 0 {
+		var err error
+		connInfoList, err = ccim.ListConnectionConfig()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, connectionName := range connectionNames {
+			connInfo, err := ccim.GetConnectionConfig(strings.TrimSpace(connectionName))
+			if err != nil {
+				return nil, err
+			}
+			connInfoList = append(connInfoList, connInfo)
+		}
+	}
+
+	filteredList := []*ccim.ConnectionConfigInfo{}
+	for _, connInfo := range connInfoList {
+		if len(providerNames) > 0 && !containsFold(providerNames, connInfo.ProviderName) {
+			continue
+		}
+		if len(regionNames) > 0 && !containsFold(regionNames, connInfo.RegionName) {
+			regionName, zoneName, err := ccm.GetRegionNameByConnectionName(connInfo.ConfigName)
+			if err != nil || !(containsFold(regionNames, regionName) || containsFold(regionNames, zoneName)) {
+				continue
+			}
+		}
+		filteredList = append(filteredList, connInfo)
+	}
+	return filteredList, nil
+}
+
+// run the function on the connections in parallel with the bounded workers.
+// The connections not started before the cancel of the ctx are passed to the cancelFunc.
+func runOnConnections(ctx context.Context, connInfoList []*ccim.ConnectionConfigInfo, workers int,
+	runFunc func(*ccim.ConnectionConfigInfo), cancelFunc func(*ccim.ConnectionConfigInfo, error)) {
+
+	if workers <= 0 {
+		workers = defaultInventoryWorkers
+	}
+	if workers > maxInventoryWorkers {
+		workers = maxInventoryWorkers
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, workers)
+	for _, one := range connInfoList {
+		connInfo := one
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				cancelFunc(connInfo, ctx.Err())
+				return
+			}
+			runFunc(connInfo)
+		}()
+	}
+	wg.Wait()
+}
+
+func containsFold(strList []string, str string) bool {
+	for _, one := range strList {
+		if strings.EqualFold(strings.TrimSpace(one), str) {
+			return true
+		}
+	}
+	return false
+}
+
+// list the resources of a connection by the resource types in order.
+func listConnectionInventory(ctx context.Context, connInfo *ccim.ConnectionConfigInfo, rsTypeList []string) (*ConnectionInventory, []*ConnectionError) {
+	errorList := []*ConnectionError{}
+
+	inventory := &ConnectionInventory{
+		Connection:    connInfo.ConfigName,
+		Provider:      connInfo.ProviderName,
+		ResourceCount: map[string]int{},
+	}
+	regionName, _, err := ccm.GetRegionNameByConnectionName(connInfo.ConfigName)
+	if err != nil {
+		errorList = append(errorList, &ConnectionError{Connection: connInfo.ConfigName, Provider: connInfo.ProviderName, Error: err.Error()})
+		return nil, errorList
+	}
+	inventory.Region = regionName
+
+	for _, rsType := range rsTypeList {
+		if ctx.Err() != nil {
+			errorList = append(errorList, &ConnectionError{connInfo.ConfigName, connInfo.ProviderName, rsType, ctx.Err().Error()})
+			continue
+		}
+
+		count := 0
+		var err error
+		switch rsType {
+		case rsVPC:
+			inventory.VPCList, err = ListVPC(connInfo.ConfigName, rsType)
+			count = len(inventory.VPCList)
+		case rsSG:
+			inventory.SGList, err = ListSecurity(connInfo.ConfigName, rsType)
+			count = len(inventory.SGList)
+		case rsKey:
+			inventory.KeyPairList, err = ListKey(connInfo.ConfigName, rsType)
+			count = len(inventory.KeyPairList)
+		case rsVM:
+			inventory.VMList, err = ListVM(ctx, connInfo.ConfigName, rsType)
+			count = len(inventory.VMList)
+		case rsDisk:
+			inventory.DiskList, err = ListDisk(connInfo.ConfigName, rsType)
+			count = len(inventory.DiskList)
+		case rsNLB:
+			inventory.NLBList, err = ListNLB(connInfo.ConfigName, rsType)
+			count = len(inventory.NLBList)
+		case rsMyImage:
+			inventory.MyImageList, err = ListMyImage(connInfo.ConfigName, rsType)
+			count = len(inventory.MyImageList)
+		case rsCluster:
+			inventory.ClusterList, err = ListCluster(connInfo.ConfigName, "", rsType)
+			count = len(inventory.ClusterList)
+		}
+		if err != nil {
+			errorList = append(errorList, &ConnectionError{connInfo.ConfigName, connInfo.ProviderName, rsType, err.Error()})
+			continue
+		}
+		inventory.ResourceCount[rsType] = count
+	}
+	return inventory, errorList
+}
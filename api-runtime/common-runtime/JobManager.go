@@ -0,0 +1,328 @@
+// Async Job Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+)
+
+// Job runs a long-running resource operation(ex. StartVM, CreateCluster) in background,
+// and keeps its state in cb-store, so clients can poll the result with the Job ID.
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "Pending"
+	JobRunning   JobStatus = "Running"
+	JobSucceeded JobStatus = "Succeeded"
+	JobFailed    JobStatus = "Failed"
+)
+
+type JobInfo struct {
+	JobId          string          // ex) "cd2h1ssdtpe2sq2v3a6g"
+	ConnectionName string          // ex) "aws-seoul-config"
+	ResourceType   string          // ex) "vm"
+	ResourceName   string          // ex) "vm-01"
+	Status         JobStatus       // ex) "Running"
+	Result         json.RawMessage `json:",omitempty"` // the result of the operation, ex) VMInfo
+	Error          string          `json:",omitempty"`
+	CreatedTime    time.Time
+	UpdatedTime    time.Time
+}
+
+// JobFunc is the operation run by a Job.
+type JobFunc func() (interface{}, error)
+
+// the finished Job is kept for the TTL, and purged after it.
+var jobTTL = 24 * time.Hour
+var jobTTLLock sync.RWMutex
+
+const jobPurgeInterval = 10 * time.Minute
+
+var jobStore icbs.Store
+
+// orders the updates of the Job status, a finished Job is not changed.
+var jobMutex sync.Mutex
+
+var jobPurgeMutex sync.Mutex
+var lastJobPurge time.Time
+
+func init() {
+	jobStore = cbstore.GetStore()
+}
+
+// format
+// /job-spaces/jobs/<JobId> [JobInfo(json)]
+const jobKeyPrefix = "/job-spaces/jobs/"
+
+// 1. create a Pending Job and save it
+// 2. run the operation in background
+// 3. return the Job Info without waiting
+func SubmitJob(connectionName string, rsType string, rsName string, jobFunc JobFunc) (*JobInfo, error) {
+	cblog.Info("call SubmitJob()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	jobInfo := JobInfo{
+		JobId:          xid.New().String(),
+		ConnectionName: connectionName,
+		ResourceType:   rsType,
+		ResourceName:   rsName,
+		Status:         JobPending,
+		CreatedTime:    now,
+		UpdatedTime:    now,
+	}
+	err = putJob(&jobInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	retInfo := jobInfo
+	go runJob(jobInfo, jobFunc)
+	go purgeExpiredJobs()
+
+	return &retInfo, nil
+}
+
+// SetJobTTL changes the TTL of the finished Jobs, ex) for the tests.
+func SetJobTTL(ttl time.Duration) {
+	jobTTLLock.Lock()
+	defer jobTTLLock.Unlock()
+	jobTTL = ttl
+}
+
+func getJobTTL() time.Duration {
+	jobTTLLock.RLock()
+	defer jobTTLLock.RUnlock()
+	return jobTTL
+}
+
+func runJob(jobInfo JobInfo, jobFunc JobFunc) {
+	defer func() {
+		// a panic of the operation should not kill the server
+		if r := recover(); r != nil {
+			finishJob(&jobInfo, nil, fmt.Errorf("%v", r))
+		}
+	}()
+
+	jobInfo.Status = JobRunning
+	jobInfo.UpdatedTime = time.Now()
+	err := updateJob(&jobInfo)
+	if err != nil {
+		cblog.Error(err)
+	}
+
+	result, err := jobFunc()
+	finishJob(&jobInfo, result, err)
+}
+
+func finishJob(jobInfo *JobInfo, result interface{}, jobErr error) {
+	if jobErr != nil {
+		jobInfo.Status = JobFailed
+		jobInfo.Error = jobErr.Error()
+	} else {
+		jobInfo.Status = JobSucceeded
+		jsonResult, err := json.Marshal(result)
+		if err != nil {
+			jobInfo.Status = JobFailed
+			jobInfo.Error = err.Error()
+		} else {
+			jobInfo.Result = jsonResult
+		}
+	}
+	jobInfo.UpdatedTime = time.Now()
+
+	err := updateJob(jobInfo)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	cblog.Infof("Job %s(%s:%s) is %s.", jobInfo.JobId, jobInfo.ResourceType, jobInfo.ResourceName, jobInfo.Status)
+}
+
+// save the status of a Job, the finished Job is not changed.
+// ex) the Job failed by RecoverJobs() is not changed by the late runJob(), and vice versa.
+func updateJob(jobInfo *JobInfo) error {
+	jobMutex.Lock()
+	defer jobMutex.Unlock()
+
+	keyValue, err := jobStore.Get(jobKeyPrefix + jobInfo.JobId)
+	if err != nil {
+		return err
+	}
+	if keyValue != nil {
+		storedInfo, err := unmarshalJob(keyValue.Value)
+		if err != nil {
+			return err
+		}
+		if isJobFinished(storedInfo) {
+			return fmt.Errorf("%s Job is already %s, it is not changed to %s!", jobInfo.JobId, storedInfo.Status, jobInfo.Status)
+		}
+	}
+	return putJob(jobInfo)
+}
+
+func GetJob(jobId string) (*JobInfo, error) {
+	cblog.Info("call GetJob()")
+
+	// check empty and trim user inputs
+	jobId, err := EmptyCheckAndTrim("jobId", jobId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	keyValue, err := jobStore.Get(jobKeyPrefix + jobId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if keyValue == nil {
+		return nil, fmt.Errorf("%s Job does not exist!", jobId)
+	}
+
+	jobInfo, err := unmarshalJob(keyValue.Value)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if isJobExpired(jobInfo) {
+		return nil, fmt.Errorf("%s Job does not exist!", jobId)
+	}
+	return jobInfo, nil
+}
+
+// list the Jobs of a connection, in order of creation
+func ListJob(connectionName string) ([]*JobInfo, error) {
+	cblog.Info("call ListJob()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	keyValueList, err := jobStore.GetList(jobKeyPrefix, true)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	jobInfoList := []*JobInfo{}
+	for _, kv := range keyValueList {
+		jobInfo, err := unmarshalJob(kv.Value)
+		if err != nil {
+			cblog.Error(err)
+			continue
+		}
+		if jobInfo.ConnectionName == connectionName && !isJobExpired(jobInfo) {
+			jobInfoList = append(jobInfoList, jobInfo)
+		}
+	}
+
+	sort.SliceStable(jobInfoList, func(i, j int) bool {
+		return jobInfoList[i].CreatedTime.Before(jobInfoList[j].CreatedTime)
+	})
+
+	return jobInfoList, nil
+}
+
+// mark the Jobs, which were Pending or Running when the server was stopped, as Failed.
+// called at the server start, before any Job is submitted.
+func RecoverJobs() {
+	keyValueList, err := jobStore.GetList(jobKeyPrefix, true)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, kv := range keyValueList {
+		jobInfo, err := unmarshalJob(kv.Value)
+		if err != nil {
+			cblog.Error(err)
+			continue
+		}
+		if jobInfo.Status == JobPending || jobInfo.Status == JobRunning {
+			finishJob(jobInfo, nil, fmt.Errorf("The server was stopped while the Job was %s.", jobInfo.Status))
+		}
+	}
+}
+
+func isJobFinished(jobInfo *JobInfo) bool {
+	return jobInfo.Status == JobSucceeded || jobInfo.Status == JobFailed
+}
+
+func isJobExpired(jobInfo *JobInfo) bool {
+	if !isJobFinished(jobInfo) {
+		return false
+	}
+	return time.Since(jobInfo.UpdatedTime) > getJobTTL()
+}
+
+// delete the expired Jobs, at most once in the purge interval.
+func purgeExpiredJobs() {
+	jobPurgeMutex.Lock()
+	defer jobPurgeMutex.Unlock()
+
+	if time.Since(lastJobPurge) < jobPurgeInterval {
+		return
+	}
+	lastJobPurge = time.Now()
+
+	keyValueList, err := jobStore.GetList(jobKeyPrefix, true)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, kv := range keyValueList {
+		jobInfo, err := unmarshalJob(kv.Value)
+		if err != nil {
+			cblog.Error(err)
+			continue
+		}
+		if isJobExpired(jobInfo) {
+			if err := jobStore.Delete(kv.Key); err != nil {
+				cblog.Error(err)
+			}
+		}
+	}
+}
+
+func putJob(jobInfo *JobInfo) error {
+	jsonValue, err := json.Marshal(jobInfo)
+	if err != nil {
+		return err
+	}
+	return jobStore.Put(jobKeyPrefix+jobInfo.JobId, string(jsonValue))
+}
+
+func unmarshalJob(value string) (*JobInfo, error) {
+	jobInfo := JobInfo{}
+	err := json.Unmarshal([]byte(value), &jobInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &jobInfo, nil
+}
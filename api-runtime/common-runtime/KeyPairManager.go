@@ -23,8 +23,9 @@ import (
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterKey(connectionName string, userIID cres.IID) (*cres.KeyPairInfo, error) {
+func RegisterKey(connectionName string, userIID cres.IID) (_ *cres.KeyPairInfo, retErr error) {
         cblog.Info("call RegisterKey()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsKey, userIID.NameId, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -49,6 +50,7 @@ func RegisterKey(connectionName string, userIID cres.IID) (*cres.KeyPairInfo, er
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateKeyPairHandler()
         if err != nil {
@@ -108,8 +110,9 @@ func RegisterKey(connectionName string, userIID cres.IID) (*cres.KeyPairInfo, er
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func CreateKey(connectionName string, rsType string, reqInfo cres.KeyPairReqInfo) (*cres.KeyPairInfo, error) {
+func CreateKey(connectionName string, rsType string, reqInfo cres.KeyPairReqInfo) (_ *cres.KeyPairInfo, retErr error) {
 	cblog.Info("call CreateKey()")
+	defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -133,6 +136,7 @@ func CreateKey(connectionName string, rsType string, reqInfo cres.KeyPairReqInfo
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateKeyPairHandler()
 	if err != nil {
@@ -225,6 +229,7 @@ func ListKey(connectionName string, rsType string) ([]*cres.KeyPairInfo, error)
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateKeyPairHandler()
 	if err != nil {
@@ -302,6 +307,7 @@ func GetKey(connectionName string, rsType string, nameID string) (*cres.KeyPairI
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateKeyPairHandler()
 	if err != nil {
@@ -332,3 +338,35 @@ func GetKey(connectionName string, rsType string, nameID string) (*cres.KeyPairI
 
 	return &info, nil
 }
+
+// get the private key kept by the driver, for Spider's own SSH access to VMs.
+// Do not return it to users.
+func getKeyPrivateKey(connectionName string, nameID string) (string, error) {
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return "", err
+	}
+	defer cldConn.Close()
+
+	handler, err := cldConn.CreateKeyPairHandler()
+	if err != nil {
+		return "", err
+	}
+
+	keySPLock.RLock(connectionName, nameID)
+	defer keySPLock.RUnlock(connectionName, nameID)
+
+	iidInfo, err := iidRWLock.GetIID(iidm.IIDSGROUP, connectionName, rsKey, cres.IID{NameId: nameID, SystemId: ""})
+	if err != nil {
+		return "", err
+	}
+
+	info, err := handler.GetKey(getDriverIID(iidInfo.IId))
+	if err != nil {
+		return "", err
+	}
+	if info.PrivateKey == "" {
+		return "", fmt.Errorf("The private key of %s '%s' is not kept by Spider!", RsTypeString(rsKey), nameID)
+	}
+	return info.PrivateKey, nil
+}
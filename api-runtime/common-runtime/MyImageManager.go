@@ -24,8 +24,9 @@ import (
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterMyImage(connectionName string, userIID cres.IID) (*cres.MyImageInfo, error) {
+func RegisterMyImage(connectionName string, userIID cres.IID) (_ *cres.MyImageInfo, retErr error) {
         cblog.Info("call RegisterMyImage()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsMyImage, userIID.NameId, retErr) }()
 
         // check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -50,6 +51,7 @@ func RegisterMyImage(connectionName string, userIID cres.IID) (*cres.MyImageInfo
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateMyImageHandler()
         if err != nil {
@@ -108,8 +110,9 @@ func RegisterMyImage(connectionName string, userIID cres.IID) (*cres.MyImageInfo
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func SnapshotVM(connectionName string, rsType string, reqInfo cres.MyImageInfo) (*cres.MyImageInfo, error) {
+func SnapshotVM(connectionName string, rsType string, reqInfo cres.MyImageInfo) (_ *cres.MyImageInfo, retErr error) {
         cblog.Info("call SnapshotVM()")
+        defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
         // check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -134,6 +137,7 @@ func SnapshotVM(connectionName string, rsType string, reqInfo cres.MyImageInfo)
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateMyImageHandler()
         if err != nil {
@@ -245,6 +249,7 @@ func ListMyImage(connectionName string, rsType string) ([]*cres.MyImageInfo, err
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateMyImageHandler()
         if err != nil {
@@ -327,6 +332,7 @@ func GetMyImage(connectionName string, rsType string, nameID string) (*cres.MyIm
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateMyImageHandler()
         if err != nil {
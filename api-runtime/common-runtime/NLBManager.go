@@ -9,6 +9,7 @@
 package commonruntime
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"errors"
@@ -16,6 +17,8 @@ import (
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
 	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
 )
 
 
@@ -44,6 +47,7 @@ func GetNLBOwnerVPC(connectionName string, cspID string) (owerVPC cres.IID, err
                 cblog.Error(err)
                 return cres.IID{}, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -127,8 +131,9 @@ func GetNLBOwnerVPC(connectionName string, cspID string) (owerVPC cres.IID, err
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterNLB(connectionName string, vpcUserID string, userIID cres.IID) (*cres.NLBInfo, error) {
+func RegisterNLB(connectionName string, vpcUserID string, userIID cres.IID) (_ *cres.NLBInfo, retErr error) {
         cblog.Info("call RegisterNLB()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsNLB, userIID.NameId, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -159,6 +164,7 @@ func RegisterNLB(connectionName string, vpcUserID string, userIID cres.IID) (*cr
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -256,7 +262,9 @@ func RegisterNLB(connectionName string, vpcUserID string, userIID cres.IID) (*cr
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func CreateNLB(connectionName string, rsType string, reqInfo cres.NLBInfo) (*cres.NLBInfo, error) { cblog.Info("call CreateNLB()")
+func CreateNLB(ctx context.Context, connectionName string, rsType string, reqInfo cres.NLBInfo) (_ *cres.NLBInfo, retErr error) {
+	cblog.Info("call CreateNLB()")
+	defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -271,6 +279,7 @@ func CreateNLB(connectionName string, rsType string, reqInfo cres.NLBInfo) (*cre
                 "resources.IID:SystemId",
                 "resources.SecurityReqInfo:Direction", // because can be unused in some CSP
                 "resources.SecurityRuleInfo:CIDR",     // because can be set without soruce CIDR
+                "resources.KeyValue:Value",            // because a tag can have an empty value
         }
 
         err = ValidateStruct(reqInfo, emptyPermissionList)
@@ -305,11 +314,12 @@ defer vpcSPLock.RUnlock(connectionName, reqInfo.VpcIID.NameId)
 	}
         //+++++++++++++++++++++++++++++++++++++++++++
 
-	cldConn, err := ccm.GetCloudConnection(connectionName)
+	cldConn, err := ccm.GetCloudConnectionWithContext(ctx, connectionName)
 	if err != nil {
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateNLBHandler()
 	if err != nil {
@@ -323,6 +333,12 @@ defer vpcSPLock.RUnlock(connectionName, reqInfo.VpcIID.NameId)
 nlbSPLock.Lock(connectionName, reqInfo.IId.NameId)
 defer nlbSPLock.Unlock(connectionName, reqInfo.IId.NameId)
 
+	// the request can be canceled while waiting for the lock
+	err = checkCanceled(ctx, connectionName, call.NLB, reqInfo.IId.NameId, "CB-Spider:CreateNLB()")
+	if err != nil {
+		return nil, err
+	}
+
 	// (1) check exist(NameID)
 	iidInfoList, err := getAllNLBIIDInfoList(connectionName)
 	if err != nil {
@@ -492,6 +508,7 @@ func ListNLB(connectionName string, rsType string) ([]*cres.NLBInfo, error) {
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateNLBHandler()
 	if err != nil {
@@ -613,6 +630,7 @@ func GetNLB(connectionName string, rsType string, nameID string) (*cres.NLBInfo,
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateNLBHandler()
 	if err != nil {
@@ -703,6 +721,7 @@ func AddNLBVMs(connectionName string, nlbName string, vmNames []string) (*cres.N
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -822,6 +841,7 @@ func RemoveNLBVMs(connectionName string, nlbName string, vmNames []string) (bool
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -917,6 +937,7 @@ func ChangeListener(connectionName string, nlbName string, listener cres.Listene
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -1030,6 +1051,7 @@ func ChangeVMGroup(connectionName string, nlbName string, vmGroup cres.VMGroupIn
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -1140,6 +1162,7 @@ func ChangeHealthChecker(connectionName string, nlbName string, healthChecker cr
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
@@ -1250,6 +1273,7 @@ func GetVMGroupHealthInfo(connectionName string, nlbName string) (*cres.HealthIn
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateNLBHandler()
         if err != nil {
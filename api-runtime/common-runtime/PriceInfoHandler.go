@@ -0,0 +1,297 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	icon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/connect"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// the hours of a month for the monthly cost
+const hoursPerMonth = 730
+
+// the resource types of the cost items
+const (
+	COST_VMSPEC   = "vmspec"
+	COST_ROOTDISK = "rootdisk"
+	COST_DATADISK = "datadisk"
+)
+
+type CostItem struct {
+	ResourceType string // COST_VMSPEC, COST_ROOTDISK or COST_DATADISK
+	Name         string // the VMSpec name, the root disk type or the data disk name
+	Quantity     string // 1 for the VMSpec, the size(GB) for the disks
+	Unit         string // cres.PRICE_UNIT_HOUR or cres.PRICE_UNIT_GB_MONTH
+	UnitPrice    string
+	HourlyCost   string
+	MonthlyCost  string
+}
+
+type VMCostEstimateInfo struct {
+	ConnectionName string
+	Currency       string
+	HourlyCost     string
+	MonthlyCost    string // 730 hours
+	ItemList       []*CostItem
+	NoteList       []string `json:",omitempty"` // the items not priced
+
+	hourlyCost float64 // the sum before the rounding
+}
+
+//================ PriceInfo Handler
+func ListVMSpecPrice(connectionName string) ([]*cres.PriceInfo, error) {
+	cblog.Info("call ListVMSpecPrice()")
+
+	cldConn, handler, err := getPriceInfoHandler(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	infoList, err := handler.ListVMSpecPrice()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if infoList == nil || len(infoList) <= 0 {
+		infoList = []*cres.PriceInfo{}
+	}
+
+	return infoList, nil
+}
+
+func GetVMSpecPrice(connectionName string, specName string) (*cres.PriceInfo, error) {
+	cblog.Info("call GetVMSpecPrice()")
+
+	specName, err := EmptyCheckAndTrim("specName", specName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, handler, err := getPriceInfoHandler(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	info, err := handler.GetVMSpecPrice(specName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func ListDiskPrice(connectionName string) ([]*cres.PriceInfo, error) {
+	cblog.Info("call ListDiskPrice()")
+
+	cldConn, handler, err := getPriceInfoHandler(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	infoList, err := handler.ListDiskPrice()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if infoList == nil || len(infoList) <= 0 {
+		infoList = []*cres.PriceInfo{}
+	}
+
+	return infoList, nil
+}
+
+func GetDiskPrice(connectionName string, diskType string) (*cres.PriceInfo, error) {
+	cblog.Info("call GetDiskPrice()")
+
+	diskType, err := EmptyCheckAndTrim("diskType", diskType)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, handler, err := getPriceInfoHandler(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	info, err := handler.GetDiskPrice(diskType)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// The caller should Close() the returned connection after the calls of the handler.
+func getPriceInfoHandler(connectionName string) (icon.CloudConnection, cres.PriceInfoHandler, error) {
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handler, err := cldConn.CreatePriceInfoHandler()
+	if err != nil {
+		cldConn.Close()
+		return nil, nil, err
+	}
+	return cldConn, handler, nil
+}
+
+// estimate the on-demand cost of the VM request before StartVM.
+// The VMSpec, the root disk and the attached data disks are priced.
+// The root disk of the CSP default type or size is not priced and noted in the NoteList.
+func EstimateVMCost(connectionName string, reqInfo cres.VMReqInfo) (*VMCostEstimateInfo, error) {
+	cblog.Info("call EstimateVMCost()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	specName, err := EmptyCheckAndTrim("reqInfo.VMSpecName", reqInfo.VMSpecName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, handler, err := getPriceInfoHandler(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	estimateInfo := &VMCostEstimateInfo{
+		ConnectionName: connectionName,
+		ItemList:       []*CostItem{},
+	}
+
+	// (1) VMSpec
+	specPrice, err := handler.GetVMSpecPrice(specName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if err := estimateInfo.addItem(COST_VMSPEC, specName, 1, specPrice); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) root disk
+	rootDiskType := strings.TrimSpace(reqInfo.RootDiskType)
+	rootDiskSize, sizeErr := strconv.ParseFloat(strings.TrimSpace(reqInfo.RootDiskSize), 64)
+	if rootDiskType == "" || strings.EqualFold(rootDiskType, "default") || sizeErr != nil {
+		estimateInfo.NoteList = append(estimateInfo.NoteList,
+			"The root disk of the default type or size is not priced, RootDiskType: '"+reqInfo.RootDiskType+"', RootDiskSize: '"+reqInfo.RootDiskSize+"'")
+	} else {
+		diskPrice, err := handler.GetDiskPrice(rootDiskType)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if err := estimateInfo.addItem(COST_ROOTDISK, rootDiskType, rootDiskSize, diskPrice); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	// (3) data disks
+	for _, diskIID := range reqInfo.DataDiskIIDs {
+		diskInfo, err := GetDisk(connectionName, rsDisk, diskIID.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		diskSize, err := strconv.ParseFloat(diskInfo.DiskSize, 64)
+		if err != nil {
+			estimateInfo.NoteList = append(estimateInfo.NoteList,
+				"The data disk("+diskIID.NameId+") of the size '"+diskInfo.DiskSize+"' is not priced")
+			continue
+		}
+		diskPrice, err := handler.GetDiskPrice(diskInfo.DiskType)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if err := estimateInfo.addItem(COST_DATADISK, diskIID.NameId, diskSize, diskPrice); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	estimateInfo.HourlyCost = formatCost(estimateInfo.hourlyCost)
+	estimateInfo.MonthlyCost = formatCost(estimateInfo.hourlyCost * hoursPerMonth)
+
+	return estimateInfo, nil
+}
+
+func (estimateInfo *VMCostEstimateInfo) addItem(rsType string, name string, quantity float64, priceInfo cres.PriceInfo) error {
+	if estimateInfo.Currency == "" {
+		estimateInfo.Currency = priceInfo.Currency
+	} else if estimateInfo.Currency != priceInfo.Currency {
+		return fmt.Errorf("The currency(%s) of %s is not %s!", priceInfo.Currency, name, estimateInfo.Currency)
+	}
+
+	unitPrice, err := strconv.ParseFloat(priceInfo.OnDemandPrice, 64)
+	if err != nil {
+		return fmt.Errorf("The price(%s) of %s is not a number!", priceInfo.OnDemandPrice, name)
+	}
+
+	var hourlyCost float64
+	switch priceInfo.Unit {
+	case cres.PRICE_UNIT_HOUR:
+		hourlyCost = unitPrice * quantity
+	case cres.PRICE_UNIT_GB_MONTH:
+		hourlyCost = unitPrice * quantity / hoursPerMonth
+	default:
+		return fmt.Errorf("The price unit(%s) of %s is not supported!", priceInfo.Unit, name)
+	}
+
+	estimateInfo.hourlyCost += hourlyCost
+	estimateInfo.ItemList = append(estimateInfo.ItemList, &CostItem{
+		ResourceType: rsType,
+		Name:         name,
+		Quantity:     strconv.FormatFloat(quantity, 'f', -1, 64),
+		Unit:         priceInfo.Unit,
+		UnitPrice:    priceInfo.OnDemandPrice,
+		HourlyCost:   formatCost(hourlyCost),
+		MonthlyCost:  formatCost(hourlyCost * hoursPerMonth),
+	})
+	return nil
+}
+
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 4, 64)
+}
@@ -55,6 +55,7 @@ func CreateImage(connectionName string, rsType string, reqInfo cres.ImageReqInfo
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateImageHandler()
 	if err != nil {
@@ -144,6 +145,7 @@ func ListImage(connectionName string, rsType string) ([]*cres.ImageInfo, error)
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateImageHandler()
 	if err != nil {
@@ -209,6 +211,7 @@ func ListRegisterImage(connectionName string, rsType string) ([]*cres.ImageInfo,
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateImageHandler()
 	if err != nil {
@@ -288,6 +291,7 @@ func GetImage(connectionName string, rsType string, nameID string) (*cres.ImageI
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateImageHandler()
 	if err != nil {
@@ -331,6 +335,7 @@ func GetRegisterImage(connectionName string, rsType string, nameID string) (*cre
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateImageHandler()
 	if err != nil {
@@ -392,6 +397,7 @@ func DeleteImage(connectionName string, rsType string, nameID string) (bool, err
 		cblog.Error(err)
 		return false, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateImageHandler()
 	if err != nil {
@@ -0,0 +1,744 @@
+// Drift and Orphan Reconcile Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	im "github.com/cloud-barista/cb-spider/cloud-info-manager"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+)
+
+//================ Reconcile Policy and Drift Report
+
+// the minimum interval of the scheduled reconciliation
+const minReconcileIntervalSec = 60
+
+// the resource types to reconcile, the order of ListAllResource()
+var reconcileRsTypeList = []string{rsVPC, rsSG, rsKey, rsVM, rsDisk, rsNLB, rsMyImage, rsCluster}
+
+type ReconcilePolicy struct {
+	ConnectionName      string
+	IntervalSec         int      // the interval of the scheduled reconciliation, ex) 3600
+	ResourceTypeList    []string `json:",omitempty"` // empty: all, ex) ["vpc", "sg", "vm"]
+	AutoUnregisterStale bool     // unregister the IIDs whose CSP resources vanished
+	AutoRegisterOrphan  bool     // register the CSP resources not in Spider with their CSP names
+}
+
+// action of the drift item
+// The stale IIDs and the orphans are handled only if they are found again by the next reconciliation,
+// because a resource being created or deleted by Spider is found as the drift for a while,
+// ex) the VM created by the CSP before its IID is inserted.
+const (
+	DRIFT_PENDING      = "pending"
+	DRIFT_UNREGISTERED = "unregistered"
+	DRIFT_REGISTERED   = "registered"
+	DRIFT_FAILED       = "failed"
+)
+
+type DriftItem struct {
+	ResourceType string
+	IId          cres.IID // Stale: {Spider Name, CSP SystemId}, Orphan: {CSP NameId, CSP SystemId}
+	Detail       string   `json:",omitempty"` // ex) "added: [inbound/TCP/80-80/0.0.0.0/0]"
+	Action       string   `json:",omitempty"` // pending | unregistered | registered | failed
+	Message      string   `json:",omitempty"`
+}
+
+type DriftReport struct {
+	ConnectionName string
+	StartTime      time.Time
+	EndTime        time.Time
+
+	StaleList   []*DriftItem // in Spider, but the CSP resource vanished
+	OrphanList  []*DriftItem // in CSP, but not in Spider
+	ChangedList []*DriftItem // SecurityGroup rules changed outside Spider
+
+	ErrorList []string `json:",omitempty"` // ex) "nlb: not supported"
+}
+
+// format
+// /reconcile-spaces/policies/<ConnectionName> [ReconcilePolicy(json)]
+// /reconcile-spaces/reports/<ConnectionName> [DriftReport(json)]
+// /reconcile-spaces/sgrules/<ConnectionName>/<SG NameId> [sorted rule list(json)]
+const (
+	reconcilePolicyKeyPrefix = "/reconcile-spaces/policies/"
+	reconcileReportKeyPrefix = "/reconcile-spaces/reports/"
+	sgRuleBaselineKeyPrefix  = "/reconcile-spaces/sgrules/"
+)
+
+var reconcileStore icbs.Store
+
+// ConnectionName => cancel of the scheduled reconciler
+var reconcilerCancelMap = map[string]context.CancelFunc{}
+
+// ConnectionName => true while reconciling
+var reconcileRunningMap = map[string]bool{}
+
+var reconcilerMutex sync.Mutex
+
+func init() {
+	reconcileStore = cbstore.GetStore()
+	// remove the policy of a deleted connection
+	im.AddInfoChangeHandler(removeConnectionReconcile)
+}
+
+//================ Reconcile Policy
+
+// set the policy and (re)start the scheduled reconciler of the connection.
+func SetReconcilePolicy(policy ReconcilePolicy) (*ReconcilePolicy, error) {
+	cblog.Info("call SetReconcilePolicy()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("ConnectionName", policy.ConnectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	policy.ConnectionName = connectionName
+
+	if _, err := ccim.GetConnectionConfig(connectionName); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if policy.IntervalSec < minReconcileIntervalSec {
+		err := fmt.Errorf("IntervalSec(%d) should be %d or more!", policy.IntervalSec, minReconcileIntervalSec)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	rsTypeList := []string{}
+	for _, rsType := range policy.ResourceTypeList {
+		rsType = strings.ToLower(strings.TrimSpace(rsType))
+		if !isReconcileRsType(rsType) {
+			err := fmt.Errorf("%s is not a resource type to reconcile, it should be one of %v!", rsType, reconcileRsTypeList)
+			cblog.Error(err)
+			return nil, err
+		}
+		rsTypeList = append(rsTypeList, rsType)
+	}
+	policy.ResourceTypeList = rsTypeList
+
+	if err := putReconcileValue(reconcilePolicyKeyPrefix+connectionName, &policy); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	startReconciler(policy)
+	return &policy, nil
+}
+
+func ListReconcilePolicy() ([]*ReconcilePolicy, error) {
+	cblog.Info("call ListReconcilePolicy()")
+
+	keyValueList, err := reconcileStore.GetList(reconcilePolicyKeyPrefix, true)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	policyList := []*ReconcilePolicy{}
+	for _, kv := range keyValueList {
+		policy := ReconcilePolicy{}
+		if err := json.Unmarshal([]byte(kv.Value), &policy); err != nil {
+			cblog.Error(err)
+			continue
+		}
+		policyList = append(policyList, &policy)
+	}
+	return policyList, nil
+}
+
+func GetReconcilePolicy(connectionName string) (*ReconcilePolicy, error) {
+	cblog.Info("call GetReconcilePolicy()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("ConnectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	policy := ReconcilePolicy{}
+	exist, err := getReconcileValue(reconcilePolicyKeyPrefix+connectionName, &policy)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if !exist {
+		return nil, fmt.Errorf("the reconcile policy of %s does not exist!", connectionName)
+	}
+	return &policy, nil
+}
+
+// remove the policy and stop the scheduled reconciler of the connection.
+func RemoveReconcilePolicy(connectionName string) (bool, error) {
+	cblog.Info("call RemoveReconcilePolicy()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("ConnectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	if _, err := GetReconcilePolicy(connectionName); err != nil {
+		return false, err
+	}
+
+	stopReconciler(connectionName)
+
+	if err := reconcileStore.Delete(reconcilePolicyKeyPrefix + connectionName); err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// remove the policy, the report and the SecurityGroup rule baselines of a deleted connection.
+func removeConnectionReconcile(kind im.InfoKind, connectionName string) {
+	if kind != im.CONNECTIONCONFIG {
+		return
+	}
+	// called after the connection is created or deleted
+	cncInfoList, err := ccim.ListConnectionConfig()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, cncInfo := range cncInfoList {
+		if cncInfo.ConfigName == connectionName {
+			return
+		}
+	}
+
+	stopReconciler(connectionName)
+
+	keyList := []string{reconcilePolicyKeyPrefix + connectionName, reconcileReportKeyPrefix + connectionName}
+	keyValueList, err := reconcileStore.GetList(sgRuleBaselineKeyPrefix+connectionName+"/", true)
+	if err != nil {
+		cblog.Error(err)
+	}
+	for _, kv := range keyValueList {
+		keyList = append(keyList, kv.Key)
+	}
+	for _, key := range keyList {
+		keyValue, err := reconcileStore.Get(key)
+		if err != nil || keyValue == nil {
+			continue
+		}
+		if err := reconcileStore.Delete(key); err != nil {
+			cblog.Error(err)
+		}
+	}
+}
+
+// start the scheduled reconcilers of the stored policies, called at the server start.
+func StartReconcilers() {
+	policyList, err := ListReconcilePolicy()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for _, policy := range policyList {
+		startReconciler(*policy)
+	}
+}
+
+func startReconciler(policy ReconcilePolicy) {
+	reconcilerMutex.Lock()
+	defer reconcilerMutex.Unlock()
+
+	if cancel, ok := reconcilerCancelMap[policy.ConnectionName]; ok {
+		cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	reconcilerCancelMap[policy.ConnectionName] = cancel
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(policy.IntervalSec) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := reconcile(policy); err != nil {
+					cblog.Error(err)
+				}
+			}
+		}
+	}()
+}
+
+func stopReconciler(connectionName string) {
+	reconcilerMutex.Lock()
+	defer reconcilerMutex.Unlock()
+
+	if cancel, ok := reconcilerCancelMap[connectionName]; ok {
+		cancel()
+		delete(reconcilerCancelMap, connectionName)
+	}
+}
+
+//================ Reconcile
+
+// reconcile the connection now with its policy.
+// Without the policy, only the drift report is made.
+func RunReconcile(connectionName string) (*DriftReport, error) {
+	cblog.Info("call RunReconcile()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("ConnectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	policy := ReconcilePolicy{ConnectionName: connectionName}
+	if _, err := getReconcileValue(reconcilePolicyKeyPrefix+connectionName, &policy); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	report, err := reconcile(policy)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// the last drift report of the connection.
+func GetDriftReport(connectionName string) (*DriftReport, error) {
+	cblog.Info("call GetDriftReport()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("ConnectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	report := DriftReport{}
+	exist, err := getReconcileValue(reconcileReportKeyPrefix+connectionName, &report)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if !exist {
+		return nil, fmt.Errorf("the drift report of %s does not exist!", connectionName)
+	}
+	return &report, nil
+}
+
+// 1. classify the resources of each type by ListAllResource()
+// 2. OnlySpiderList => StaleList, unregister them by the policy if they were in the last report
+// 3. OnlyCSPList => OrphanList, register them by the policy if they were in the last report
+// 4. compare the rules of the mapped SecurityGroups with the baselines
+// 5. save the report
+func reconcile(policy ReconcilePolicy) (*DriftReport, error) {
+	connectionName := policy.ConnectionName
+
+	reconcilerMutex.Lock()
+	if reconcileRunningMap[connectionName] {
+		reconcilerMutex.Unlock()
+		return nil, fmt.Errorf("the reconciliation of %s is already running!", connectionName)
+	}
+	reconcileRunningMap[connectionName] = true
+	reconcilerMutex.Unlock()
+
+	defer func() {
+		reconcilerMutex.Lock()
+		delete(reconcileRunningMap, connectionName)
+		reconcilerMutex.Unlock()
+	}()
+
+	report := &DriftReport{
+		ConnectionName: connectionName,
+		StartTime:      time.Now().Round(time.Second),
+		StaleList:      []*DriftItem{},
+		OrphanList:     []*DriftItem{},
+		ChangedList:    []*DriftItem{},
+	}
+
+	rsTypeList := policy.ResourceTypeList
+	if len(rsTypeList) == 0 {
+		rsTypeList = reconcileRsTypeList
+	}
+
+	// the drift found by the last reconciliation
+	lastReport := DriftReport{}
+	if _, err := getReconcileValue(reconcileReportKeyPrefix+connectionName, &lastReport); err != nil {
+		cblog.Error(err)
+	}
+	lastStaleMap := driftItemMap(lastReport.StaleList)
+	lastOrphanMap := driftItemMap(lastReport.OrphanList)
+
+	for _, rsType := range rsTypeList {
+		allResList, err := ListAllResource(connectionName, rsType)
+		if err != nil {
+			report.ErrorList = append(report.ErrorList, rsType+": "+err.Error())
+			continue
+		}
+
+		for _, iid := range allResList.AllList.OnlySpiderList {
+			item := &DriftItem{ResourceType: rsType, IId: *iid}
+			if policy.AutoUnregisterStale {
+				if lastStaleMap[driftItemKey(item)] {
+					unregisterStale(connectionName, item)
+				} else {
+					item.Action = DRIFT_PENDING
+				}
+			}
+			report.StaleList = append(report.StaleList, item)
+		}
+
+		for _, iid := range allResList.AllList.OnlyCSPList {
+			item := &DriftItem{ResourceType: rsType, IId: *iid}
+			if policy.AutoRegisterOrphan {
+				if lastOrphanMap[driftItemKey(item)] {
+					registerOrphan(connectionName, item)
+				} else {
+					item.Action = DRIFT_PENDING
+				}
+			}
+			report.OrphanList = append(report.OrphanList, item)
+		}
+
+		if rsType == rsSG {
+			changedList, err := checkSGRuleDrift(connectionName, allResList.AllList.MappedList)
+			if err != nil {
+				report.ErrorList = append(report.ErrorList, rsType+": "+err.Error())
+			}
+			report.ChangedList = append(report.ChangedList, changedList...)
+		}
+	}
+
+	report.EndTime = time.Now().Round(time.Second)
+	if err := putReconcileValue(reconcileReportKeyPrefix+connectionName, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ex) "vm/vm-01/i-0123456789"
+func driftItemKey(item *DriftItem) string {
+	return item.ResourceType + "/" + item.IId.NameId + "/" + item.IId.SystemId
+}
+
+func driftItemMap(itemList []*DriftItem) map[string]bool {
+	itemMap := map[string]bool{}
+	for _, item := range itemList {
+		itemMap[driftItemKey(item)] = true
+	}
+	return itemMap
+}
+
+func unregisterStale(connectionName string, item *DriftItem) {
+	_, err := UnregisterResource(connectionName, item.ResourceType, item.IId.NameId)
+	if err != nil {
+		cblog.Error(err)
+		item.Action = DRIFT_FAILED
+		item.Message = err.Error()
+		return
+	}
+	if item.ResourceType == rsSG {
+		deleteSGRuleBaseline(connectionName, item.IId.NameId)
+	}
+	item.Action = DRIFT_UNREGISTERED
+}
+
+// register the orphan with its CSP NameId, or its SystemId if the CSP has no name.
+func registerOrphan(connectionName string, item *DriftItem) {
+	name := item.IId.NameId
+	if name == "" {
+		name = item.IId.SystemId
+	}
+	userIID := cres.IID{name, item.IId.SystemId}
+
+	var err error
+	switch item.ResourceType {
+	case rsVPC:
+		_, err = RegisterVPC(connectionName, userIID)
+	case rsKey:
+		_, err = RegisterKey(connectionName, userIID)
+	case rsVM:
+		_, err = RegisterVM(connectionName, userIID)
+	case rsDisk:
+		_, err = RegisterDisk(connectionName, userIID)
+	case rsMyImage:
+		_, err = RegisterMyImage(connectionName, userIID)
+	case rsSG, rsNLB, rsCluster:
+		var vpcName string
+		vpcName, err = getOrphanVPCName(connectionName, item.ResourceType, item.IId)
+		if err != nil {
+			break
+		}
+		switch item.ResourceType {
+		case rsSG:
+			_, err = RegisterSecurity(connectionName, vpcName, userIID)
+		case rsNLB:
+			_, err = RegisterNLB(connectionName, vpcName, userIID)
+		case rsCluster:
+			_, err = RegisterCluster(connectionName, vpcName, userIID)
+		}
+	default:
+		err = fmt.Errorf("%s is not supported Resource!!", item.ResourceType)
+	}
+	if err != nil {
+		cblog.Error(err)
+		item.Action = DRIFT_FAILED
+		item.Message = err.Error()
+		return
+	}
+	item.Action = DRIFT_REGISTERED
+}
+
+// the Spider name of the VPC of the orphan SecurityGroup, NLB or Cluster.
+// The VPC should be registered in Spider before.
+func getOrphanVPCName(connectionName string, rsType string, cspIID cres.IID) (string, error) {
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return "", err
+	}
+	defer cldConn.Close()
+
+	var vpcSystemId string
+	switch rsType {
+	case rsSG:
+		handler, err := cldConn.CreateSecurityHandler()
+		if err != nil {
+			return "", err
+		}
+		info, err := handler.GetSecurity(cspIID)
+		if err != nil {
+			return "", err
+		}
+		vpcSystemId = info.VpcIID.SystemId
+	case rsNLB:
+		handler, err := cldConn.CreateNLBHandler()
+		if err != nil {
+			return "", err
+		}
+		info, err := handler.GetNLB(cspIID)
+		if err != nil {
+			return "", err
+		}
+		vpcSystemId = info.VpcIID.SystemId
+	case rsCluster:
+		handler, err := cldConn.CreateClusterHandler()
+		if err != nil {
+			return "", err
+		}
+		info, err := handler.GetCluster(cspIID)
+		if err != nil {
+			return "", err
+		}
+		vpcSystemId = info.Network.VpcIID.SystemId
+	}
+
+	iidInfoList, err := iidRWLock.ListIID(iidm.IIDSGROUP, connectionName, rsVPC)
+	if err != nil {
+		return "", err
+	}
+	for _, iidInfo := range iidInfoList {
+		if getDriverSystemId(iidInfo.IId) == vpcSystemId {
+			return iidInfo.IId.NameId, nil
+		}
+	}
+	return "", fmt.Errorf("the VPC(%s) of the %s is not registered in Spider!", vpcSystemId, RsTypeString(rsType))
+}
+
+//================ SecurityGroup Rule Baseline
+
+// the baseline is the rules known by Spider.
+// It is set by CreateSecurity() or by the first reconciliation of the SecurityGroup,
+// and changed by AddRules() and RemoveRules() with their own rules.
+func checkSGRuleDrift(connectionName string, mappedList []*cres.IID) ([]*DriftItem, error) {
+	changedList := []*DriftItem{}
+	mappedMap := map[string]bool{}
+	for _, iid := range mappedList {
+		mappedMap[iid.NameId] = true
+
+		info, err := GetSecurity(connectionName, rsSG, iid.NameId)
+		if err != nil {
+			return changedList, err
+		}
+		ruleList := []cres.SecurityRuleInfo{}
+		if info.SecurityRules != nil {
+			ruleList = *info.SecurityRules
+		}
+		currentList := ruleStringList(ruleList)
+
+		baselineList := []string{}
+		exist, err := getReconcileValue(sgRuleBaselineKey(connectionName, iid.NameId), &baselineList)
+		if err != nil {
+			return changedList, err
+		}
+		if !exist {
+			saveSGRuleBaseline(connectionName, iid.NameId, ruleList)
+			continue
+		}
+
+		addedList, removedList := diffStringList(baselineList, currentList)
+		if len(addedList) == 0 && len(removedList) == 0 {
+			continue
+		}
+		changedList = append(changedList, &DriftItem{
+			ResourceType: rsSG,
+			IId:          *iid,
+			Detail:       fmt.Sprintf("added: %v, removed: %v", addedList, removedList),
+		})
+	}
+
+	// clear the baselines of the SecurityGroups not in Spider
+	keyValueList, err := reconcileStore.GetList(sgRuleBaselineKeyPrefix+connectionName+"/", true)
+	if err != nil {
+		return changedList, err
+	}
+	for _, kv := range keyValueList {
+		sgName := strings.TrimPrefix(kv.Key, sgRuleBaselineKeyPrefix+connectionName+"/")
+		if !mappedMap[sgName] {
+			deleteSGRuleBaseline(connectionName, sgName)
+		}
+	}
+	return changedList, nil
+}
+
+func saveSGRuleBaseline(connectionName string, sgName string, ruleList []cres.SecurityRuleInfo) {
+	err := putReconcileValue(sgRuleBaselineKey(connectionName, sgName), ruleStringList(ruleList))
+	if err != nil {
+		cblog.Error(err)
+	}
+}
+
+// add and remove only the rules changed by Spider, so the rules changed outside Spider are still the drift.
+// Without the baseline, the next reconciliation sets it.
+func updateSGRuleBaseline(connectionName string, sgName string, addedRuleList []cres.SecurityRuleInfo, removedRuleList []cres.SecurityRuleInfo) {
+	baselineList := []string{}
+	exist, err := getReconcileValue(sgRuleBaselineKey(connectionName, sgName), &baselineList)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	if !exist {
+		return
+	}
+
+	ruleMap := map[string]bool{}
+	for _, rule := range baselineList {
+		ruleMap[rule] = true
+	}
+	for _, rule := range ruleStringList(append([]cres.SecurityRuleInfo{}, removedRuleList...)) {
+		delete(ruleMap, rule)
+	}
+	for _, rule := range ruleStringList(append([]cres.SecurityRuleInfo{}, addedRuleList...)) {
+		ruleMap[rule] = true
+	}
+	newList := []string{}
+	for rule := range ruleMap {
+		newList = append(newList, rule)
+	}
+	sort.Strings(newList)
+
+	if err := putReconcileValue(sgRuleBaselineKey(connectionName, sgName), newList); err != nil {
+		cblog.Error(err)
+	}
+}
+
+// the next reconciliation sets the new baseline.
+func deleteSGRuleBaseline(connectionName string, sgName string) {
+	err := reconcileStore.Delete(sgRuleBaselineKey(connectionName, sgName))
+	if err != nil {
+		cblog.Error(err)
+	}
+}
+
+func sgRuleBaselineKey(connectionName string, sgName string) string {
+	return sgRuleBaselineKeyPrefix + connectionName + "/" + sgName
+}
+
+// sorted rules, ex) ["inbound/TCP/22-22/0.0.0.0/0"]
+func ruleStringList(ruleList []cres.SecurityRuleInfo) []string {
+	transformArgs(&ruleList)
+	strList := []string{}
+	for _, rule := range ruleList {
+		strList = append(strList, fmt.Sprintf("%s/%s/%s-%s/%s", rule.Direction, rule.IPProtocol, rule.FromPort, rule.ToPort, rule.CIDR))
+	}
+	sort.Strings(strList)
+	return strList
+}
+
+func diffStringList(oldList []string, newList []string) ([]string, []string) {
+	oldMap := map[string]bool{}
+	for _, str := range oldList {
+		oldMap[str] = true
+	}
+	newMap := map[string]bool{}
+	for _, str := range newList {
+		newMap[str] = true
+	}
+
+	addedList := []string{}
+	for _, str := range newList {
+		if !oldMap[str] {
+			addedList = append(addedList, str)
+		}
+	}
+	removedList := []string{}
+	for _, str := range oldList {
+		if !newMap[str] {
+			removedList = append(removedList, str)
+		}
+	}
+	return addedList, removedList
+}
+
+//================ Reconcile Store
+
+func isReconcileRsType(rsType string) bool {
+	for _, one := range reconcileRsTypeList {
+		if one == rsType {
+			return true
+		}
+	}
+	return false
+}
+
+func putReconcileValue(key string, value interface{}) error {
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return reconcileStore.Put(key, string(jsonValue))
+}
+
+// false if the key does not exist.
+func getReconcileValue(key string, value interface{}) (bool, error) {
+	keyValue, err := reconcileStore.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if keyValue == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal([]byte(keyValue.Value), value)
+}
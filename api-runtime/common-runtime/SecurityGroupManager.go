@@ -43,6 +43,7 @@ func GetSGOwnerVPC(connectionName string, cspID string) (owerVPC cres.IID, err e
                 cblog.Error(err)
                 return cres.IID{}, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateSecurityHandler()
         if err != nil {
@@ -127,8 +128,9 @@ func GetSGOwnerVPC(connectionName string, cspID string) (owerVPC cres.IID, err e
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterSecurity(connectionName string, vpcUserID string, userIID cres.IID) (*cres.SecurityInfo, error) {
+func RegisterSecurity(connectionName string, vpcUserID string, userIID cres.IID) (_ *cres.SecurityInfo, retErr error) {
         cblog.Info("call RegisterSecurity()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsSG, userIID.NameId, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -159,6 +161,7 @@ func RegisterSecurity(connectionName string, vpcUserID string, userIID cres.IID)
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateSecurityHandler()
         if err != nil {
@@ -257,8 +260,9 @@ func RegisterSecurity(connectionName string, vpcUserID string, userIID cres.IID)
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityReqInfo) (*cres.SecurityInfo, error) {
+func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityReqInfo) (_ *cres.SecurityInfo, retErr error) {
 	cblog.Info("call CreateSecurity()")
+	defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -272,6 +276,7 @@ func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityR
                 "resources.IID:SystemId",
                 "resources.SecurityReqInfo:Direction", // because can be unused in some CSP
                 "resources.SecurityRuleInfo:CIDR",     // because can be set without soruce CIDR
+                "resources.KeyValue:Value",            // because a tag can have an empty value
         }
 
         err = ValidateStruct(reqInfo, emptyPermissionList)
@@ -300,6 +305,7 @@ func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityR
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateSecurityHandler()
 	if err != nil {
@@ -392,6 +398,11 @@ func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityR
 	// set VPC SystemId
 	info.VpcIID.SystemId = getDriverSystemId(vpcIIDInfo.IId)
 
+	// the rules known by Spider, for the drift reconciliation
+	if info.SecurityRules != nil {
+		saveSGRuleBaseline(connectionName, info.IId.NameId, *info.SecurityRules)
+	}
+
 	return &info, nil
 }
 
@@ -426,6 +437,7 @@ func ListSecurity(connectionName string, rsType string) ([]*cres.SecurityInfo, e
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateSecurityHandler()
 	if err != nil {
@@ -512,6 +524,7 @@ func GetSecurity(connectionName string, rsType string, nameID string) (*cres.Sec
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateSecurityHandler()
 	if err != nil {
@@ -592,6 +605,7 @@ func AddRules(connectionName string, sgName string, reqInfoList []cres.SecurityR
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateSecurityHandler()
         if err != nil {
@@ -651,6 +665,9 @@ func AddRules(connectionName string, sgName string, reqInfoList []cres.SecurityR
         }
         info.VpcIID = getUserIID(vpcIIDInfo.IId)
 
+        // the rules known by Spider, for the drift reconciliation
+        updateSGRuleBaseline(connectionName, sgName, reqInfoList, nil)
+
         return &info, nil
 }
 
@@ -677,6 +694,7 @@ func RemoveRules(connectionName string, sgName string, reqRuleInfoList []cres.Se
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
         handler, err := cldConn.CreateSecurityHandler()
         if err != nil {
@@ -721,5 +739,8 @@ func RemoveRules(connectionName string, sgName string, reqRuleInfoList []cres.Se
                 return false, err
         }
 
+        // the rules known by Spider, for the drift reconciliation
+        updateSGRuleBaseline(connectionName, sgName, nil, reqRuleInfoList)
+
         return result, nil
 }
@@ -0,0 +1,287 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strings"
+
+	splock "github.com/cloud-barista/cb-spider/api-runtime/common-runtime/sp-lock"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	icon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/connect"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+)
+
+//================ Tag Handler
+
+// rsType => the resource type of the driver and the SPLock
+var tagRSTypeMap = map[string]cres.RSType{
+	rsVPC:     cres.VPC,
+	rsSG:      cres.SG,
+	rsVM:      cres.VM,
+	rsNLB:     cres.NLB,
+	rsDisk:    cres.DISK,
+	rsCluster: cres.CLUSTER,
+}
+
+func getTagSPLock(rsType string) *splock.SPLOCK {
+	switch rsType {
+	case rsVPC:
+		return vpcSPLock
+	case rsSG:
+		return sgSPLock
+	case rsVM:
+		return vmSPLock
+	case rsNLB:
+		return nlbSPLock
+	case rsDisk:
+		return diskSPLock
+	default: // rsCluster
+		return clusterSPLock
+	}
+}
+
+// get the TagHandler of the connection, the driver's resource type and the driver's IID of the resource.
+func getTagTarget(cldConn icon.CloudConnection, connectionName string, rsType string, nameID string) (cres.TagHandler, cres.RSType, cres.IID, error) {
+	driverRSType, ok := tagRSTypeMap[rsType]
+	if !ok {
+		return nil, "", cres.IID{}, fmt.Errorf(rsType + " is not a taggable Resource!!")
+	}
+
+	handler, err := cldConn.CreateTagHandler()
+	if err != nil {
+		return nil, "", cres.IID{}, err
+	}
+
+	// (1) get spiderIID for creating driverIID
+	var iidInfoList []*iidm.IIDInfo
+	switch rsType {
+	case rsSG:
+		iidInfoList, err = getAllSGIIDInfoList(connectionName)
+	case rsNLB:
+		iidInfoList, err = getAllNLBIIDInfoList(connectionName)
+	case rsCluster:
+		iidInfoList, err = getAllClusterIIDInfoList(connectionName)
+	default:
+		var iidInfo *iidm.IIDInfo
+		iidInfo, err = iidRWLock.GetIID(iidm.IIDSGROUP, connectionName, rsType, cres.IID{nameID, ""})
+		if err != nil {
+			return nil, "", cres.IID{}, err
+		}
+		iidInfoList = []*iidm.IIDInfo{iidInfo}
+	}
+	if err != nil {
+		return nil, "", cres.IID{}, err
+	}
+
+	for _, iidInfo := range iidInfoList {
+		if iidInfo.IId.NameId == nameID {
+			// (2) get driverIID
+			return handler, driverRSType, getDriverIID(iidInfo.IId), nil
+		}
+	}
+	return nil, "", cres.IID{}, fmt.Errorf("[" + connectionName + ":" + RsTypeString(rsType) + ":" + nameID + "] does not exist!")
+}
+
+// add a tag, or change the value of the tag with the same key.
+func AddTag(connectionName string, rsType string, nameID string, tag cres.KeyValue) (*cres.KeyValue, error) {
+	cblog.Info("call AddTag()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	tag.Key, err = EmptyCheckAndTrim("tag.Key", tag.Key)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if _, ok := tagRSTypeMap[rsType]; !ok {
+		err := fmt.Errorf(rsType + " is not a taggable Resource!!")
+		cblog.Error(err)
+		return nil, err
+	}
+	spLock := getTagSPLock(rsType)
+	spLock.Lock(connectionName, nameID)
+	defer spLock.Unlock(connectionName, nameID)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	handler, driverRSType, driverIId, err := getTagTarget(cldConn, connectionName, rsType, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	result, err := handler.AddTag(driverRSType, driverIId, tag)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return &result, nil
+}
+
+func ListTag(connectionName string, rsType string, nameID string) ([]cres.KeyValue, error) {
+	cblog.Info("call ListTag()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if _, ok := tagRSTypeMap[rsType]; !ok {
+		err := fmt.Errorf(rsType + " is not a taggable Resource!!")
+		cblog.Error(err)
+		return nil, err
+	}
+	spLock := getTagSPLock(rsType)
+	spLock.RLock(connectionName, nameID)
+	defer spLock.RUnlock(connectionName, nameID)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	defer cldConn.Close()
+
+	handler, driverRSType, driverIId, err := getTagTarget(cldConn, connectionName, rsType, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	result, err := handler.ListTag(driverRSType, driverIId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if result == nil {
+		result = []cres.KeyValue{}
+	}
+	return result, nil
+}
+
+func RemoveTag(connectionName string, rsType string, nameID string, key string) (bool, error) {
+	cblog.Info("call RemoveTag()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	key, err = EmptyCheckAndTrim("key", key)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	if _, ok := tagRSTypeMap[rsType]; !ok {
+		err := fmt.Errorf(rsType + " is not a taggable Resource!!")
+		cblog.Error(err)
+		return false, err
+	}
+	spLock := getTagSPLock(rsType)
+	spLock.Lock(connectionName, nameID)
+	defer spLock.Unlock(connectionName, nameID)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	defer cldConn.Close()
+
+	handler, driverRSType, driverIId, err := getTagTarget(cldConn, connectionName, rsType, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	result, err := handler.RemoveTag(driverRSType, driverIId, key)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	return result, nil
+}
+
+// Tag filter of the list APIs.
+// AnyValue: the filter without the value("key") matches any value of the key.
+type TagFilter struct {
+	Key      string
+	Value    string
+	AnyValue bool
+}
+
+// ex) "owner:team-a" => {owner, team-a}, "owner:" => {owner, ""}(empty value), "owner" => {owner, any value}
+func ParseTagFilter(tagStrList []string) []TagFilter {
+	tagFilter := []TagFilter{}
+	for _, tagStr := range tagStrList {
+		strList := strings.SplitN(tagStr, ":", 2)
+		tag := TagFilter{Key: strings.TrimSpace(strList[0]), AnyValue: true}
+		if len(strList) == 2 {
+			tag.Value = strings.TrimSpace(strList[1])
+			tag.AnyValue = false
+		}
+		if tag.Key != "" {
+			tagFilter = append(tagFilter, tag)
+		}
+	}
+	return tagFilter
+}
+
+// true if the tagList has all tags of the filter.
+func MatchTagFilter(tagList []cres.KeyValue, tagFilter []TagFilter) bool {
+	for _, filterTag := range tagFilter {
+		matched := false
+		for _, tag := range tagList {
+			if tag.Key == filterTag.Key && (filterTag.AnyValue || tag.Value == filterTag.Value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
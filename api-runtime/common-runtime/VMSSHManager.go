@@ -0,0 +1,547 @@
+// VM SSH Command Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	sshrun "github.com/cloud-barista/cb-spider/cloud-control-manager/vm-ssh"
+)
+
+//================ VM SSH Command
+
+const (
+	defaultVMCommandTimeoutSec = 60
+	maxVMCommandTimeoutSec     = 3600
+)
+
+// The Command or the Script is required.
+// The Script is run by the login shell of the VM user as one command string.
+type VMCommandReq struct {
+	Command    string   // ex) "uname -a"
+	Script     []string // lines of a script, ex) ["cd /tmp", "ls -al"]
+	Stdin      string   // optional
+	TimeoutSec int      // 0: 60 sec (default), max: 3600 sec
+
+	JumpHosts []SSHJumpHostInfo // optional, default: the jump hosts of the connection
+}
+
+type VMCommandResult struct {
+	VMName     string
+	ServerPort string // ex) "3.35.114.10:22"
+	UserName   string // ex) "cb-user"
+	Stdout     string
+	Stderr     string
+	ExitCode   int // -1: the command did not exit normally, ex) timed out
+	TimedOut   bool
+	Truncated  bool // true: the Stdout or the Stderr exceeded 4MB
+}
+
+// RunVMCommand runs a command or a script on the VM with the Spider-managed KeyPair and SSH access point.
+// The host key of the VM is pinned on the first connection.
+func RunVMCommand(ctx context.Context, connectionName string, vmName string, reqInfo VMCommandReq) (*VMCommandResult, error) {
+	cblog.Info("call RunVMCommand()")
+
+	cmd, timeout, err := checkVMCommandReq(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sshInfo, err := getVMSSHInfo(connectionName, vmName, int(timeout/time.Second), reqInfo.JumpHosts)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdin io.Reader
+	if reqInfo.Stdin != "" {
+		stdin = strings.NewReader(reqInfo.Stdin)
+	}
+
+	result, err := sshrun.SSHRunContext(ctx, sshInfo, cmd, stdin)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &VMCommandResult{
+		VMName:     strings.TrimSpace(vmName),
+		ServerPort: sshInfo.ServerPort,
+		UserName:   sshInfo.UserName,
+		Stdout:     result.Stdout,
+		Stderr:     result.Stderr,
+		ExitCode:   result.ExitCode,
+		TimedOut:   result.TimedOut,
+		Truncated:  result.Truncated,
+	}, nil
+}
+
+// returns the command string and the timeout of the request.
+func checkVMCommandReq(reqInfo VMCommandReq) (string, time.Duration, error) {
+	cmd := strings.TrimSpace(reqInfo.Command)
+	script := strings.TrimSpace(strings.Join(reqInfo.Script, "\n"))
+	if cmd == "" && script == "" {
+		return "", 0, fmt.Errorf("The Command or the Script is required!")
+	}
+	if cmd != "" && script != "" {
+		return "", 0, fmt.Errorf("Only one of the Command and the Script is allowed!")
+	}
+	if cmd == "" {
+		cmd = script
+	}
+
+	timeoutSec := reqInfo.TimeoutSec
+	if timeoutSec < 0 || timeoutSec > maxVMCommandTimeoutSec {
+		return "", 0, fmt.Errorf("The TimeoutSec(%d) should be between 0 and %d!", timeoutSec, maxVMCommandTimeoutSec)
+	}
+	if timeoutSec == 0 {
+		timeoutSec = defaultVMCommandTimeoutSec
+	}
+	return cmd, time.Duration(timeoutSec) * time.Second, nil
+}
+
+// getVMSSHInfo resolves the SSH access point, the user and the private key of the VM,
+// and sets the host key callback with the pinned key of the VM.
+// With the jump hosts of the request or the connection, the VM is reached by the private IP through them.
+func getVMSSHInfo(connectionName string, vmName string, connectTimeoutSec int, reqJumpHostList []SSHJumpHostInfo) (sshrun.SSHInfo, error) {
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		return sshrun.SSHInfo{}, err
+	}
+
+	jumpHosts, err := resolveSSHJumpHosts(connectionName, reqJumpHostList, connectTimeoutSec)
+	if err != nil {
+		return sshrun.SSHInfo{}, err
+	}
+	return getVMSSHInfoWithJumpHosts(connectionName, vmName, connectTimeoutSec, jumpHosts)
+}
+
+func getVMSSHInfoWithJumpHosts(connectionName string, vmName string, connectTimeoutSec int, jumpHosts []sshrun.JumpHostInfo) (sshrun.SSHInfo, error) {
+	vmName, err := EmptyCheckAndTrim("vmName", vmName)
+	if err != nil {
+		return sshrun.SSHInfo{}, err
+	}
+
+	vmInfo, err := GetVM(connectionName, rsVM, vmName)
+	if err != nil {
+		return sshrun.SSHInfo{}, err
+	}
+	if strings.HasPrefix(vmInfo.SSHAccessPoint, "RDP:") {
+		return sshrun.SSHInfo{}, fmt.Errorf("The %s '%s' is a Windows VM, SSH is not supported!", RsTypeString(rsVM), vmName)
+	}
+	serverPort := vmInfo.SSHAccessPoint
+	if len(jumpHosts) > 0 {
+		if vmInfo.PrivateIP == "" {
+			return sshrun.SSHInfo{}, fmt.Errorf("The %s '%s' has no Private IP to be reached through the jump hosts!", RsTypeString(rsVM), vmName)
+		}
+		serverPort = net.JoinHostPort(vmInfo.PrivateIP, sshPort(vmInfo.SSHAccessPoint))
+	} else if serverPort == "" || strings.HasPrefix(serverPort, ":") {
+		return sshrun.SSHInfo{}, fmt.Errorf("The %s '%s' has no SSH access point(Public IP), use the jump hosts!", RsTypeString(rsVM), vmName)
+	}
+	if vmInfo.KeyPairIId.NameId == "" {
+		return sshrun.SSHInfo{}, fmt.Errorf("The %s '%s' has no KeyPair managed by Spider!", RsTypeString(rsVM), vmName)
+	}
+
+	privateKey, err := getKeyPrivateKey(connectionName, vmInfo.KeyPairIId.NameId)
+	if err != nil {
+		return sshrun.SSHInfo{}, err
+	}
+	if _, err := ssh.ParsePrivateKey([]byte(privateKey)); err != nil {
+		return sshrun.SSHInfo{}, fmt.Errorf("The private key of %s '%s' is invalid: %v", RsTypeString(rsKey), vmInfo.KeyPairIId.NameId, err)
+	}
+
+	hostKeyCallback, hostKeyAlgorithms, err := VMHostKeyCallback(connectionName, vmName)
+	if err != nil {
+		return sshrun.SSHInfo{}, err
+	}
+
+	return sshrun.SSHInfo{
+		UserName:          vmInfo.VMUserId,
+		PrivateKey:        []byte(privateKey),
+		ServerPort:        serverPort,
+		Timeout:           connectTimeoutSec,
+		HostKeyCallback:   hostKeyCallback,
+		HostKeyAlgorithms: hostKeyAlgorithms,
+		JumpHosts:         jumpHosts,
+	}, nil
+}
+
+// the port of the SSH access point, ex) "3.35.114.10:22" => "22"
+func sshPort(sshAccessPoint string) string {
+	_, port, err := net.SplitHostPort(sshAccessPoint)
+	if err != nil || port == "" {
+		return "22"
+	}
+	return port
+}
+
+//================ VM SSH Command Fan-out
+
+const (
+	defaultVMCommandParallelism = 10
+	maxVMCommandParallelism     = 100
+)
+
+const (
+	VMCommandSucceeded = "Succeeded" // exit code 0
+	VMCommandFailed    = "Failed"    // non-zero exit code or timed out
+	VMCommandError     = "Error"     // not executed, ex) SSH connection error
+)
+
+// The target VMs are given by one of the VMNames, the VMNamePrefix and the NLBName.
+type VMCommandFanOutReq struct {
+	VMNames      []string // ex) ["vm-01", "vm-02"]
+	VMNamePrefix string   // ex) "web-" => all VMs with the name prefix in the connection
+	NLBName      string   // ex) "nlb-01" => all VMs of the NLB's VM group
+	Parallelism  int      // 0: 10 (default), max: 100
+
+	CommandReq VMCommandReq
+}
+
+type VMCommandFanOutResult struct {
+	TotalCount     int
+	SucceededCount int
+	FailedCount    int
+	ErrorCount     int
+	ResultList     []VMCommandFanOutItem // the order of the target VMs
+}
+
+type VMCommandFanOutItem struct {
+	VMName      string
+	Status      string // Succeeded | Failed | Error
+	Result      *VMCommandResult
+	ErrorMSG    string
+	ElapsedTime string // ex) "2.0201" (sec)
+}
+
+// RunVMCommandFanOut runs a command on the target VMs concurrently within the parallelism.
+// The failure of some VMs is reported in the result of each VM, it does not stop the others.
+func RunVMCommandFanOut(ctx context.Context, connectionName string, reqInfo VMCommandFanOutReq) (*VMCommandFanOutResult, error) {
+	cblog.Info("call RunVMCommandFanOut()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if _, _, err := checkVMCommandReq(reqInfo.CommandReq); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	parallelism := reqInfo.Parallelism
+	if parallelism < 0 || parallelism > maxVMCommandParallelism {
+		err := fmt.Errorf("The Parallelism(%d) should be between 0 and %d!", parallelism, maxVMCommandParallelism)
+		cblog.Error(err)
+		return nil, err
+	}
+	if parallelism == 0 {
+		parallelism = defaultVMCommandParallelism
+	}
+
+	vmNames, err := getFanOutVMNames(connectionName, reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	resultList := make([]VMCommandFanOutItem, len(vmNames))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for idx, vmName := range vmNames {
+		wg.Add(1)
+		go func(idx int, vmName string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			start := time.Now()
+			item := VMCommandFanOutItem{VMName: vmName}
+			var result *VMCommandResult
+			err := ctx.Err() // canceled while waiting for the turn
+			if err == nil {
+				result, err = RunVMCommand(ctx, connectionName, vmName, reqInfo.CommandReq)
+			}
+			switch {
+			case err != nil:
+				item.Status = VMCommandError
+				item.ErrorMSG = err.Error()
+			case result.ExitCode != 0 || result.TimedOut:
+				item.Status = VMCommandFailed
+				item.Result = result
+			default:
+				item.Status = VMCommandSucceeded
+				item.Result = result
+			}
+			item.ElapsedTime = call.Elapsed(start)
+			resultList[idx] = item
+		}(idx, vmName)
+	}
+	wg.Wait()
+
+	fanOutResult := &VMCommandFanOutResult{TotalCount: len(resultList), ResultList: resultList}
+	for _, item := range resultList {
+		switch item.Status {
+		case VMCommandSucceeded:
+			fanOutResult.SucceededCount++
+		case VMCommandFailed:
+			fanOutResult.FailedCount++
+		default:
+			fanOutResult.ErrorCount++
+		}
+	}
+	return fanOutResult, nil
+}
+
+// returns the target VM names without duplication.
+func getFanOutVMNames(connectionName string, reqInfo VMCommandFanOutReq) ([]string, error) {
+	vmNamePrefix := strings.TrimSpace(reqInfo.VMNamePrefix)
+	nlbName := strings.TrimSpace(reqInfo.NLBName)
+
+	selectorCount := 0
+	for _, isSet := range []bool{len(reqInfo.VMNames) > 0, vmNamePrefix != "", nlbName != ""} {
+		if isSet {
+			selectorCount++
+		}
+	}
+	if selectorCount != 1 {
+		return nil, fmt.Errorf("One of the VMNames, the VMNamePrefix and the NLBName is required!")
+	}
+
+	candidates := []string{}
+	switch {
+	case len(reqInfo.VMNames) > 0:
+		candidates = reqInfo.VMNames
+	case vmNamePrefix != "":
+		iidInfoList, err := iidRWLock.ListIID(iidm.IIDSGROUP, connectionName, rsVM)
+		if err != nil {
+			return nil, err
+		}
+		for _, iidInfo := range iidInfoList {
+			if strings.HasPrefix(iidInfo.IId.NameId, vmNamePrefix) {
+				candidates = append(candidates, iidInfo.IId.NameId)
+			}
+		}
+		sort.Strings(candidates)
+	default:
+		nlbInfo, err := GetNLB(connectionName, rsNLB, nlbName)
+		if err != nil {
+			return nil, err
+		}
+		if nlbInfo.VMGroup.VMs != nil {
+			for _, vmIID := range *nlbInfo.VMGroup.VMs {
+				candidates = append(candidates, vmIID.NameId)
+			}
+		}
+	}
+
+	vmNames := []string{}
+	added := map[string]bool{}
+	for _, vmName := range candidates {
+		vmName = strings.TrimSpace(vmName)
+		if vmName == "" || added[vmName] {
+			continue
+		}
+		added[vmName] = true
+		vmNames = append(vmNames, vmName)
+	}
+	if len(vmNames) == 0 {
+		return nil, fmt.Errorf("There is no target %s in the connection '%s'!", RsTypeString(rsVM), connectionName)
+	}
+	return vmNames, nil
+}
+
+//================ VM File Transfer (SCP)
+
+const (
+	defaultVMFileTimeoutSec = 300
+	maxVMFileTimeoutSec     = 3600
+	MaxVMFileSize           = 100 * 1024 * 1024 // 100MB, the limit of the upload and the download
+	defaultVMFileMode       = "0644"
+)
+
+type VMFileUploadReq struct {
+	RemotePath string // ex) "/home/cb-user/app.conf"
+	Mode       string // optional, default: "0644"
+	SHA256     string // optional, the upload is rejected if the checksum of the file is different
+	TimeoutSec int    // optional, default: 300
+
+	JumpHosts []SSHJumpHostInfo // optional, default: the jump hosts of the connection
+}
+
+type VMFileDownloadReq struct {
+	RemotePath string // ex) "/var/log/syslog"
+	MaxSize    int64  // optional, default and max: 100MB
+	TimeoutSec int    // optional, default: 300
+
+	JumpHosts []SSHJumpHostInfo // optional, default: the jump hosts of the connection
+}
+
+type VMFileInfo struct {
+	VMName     string
+	RemotePath string
+	Mode       string // ex) "0644"
+	Size       int64  // bytes
+	SHA256     string // hex string
+}
+
+// UploadVMFile uploads the size bytes of the r to the VM with the Spider-managed KeyPair.
+// The checksum of the file is verified before the upload if the reqInfo.SHA256 is given.
+func UploadVMFile(ctx context.Context, connectionName string, vmName string, reqInfo VMFileUploadReq, r io.ReadSeeker, size int64) (*VMFileInfo, error) {
+	cblog.Info("call UploadVMFile()")
+
+	remotePath, timeout, err := checkVMFileReq(reqInfo.RemotePath, reqInfo.TimeoutSec)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	// go-scp quotes the remote path with the double quotes
+	if strings.ContainsAny(remotePath, "\"$`\\") {
+		err := fmt.Errorf("The RemotePath(%s) should not include the characters: \" $ ` \\", remotePath)
+		cblog.Error(err)
+		return nil, err
+	}
+	mode := strings.TrimSpace(reqInfo.Mode)
+	if mode == "" {
+		mode = defaultVMFileMode
+	}
+	if _, err := strconv.ParseUint(mode, 8, 32); err != nil || len(mode) != 4 {
+		err := fmt.Errorf("The Mode(%s) should be a 4-digit octal number, ex) 0644", mode)
+		cblog.Error(err)
+		return nil, err
+	}
+	if size < 0 || size > MaxVMFileSize {
+		err := fmt.Errorf("The size(%d bytes) of the file exceeds the limit(%d bytes)!", size, MaxVMFileSize)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if reqInfo.SHA256 != "" {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, r); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, strings.TrimSpace(reqInfo.SHA256)) {
+			err := fmt.Errorf("The SHA256(%s) of the file is different from the given SHA256(%s)!", sum, reqInfo.SHA256)
+			cblog.Error(err)
+			return nil, err
+		}
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	sshInfo, err := getVMSSHInfo(connectionName, vmName, int(timeout/time.Second), reqInfo.JumpHosts)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	info, err := sshrun.SSHUploadContext(ctx, sshInfo, r, size, remotePath, mode)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &VMFileInfo{
+		VMName:     strings.TrimSpace(vmName),
+		RemotePath: remotePath,
+		Mode:       info.Mode,
+		Size:       info.Size,
+		SHA256:     info.SHA256,
+	}, nil
+}
+
+// DownloadVMFile downloads a file of the VM to the w with the Spider-managed KeyPair.
+// Nothing is written to the w if the file is larger than the limit.
+// The w can be a sshrun.FileHeaderWriter to get the mode and the size of the file before the content.
+func DownloadVMFile(ctx context.Context, connectionName string, vmName string, reqInfo VMFileDownloadReq, w io.Writer) (*VMFileInfo, error) {
+	cblog.Info("call DownloadVMFile()")
+
+	remotePath, timeout, err := checkVMFileReq(reqInfo.RemotePath, reqInfo.TimeoutSec)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	maxSize := reqInfo.MaxSize
+	if maxSize < 0 || maxSize > MaxVMFileSize {
+		err := fmt.Errorf("The MaxSize(%d) should be between 0 and %d!", maxSize, MaxVMFileSize)
+		cblog.Error(err)
+		return nil, err
+	}
+	if maxSize == 0 {
+		maxSize = MaxVMFileSize
+	}
+
+	sshInfo, err := getVMSSHInfo(connectionName, vmName, int(timeout/time.Second), reqInfo.JumpHosts)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	info, err := sshrun.SSHDownloadContext(ctx, sshInfo, remotePath, maxSize, w)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &VMFileInfo{
+		VMName:     strings.TrimSpace(vmName),
+		RemotePath: remotePath,
+		Mode:       info.Mode,
+		Size:       info.Size,
+		SHA256:     info.SHA256,
+	}, nil
+}
+
+// returns the remote path and the timeout of the request.
+func checkVMFileReq(remotePath string, timeoutSec int) (string, time.Duration, error) {
+	remotePath, err := EmptyCheckAndTrim("RemotePath", remotePath)
+	if err != nil {
+		return "", 0, err
+	}
+	if strings.ContainsAny(remotePath, "\n\r\x00") || strings.HasSuffix(remotePath, "/") {
+		return "", 0, fmt.Errorf("The RemotePath(%q) should be a file path!", remotePath)
+	}
+
+	if timeoutSec < 0 || timeoutSec > maxVMFileTimeoutSec {
+		return "", 0, fmt.Errorf("The TimeoutSec(%d) should be between 0 and %d!", timeoutSec, maxVMFileTimeoutSec)
+	}
+	if timeoutSec == 0 {
+		timeoutSec = defaultVMFileTimeoutSec
+	}
+	return remotePath, time.Duration(timeoutSec) * time.Second, nil
+}
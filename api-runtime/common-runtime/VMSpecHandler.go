@@ -29,6 +29,7 @@ func ListVMSpec(connectionName string) ([]*cres.VMSpecInfo, error) {
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVMSpecHandler()
 	if err != nil {
@@ -70,6 +71,7 @@ func GetVMSpec(connectionName string, nameID string) (*cres.VMSpecInfo, error) {
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVMSpecHandler()
 	if err != nil {
@@ -100,6 +102,7 @@ func ListOrgVMSpec(connectionName string) (string, error) {
 		cblog.Error(err)
 		return "", err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVMSpecHandler()
 	if err != nil {
@@ -137,6 +140,7 @@ func GetOrgVMSpec(connectionName string, nameID string) (string, error) {
 		cblog.Error(err)
 		return "", err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVMSpecHandler()
 	if err != nil {
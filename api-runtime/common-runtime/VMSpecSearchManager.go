@@ -0,0 +1,328 @@
+// VMSpec Search Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+)
+
+// the constraints of the VMSpec search, 0 or empty: no constraint
+type VMSpecSearchReqInfo struct {
+	ConnectionNames []string // empty: all connections
+	ProviderNames   []string // ex) ["AWS", "GCP", "AZURE"]
+	RegionNames     []string // the region config name or the CSP region, ex) ["aws-ohio", "us-east1"]
+
+	MinVCpu int
+	MaxVCpu int
+	MinMem  int // MB
+	MaxMem  int // MB
+
+	MinGpu   int    // the number of GPUs
+	MaxGpu   int    // the number of GPUs
+	GpuModel string // case-insensitive substring of the GPU maker and model, ex) "V100", "NVIDIA"
+
+	Limit      int // 0: all matched specs
+	MaxWorkers int // 0: default(10), max 50
+}
+
+// the provider-neutral shape of a VMSpec
+type EquivalentSpec struct {
+	Key      string  // ex) "4vCPU-16GiB", "8vCPU-64GiB-2xNVIDIA V100"
+	VCpu     int     //
+	MemGiB   float64 // rounded to 1GiB, 0.5GiB under 1GiB
+	GpuCount int     //
+	GpuModel string  `json:",omitempty"` // ex) "NVIDIA V100"
+}
+
+type VMSpecSearchResult struct {
+	Connection string
+	Provider   string
+	Distance   float64 // the closeness to the request, 0: exactly the requested minimum
+	Equivalent EquivalentSpec
+	VMSpecInfo *cres.VMSpecInfo
+}
+
+// the matched specs with the same equivalent shape over the connections
+type EquivalentSpecGroup struct {
+	Equivalent EquivalentSpec
+	Distance   float64        // the distance of the shape
+	SpecList   []*SpecSummary // the closest spec of each connection
+}
+
+type SpecSummary struct {
+	Connection string
+	Provider   string
+	Region     string
+	Name       string // the CSP spec name, ex) "t3.xlarge", "n2-standard-4"
+}
+
+type VMSpecSearchInfo struct {
+	SpecList       []*VMSpecSearchResult
+	EquivalentList []*EquivalentSpecGroup
+	ErrorList      []*ConnectionError
+}
+
+// search the VMSpecs of the connections in parallel by the constraints,
+// the closer to the request, the earlier in the result.
+func SearchVMSpec(ctx context.Context, reqInfo VMSpecSearchReqInfo) (*VMSpecSearchInfo, error) {
+	cblog.Info("call SearchVMSpec()")
+
+	if err := checkVMSpecSearchReqInfo(reqInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	connInfoList, err := getConnectionList(reqInfo.ConnectionNames, reqInfo.ProviderNames, reqInfo.RegionNames)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	searchInfo := &VMSpecSearchInfo{
+		SpecList:       []*VMSpecSearchResult{},
+		EquivalentList: []*EquivalentSpecGroup{},
+		ErrorList:      []*ConnectionError{},
+	}
+	var mutex sync.Mutex
+	runOnConnections(ctx, connInfoList, reqInfo.MaxWorkers, func(connInfo *ccim.ConnectionConfigInfo) {
+		specList, err := ListVMSpec(connInfo.ConfigName)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil {
+			searchInfo.ErrorList = append(searchInfo.ErrorList,
+				&ConnectionError{Connection: connInfo.ConfigName, Provider: connInfo.ProviderName, Error: err.Error()})
+			return
+		}
+		for _, spec := range specList {
+			if result, ok := matchVMSpec(reqInfo, spec); ok {
+				result.Connection = connInfo.ConfigName
+				result.Provider = connInfo.ProviderName
+				searchInfo.SpecList = append(searchInfo.SpecList, result)
+			}
+		}
+	}, func(connInfo *ccim.ConnectionConfigInfo, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		searchInfo.ErrorList = append(searchInfo.ErrorList,
+			&ConnectionError{Connection: connInfo.ConfigName, Provider: connInfo.ProviderName, Error: err.Error()})
+	})
+
+	sort.SliceStable(searchInfo.SpecList, func(i, j int) bool {
+		return lessVMSpecSearchResult(searchInfo.SpecList[i], searchInfo.SpecList[j])
+	})
+	sort.SliceStable(searchInfo.ErrorList, func(i, j int) bool {
+		return searchInfo.ErrorList[i].Connection < searchInfo.ErrorList[j].Connection
+	})
+
+	// the groups are made from all matched specs before the limit
+	searchInfo.EquivalentList = groupEquivalentSpec(searchInfo.SpecList)
+	if reqInfo.Limit > 0 {
+		if len(searchInfo.SpecList) > reqInfo.Limit {
+			searchInfo.SpecList = searchInfo.SpecList[:reqInfo.Limit]
+		}
+		if len(searchInfo.EquivalentList) > reqInfo.Limit {
+			searchInfo.EquivalentList = searchInfo.EquivalentList[:reqInfo.Limit]
+		}
+	}
+
+	return searchInfo, nil
+}
+
+func checkVMSpecSearchReqInfo(reqInfo VMSpecSearchReqInfo) error {
+	if reqInfo.MinVCpu < 0 || reqInfo.MaxVCpu < 0 || reqInfo.MinMem < 0 || reqInfo.MaxMem < 0 ||
+		reqInfo.MinGpu < 0 || reqInfo.MaxGpu < 0 || reqInfo.Limit < 0 {
+		return fmt.Errorf("the constraints of the VMSpec search can not be negative!")
+	}
+	if reqInfo.MaxVCpu > 0 && reqInfo.MinVCpu > reqInfo.MaxVCpu {
+		return fmt.Errorf("MinVCpu(%d) is greater than MaxVCpu(%d)!", reqInfo.MinVCpu, reqInfo.MaxVCpu)
+	}
+	if reqInfo.MaxMem > 0 && reqInfo.MinMem > reqInfo.MaxMem {
+		return fmt.Errorf("MinMem(%d) is greater than MaxMem(%d)!", reqInfo.MinMem, reqInfo.MaxMem)
+	}
+	if reqInfo.MaxGpu > 0 && reqInfo.MinGpu > reqInfo.MaxGpu {
+		return fmt.Errorf("MinGpu(%d) is greater than MaxGpu(%d)!", reqInfo.MinGpu, reqInfo.MaxGpu)
+	}
+	return nil
+}
+
+// check the spec with the constraints and measure the distance from the request.
+// The specs with an unknown vCPU or memory fail the constraints of them.
+func matchVMSpec(reqInfo VMSpecSearchReqInfo, spec *cres.VMSpecInfo) (*VMSpecSearchResult, bool) {
+	equivalent, vCpuOK, memOK := getEquivalentSpec(spec)
+	memMB := 0.0
+	if memOK {
+		memMB, _ = parseSpecNumber(spec.Mem)
+	}
+
+	if reqInfo.MinVCpu > 0 || reqInfo.MaxVCpu > 0 {
+		if !vCpuOK || !inRange(float64(equivalent.VCpu), reqInfo.MinVCpu, reqInfo.MaxVCpu) {
+			return nil, false
+		}
+	}
+	if reqInfo.MinMem > 0 || reqInfo.MaxMem > 0 {
+		if !memOK || !inRange(memMB, reqInfo.MinMem, reqInfo.MaxMem) {
+			return nil, false
+		}
+	}
+	if reqInfo.MinGpu > 0 || reqInfo.MaxGpu > 0 {
+		if !inRange(float64(equivalent.GpuCount), reqInfo.MinGpu, reqInfo.MaxGpu) {
+			return nil, false
+		}
+	}
+	if reqInfo.GpuModel != "" {
+		if !strings.Contains(strings.ToLower(equivalent.GpuModel), strings.ToLower(strings.TrimSpace(reqInfo.GpuModel))) {
+			return nil, false
+		}
+	}
+
+	distance := specDistance(float64(equivalent.VCpu), reqInfo.MinVCpu, reqInfo.MaxVCpu) +
+		specDistance(memMB, reqInfo.MinMem, reqInfo.MaxMem) +
+		specDistance(float64(equivalent.GpuCount), reqInfo.MinGpu, reqInfo.MaxGpu)
+
+	return &VMSpecSearchResult{
+		Distance:   math.Round(distance*1000) / 1000,
+		Equivalent: equivalent,
+		VMSpecInfo: spec,
+	}, true
+}
+
+func inRange(value float64, min int, max int) bool {
+	if min > 0 && value < float64(min) {
+		return false
+	}
+	if max > 0 && value > float64(max) {
+		return false
+	}
+	return true
+}
+
+// the relative distance from the requested minimum, or the maximum without the minimum.
+// 0 without both of them.
+func specDistance(value float64, min int, max int) float64 {
+	target := min
+	if target == 0 {
+		target = max
+	}
+	if target == 0 {
+		return 0
+	}
+	return math.Abs(value-float64(target)) / float64(target)
+}
+
+func lessVMSpecSearchResult(a, b *VMSpecSearchResult) bool {
+	if a.Distance != b.Distance {
+		return a.Distance < b.Distance
+	}
+	if a.Equivalent.VCpu != b.Equivalent.VCpu {
+		return a.Equivalent.VCpu < b.Equivalent.VCpu
+	}
+	if a.Equivalent.MemGiB != b.Equivalent.MemGiB {
+		return a.Equivalent.MemGiB < b.Equivalent.MemGiB
+	}
+	if a.Connection != b.Connection {
+		return a.Connection < b.Connection
+	}
+	return a.VMSpecInfo.Name < b.VMSpecInfo.Name
+}
+
+// get the provider-neutral shape of the spec.
+// vCpuOK and memOK are false if the driver does not give them as numbers.
+func getEquivalentSpec(spec *cres.VMSpecInfo) (equivalent EquivalentSpec, vCpuOK bool, memOK bool) {
+	vCpu, vCpuOK := parseSpecNumber(spec.VCpu.Count)
+	if vCpuOK {
+		equivalent.VCpu = int(vCpu)
+	}
+
+	memMB, memOK := parseSpecNumber(spec.Mem)
+	if memOK {
+		memGiB := memMB / 1024
+		if memGiB < 1 {
+			equivalent.MemGiB = math.Round(memGiB*2) / 2
+		} else {
+			equivalent.MemGiB = math.Round(memGiB)
+		}
+	}
+
+	modelList := []string{}
+	for _, gpu := range spec.Gpu {
+		count, ok := parseSpecNumber(gpu.Count)
+		if !ok || count <= 0 {
+			continue
+		}
+		equivalent.GpuCount += int(count)
+		model := strings.TrimSpace(strings.TrimSpace(gpu.Mfr) + " " + strings.TrimSpace(gpu.Model))
+		if model != "" {
+			modelList = append(modelList, model)
+		}
+	}
+	equivalent.GpuModel = strings.Join(modelList, ",")
+
+	equivalent.Key = fmt.Sprintf("%dvCPU-%sGiB", equivalent.VCpu, strconv.FormatFloat(equivalent.MemGiB, 'f', -1, 64))
+	if equivalent.GpuCount > 0 {
+		equivalent.Key += fmt.Sprintf("-%dx%s", equivalent.GpuCount, equivalent.GpuModel)
+	}
+	return equivalent, vCpuOK, memOK
+}
+
+// parse the number in front of the unit, ex) "4", "16384", "16384MB", "2.5"
+func parseSpecNumber(str string) (float64, bool) {
+	str = strings.TrimSpace(str)
+	end := 0
+	for end < len(str) && (str[end] == '.' || (str[end] >= '0' && str[end] <= '9')) {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(str[:end], 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+// group the sorted specs by the equivalent shape, a group keeps the closest spec of each connection.
+func groupEquivalentSpec(specList []*VMSpecSearchResult) []*EquivalentSpecGroup {
+	groupList := []*EquivalentSpecGroup{}
+	groupMap := map[string]*EquivalentSpecGroup{}
+	connMap := map[string]bool{}
+	for _, result := range specList {
+		group, ok := groupMap[result.Equivalent.Key]
+		if !ok {
+			group = &EquivalentSpecGroup{
+				Equivalent: result.Equivalent,
+				Distance:   result.Distance,
+				SpecList:   []*SpecSummary{},
+			}
+			groupMap[result.Equivalent.Key] = group
+			groupList = append(groupList, group)
+		}
+		if connMap[result.Equivalent.Key+"/"+result.Connection] {
+			continue
+		}
+		connMap[result.Equivalent.Key+"/"+result.Connection] = true
+		group.SpecList = append(group.SpecList, &SpecSummary{
+			Connection: result.Connection,
+			Provider:   result.Provider,
+			Region:     result.VMSpecInfo.Region,
+			Name:       result.VMSpecInfo.Name,
+		})
+	}
+	return groupList
+}
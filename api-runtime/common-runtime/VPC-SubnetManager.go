@@ -28,8 +28,9 @@ import (
 // (2) get resource info(CSP-ID)
 // (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 // (4) insert spiderIID
-func RegisterVPC(connectionName string, userIID cres.IID) (*cres.VPCInfo, error) {
+func RegisterVPC(connectionName string, userIID cres.IID) (_ *cres.VPCInfo, retErr error) {
         cblog.Info("call RegisterVPC()")
+        defer func() { emitResourceEvent(EVENT_REGISTERED, connectionName, rsVPC, userIID.NameId, retErr) }()
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -54,6 +55,7 @@ func RegisterVPC(connectionName string, userIID cres.IID) (*cres.VPCInfo, error)
                 cblog.Error(err)
                 return nil, err
         }
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
         if err != nil {
@@ -132,8 +134,9 @@ func RegisterVPC(connectionName string, userIID cres.IID) (*cres.VPCInfo, error)
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo) (*cres.VPCInfo, error) {
+func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo) (_ *cres.VPCInfo, retErr error) {
 	cblog.Info("call CreateVPC()")
+	defer func(nameID string) { emitResourceEvent(EVENT_CREATED, connectionName, rsType, nameID, retErr) }(reqInfo.IId.NameId)
 
 	// check empty and trim user inputs
         connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
@@ -160,6 +163,7 @@ func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo) (*
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
 	if err != nil {
@@ -361,6 +365,7 @@ func ListVPC(connectionName string, rsType string) ([]*cres.VPCInfo, error) {
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
 	if err != nil {
@@ -491,6 +496,7 @@ func GetVPC(connectionName string, rsType string, nameID string) (*cres.VPCInfo,
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
 	if err != nil {
@@ -559,6 +565,7 @@ func AddSubnet(connectionName string, rsType string, vpcName string, reqInfo cre
 		cblog.Error(err)
 		return nil, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
 	if err != nil {
@@ -684,6 +691,7 @@ func RemoveSubnet(connectionName string, vpcName string, nameID string, force st
 		cblog.Error(err)
 		return false, err
 	}
+	defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
 	if err != nil {
@@ -766,6 +774,7 @@ func RemoveCSPSubnet(connectionName string, vpcName string, systemID string) (bo
                 cblog.Error(err)
                 return false, err
         }
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateVPCHandler()
         if err != nil {
@@ -8,6 +8,7 @@
 package commonruntime
 
 import (
+	"context"
 	"time"
 )
 
@@ -16,13 +17,18 @@ type WAITER struct {
         start 	 time.Time
 	Sleep 	 int  // sec, default = 1
 	Timeout  int  // sec, default = 120
-	
+	ctx	 context.Context // stop waiting when the request is canceled
 }
 //============================================
 
 func NewWaiter(sleep int, timeout int) *WAITER {
+	return NewWaiterWithContext(context.Background(), sleep, timeout)
+}
+
+func NewWaiterWithContext(ctx context.Context, sleep int, timeout int) *WAITER {
 	var waiter = new(WAITER)
 	waiter.start = time.Now()
+	waiter.ctx = ctx
 	waiter.Sleep = 1
 	waiter.Timeout = 120
 
@@ -38,11 +44,31 @@ func NewWaiter(sleep int, timeout int) *WAITER {
 }
 
 func (waiter *WAITER)Wait() bool {
+	if waiter.Canceled() {
+		return false // stop waiting
+	}
+
 	elapsed := time.Since(waiter.start)
 
 	if int(elapsed.Seconds()) < waiter.Timeout {
-		time.Sleep(time.Duration(waiter.Sleep) * time.Second)
-		return true // more waiting
+		timer := time.NewTimer(time.Duration(waiter.Sleep) * time.Second)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return true // more waiting
+		case <-waiter.ctx.Done():
+			return false // stop waiting
+		}
 	}
 	return false // stop waiting
 }
+
+// true if the waiting was stopped by the canceled request, not by the timeout
+func (waiter *WAITER)Canceled() bool {
+	return waiter.ctx.Err() != nil
+}
+
+// context.Canceled or context.DeadlineExceeded
+func (waiter *WAITER)Err() error {
+	return waiter.ctx.Err()
+}
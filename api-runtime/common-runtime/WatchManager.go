@@ -0,0 +1,358 @@
+// Resource Status Watch Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+)
+
+//================ Resource Status Watch
+
+// the resource types of the watch
+var watchRsTypeList = []string{rsVM, rsNLB, rsCluster}
+
+// the health status of the NLB
+const (
+	NLB_HEALTHY   = "Healthy"   // all VMs are healthy
+	NLB_DEGRADED  = "Degraded"  // some VMs are unhealthy
+	NLB_UNHEALTHY = "Unhealthy" // no healthy VM
+)
+
+// the status of the resource removed while watching all resources of a type
+const WATCH_NOT_EXIST = "NotExist"
+
+// the interval of the shared pollers, applied to the pollers started after the change
+var WatchPollInterval = 5 * time.Second
+
+// the buffer of a watcher, the channel of the slow watcher is closed when the buffer is full,
+// so the client reconnects and gets the current states.
+const watchEventBufferSize = 100
+
+type WatchEvent struct {
+	ConnectionName string
+	ResourceType   string // "vm", "nlb" or "cluster"
+	ResourceName   string // the NameId of the resource, empty with the Error of the all resources poll
+
+	OldStatus string `json:",omitempty"` // empty: the first status
+	Status    string `json:",omitempty"` // VM: VMStatus, NLB: NLB_HEALTHY | NLB_DEGRADED | NLB_UNHEALTHY, Cluster: ClusterStatus
+	Detail    string `json:",omitempty"` // NLB, ex) "HealthyVMs: 2/3"
+
+	Error string `json:",omitempty"` // the error of the poll, the last status is kept
+
+	Time time.Time
+}
+
+type watchState struct {
+	status string
+	detail string
+	err    string
+}
+
+// a poller is shared by the watchers of the same connection, resource type and name.
+type statusPoller struct {
+	connectionName string
+	rsType         string
+	nameID         string // empty: all resources of the type
+
+	stateMap   map[string]*watchState // NameId => the last state
+	pollErr    string                 // the last error of the all resources poll
+	watcherMap map[chan *WatchEvent]bool
+	stopChan   chan struct{}
+}
+
+// <ConnectionName>/<ResourceType>/<NameId> => the poller
+var statusPollerMap = map[string]*statusPoller{}
+
+var statusPollerMutex sync.Mutex
+
+// watch the status transitions of a resource or all resources of a type(nameID: empty).
+// The current status is sent first, and the channel is closed after the cancel of the ctx,
+// or when the watcher is too slow to receive the events before the ctx is canceled.
+func WatchStatus(ctx context.Context, connectionName string, rsType string, nameID string) (<-chan *WatchEvent, error) {
+	cblog.Info("call WatchStatus()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	rsType = strings.ToLower(strings.TrimSpace(rsType))
+	if !containsFold(watchRsTypeList, rsType) {
+		err := fmt.Errorf("%s is not a resource type of the watch, it should be one of %v!", rsType, watchRsTypeList)
+		cblog.Error(err)
+		return nil, err
+	}
+	nameID = strings.TrimSpace(nameID)
+
+	if _, err := ccim.GetConnectionConfig(connectionName); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	key := connectionName + "/" + rsType + "/" + nameID
+
+	statusPollerMutex.Lock()
+	poller, ok := statusPollerMap[key]
+	if !ok {
+		poller = &statusPoller{
+			connectionName: connectionName,
+			rsType:         rsType,
+			nameID:         nameID,
+			stateMap:       map[string]*watchState{},
+			watcherMap:     map[chan *WatchEvent]bool{},
+			stopChan:       make(chan struct{}),
+		}
+		statusPollerMap[key] = poller
+		go poller.run(WatchPollInterval)
+	}
+	// the new watcher of the running poller gets the last states
+	watchChan := make(chan *WatchEvent, len(poller.stateMap)+watchEventBufferSize)
+	for _, name := range poller.sortedNames() {
+		state := poller.stateMap[name]
+		if state.status != "" {
+			watchChan <- poller.newEvent(name, "", state.status, state.detail, "")
+		}
+	}
+	poller.watcherMap[watchChan] = true
+	statusPollerMutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		statusPollerMutex.Lock()
+		defer statusPollerMutex.Unlock()
+		// the channel of the slow watcher is already closed
+		if poller.watcherMap[watchChan] {
+			delete(poller.watcherMap, watchChan)
+			close(watchChan)
+		}
+		// the last watcher stops the poller
+		if len(poller.watcherMap) == 0 && statusPollerMap[key] == poller {
+			close(poller.stopChan)
+			delete(statusPollerMap, key)
+		}
+	}()
+
+	return watchChan, nil
+}
+
+// the number of the running pollers
+func CountStatusPoller() int {
+	statusPollerMutex.Lock()
+	defer statusPollerMutex.Unlock()
+	return len(statusPollerMap)
+}
+
+func (poller *statusPoller) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		stateMap, err := poller.poll()
+
+		statusPollerMutex.Lock()
+		select {
+		case <-poller.stopChan:
+			statusPollerMutex.Unlock()
+			return
+		default:
+		}
+		poller.update(stateMap, err)
+		statusPollerMutex.Unlock()
+
+		select {
+		case <-poller.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// send the changed states to the watchers, called with the statusPollerMutex.
+func (poller *statusPoller) update(stateMap map[string]*watchState, pollErr error) {
+	if pollErr != nil {
+		if pollErr.Error() != poller.pollErr {
+			poller.pollErr = pollErr.Error()
+			poller.broadcast(poller.newEvent(poller.nameID, "", "", "", poller.pollErr))
+		}
+		return
+	}
+	poller.pollErr = ""
+
+	names := []string{}
+	for name := range stateMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		state := stateMap[name]
+		oldState, ok := poller.stateMap[name]
+		if !ok {
+			oldState = &watchState{}
+			poller.stateMap[name] = oldState
+		}
+		if state.err != "" {
+			if state.err != oldState.err {
+				oldState.err = state.err
+				poller.broadcast(poller.newEvent(name, "", "", "", state.err))
+			}
+			continue
+		}
+		oldState.err = ""
+		if state.status == oldState.status && state.detail == oldState.detail {
+			continue
+		}
+		poller.broadcast(poller.newEvent(name, oldState.status, state.status, state.detail, ""))
+		oldState.status, oldState.detail = state.status, state.detail
+	}
+
+	// the removed resources of the all resources poll
+	if poller.nameID == "" {
+		for _, name := range poller.sortedNames() {
+			if _, ok := stateMap[name]; ok {
+				continue
+			}
+			poller.broadcast(poller.newEvent(name, poller.stateMap[name].status, WATCH_NOT_EXIST, "", ""))
+			delete(poller.stateMap, name)
+		}
+	}
+}
+
+// The slow watcher does not get the rest of the events, so its channel is closed instead of dropping an event.
+// The poller is stopped by the cancel of the last watcher.
+func (poller *statusPoller) broadcast(event *WatchEvent) {
+	for watchChan := range poller.watcherMap {
+		select {
+		case watchChan <- event:
+		default:
+			cblog.Errorf("the watcher of %s/%s is slow, the watch is closed at the event of %s!", poller.connectionName, poller.rsType, event.ResourceName)
+			delete(poller.watcherMap, watchChan)
+			close(watchChan)
+		}
+	}
+}
+
+func (poller *statusPoller) newEvent(nameID string, oldStatus string, status string, detail string, errMsg string) *WatchEvent {
+	return &WatchEvent{
+		ConnectionName: poller.connectionName,
+		ResourceType:   poller.rsType,
+		ResourceName:   nameID,
+		OldStatus:      oldStatus,
+		Status:         status,
+		Detail:         detail,
+		Error:          errMsg,
+		Time:           time.Now(),
+	}
+}
+
+func (poller *statusPoller) sortedNames() []string {
+	names := []string{}
+	for name := range poller.stateMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// get the states of the resources, a resource error is kept in the state.
+func (poller *statusPoller) poll() (map[string]*watchState, error) {
+	stateMap := map[string]*watchState{}
+	switch poller.rsType {
+	case rsVM:
+		if poller.nameID != "" {
+			status, err := GetVMStatus(poller.connectionName, rsVM, poller.nameID)
+			stateMap[poller.nameID] = newWatchState(string(status), "", err)
+			return stateMap, nil
+		}
+		statusList, err := ListVMStatus(poller.connectionName, rsVM)
+		if err != nil {
+			return nil, err
+		}
+		for _, statusInfo := range statusList {
+			stateMap[statusInfo.IId.NameId] = &watchState{status: string(statusInfo.VmStatus)}
+		}
+
+	case rsNLB:
+		nameList := []string{poller.nameID}
+		if poller.nameID == "" {
+			nlbList, err := ListNLB(poller.connectionName, rsNLB)
+			if err != nil {
+				return nil, err
+			}
+			nameList = []string{}
+			for _, nlbInfo := range nlbList {
+				nameList = append(nameList, nlbInfo.IId.NameId)
+			}
+		}
+		for _, name := range nameList {
+			healthInfo, err := GetVMGroupHealthInfo(poller.connectionName, name)
+			if err != nil {
+				stateMap[name] = newWatchState("", "", err)
+				continue
+			}
+			status, detail := getNLBHealthStatus(healthInfo)
+			stateMap[name] = &watchState{status: status, detail: detail}
+		}
+
+	case rsCluster:
+		if poller.nameID != "" {
+			clusterInfo, err := GetCluster(poller.connectionName, rsCluster, poller.nameID)
+			if err != nil {
+				stateMap[poller.nameID] = newWatchState("", "", err)
+				return stateMap, nil
+			}
+			stateMap[poller.nameID] = &watchState{status: string(clusterInfo.Status)}
+			return stateMap, nil
+		}
+		clusterList, err := ListCluster(poller.connectionName, "", rsCluster)
+		if err != nil {
+			return nil, err
+		}
+		for _, clusterInfo := range clusterList {
+			stateMap[clusterInfo.IId.NameId] = &watchState{status: string(clusterInfo.Status)}
+		}
+	}
+	return stateMap, nil
+}
+
+func newWatchState(status string, detail string, err error) *watchState {
+	if err != nil {
+		return &watchState{err: err.Error()}
+	}
+	return &watchState{status: status, detail: detail}
+}
+
+func getNLBHealthStatus(healthInfo *cres.HealthInfo) (string, string) {
+	allCount, healthyCount := 0, 0
+	if healthInfo.AllVMs != nil {
+		allCount = len(*healthInfo.AllVMs)
+	}
+	if healthInfo.HealthyVMs != nil {
+		healthyCount = len(*healthInfo.HealthyVMs)
+	}
+
+	status := NLB_DEGRADED
+	if healthyCount == 0 {
+		status = NLB_UNHEALTHY
+	} else if healthyCount == allCount {
+		status = NLB_HEALTHY
+	}
+	return status, fmt.Sprintf("HealthyVMs: %d/%d", healthyCount, allCount)
+}
@@ -0,0 +1,612 @@
+// Webhook Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package commonruntime
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+)
+
+//================ Webhook Subscription and Delivery
+
+const (
+	defaultWebhookMaxRetries    = 3
+	maxWebhookMaxRetries        = 10
+	defaultWebhookRetryInterval = 2   // seconds, doubled at each retry
+	maxWebhookRetryInterval     = 300 // seconds
+	maxWebhookDeliveries        = 100 // the delivery history of a webhook
+	webhookTimeout              = 10 * time.Second
+)
+
+// the status of a delivery
+const (
+	DELIVERY_PENDING   = "pending" // retrying
+	DELIVERY_SUCCEEDED = "succeeded"
+	DELIVERY_FAILED    = "failed"
+)
+
+// the headers of a delivery
+// X-Spider-Signature: "sha256=" + hex(HMAC-SHA256(Secret, X-Spider-Timestamp + "." + body))
+const (
+	webhookEventHeader     = "X-Spider-Event"
+	webhookDeliveryHeader  = "X-Spider-Delivery"
+	webhookTimestampHeader = "X-Spider-Timestamp"
+	webhookSignatureHeader = "X-Spider-Signature"
+)
+
+type WebhookInfo struct {
+	Name   string // ex) "orchestrator-hook"
+	URL    string // ex) "https://orchestrator.example.com/spider/events"
+	Secret string `json:",omitempty"` // the key of the signature, generated if empty, shown only at the creation, stored encrypted
+
+	EventTypes      []string // empty: all, ex) ["created", "status-changed"]
+	ConnectionNames []string // empty: all connections
+	ResourceTypes   []string // empty: all, ex) ["vm", "disk"]
+
+	MaxRetries       int // 0: default(3), max 10
+	RetryIntervalSec int // 0: default(2), doubled at each retry
+
+	CreatedTime time.Time
+}
+
+type WebhookDelivery struct {
+	DeliveryId      string
+	WebhookName     string
+	Event           *ResourceEvent
+	Status          string // DELIVERY_PENDING | DELIVERY_SUCCEEDED | DELIVERY_FAILED
+	Attempts        int
+	ResponseCode    int    `json:",omitempty"`
+	Error           string `json:",omitempty"`
+	LastAttemptTime time.Time
+}
+
+// format
+// /event-spaces/webhooks/<WebhookName> [WebhookInfo(json), the Secret is encrypted by the SPIDER_KEY]
+// /event-spaces/deliveries/<WebhookName>/<DeliveryId> [WebhookDelivery(json)]
+const (
+	webhookKeyPrefix         = "/event-spaces/webhooks/"
+	webhookDeliveryKeyPrefix = "/event-spaces/deliveries/"
+)
+
+var eventStore icbs.Store
+
+// WebhookName => WebhookInfo, the cache of the stored webhooks
+var webhookMap map[string]*WebhookInfo
+var webhookMutex sync.RWMutex
+
+// the serialized saving of the delivery history
+var deliveryMutex sync.Mutex
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+func init() {
+	eventStore = cbstore.GetStore()
+}
+
+func CreateWebhook(webhookInfo WebhookInfo) (*WebhookInfo, error) {
+	cblog.Info("call CreateWebhook()")
+
+	if err := checkWebhookInfo(&webhookInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := loadWebhookMap(); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	webhookMutex.Lock()
+	defer webhookMutex.Unlock()
+
+	if _, ok := webhookMap[webhookInfo.Name]; ok {
+		err := fmt.Errorf("The Webhook(%s) already exists!", webhookInfo.Name)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if webhookInfo.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		webhookInfo.Secret = secret
+	}
+	webhookInfo.CreatedTime = time.Now()
+
+	storedInfo := webhookInfo
+	encryptedSecret, err := cim.EncryptSecret(webhookSecretAAD(webhookInfo.Name), webhookInfo.Secret)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	storedInfo.Secret = encryptedSecret
+	if err := putEventValue(webhookKeyPrefix+webhookInfo.Name, storedInfo); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	info := webhookInfo
+	webhookMap[webhookInfo.Name] = &info
+
+	return &webhookInfo, nil
+}
+
+// the Secret is not included.
+func ListWebhook() ([]*WebhookInfo, error) {
+	cblog.Info("call ListWebhook()")
+
+	if err := loadWebhookMap(); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	webhookMutex.RLock()
+	defer webhookMutex.RUnlock()
+
+	infoList := []*WebhookInfo{}
+	for _, webhookInfo := range webhookMap {
+		info := *webhookInfo
+		info.Secret = ""
+		infoList = append(infoList, &info)
+	}
+	sort.Slice(infoList, func(i, j int) bool {
+		return infoList[i].Name < infoList[j].Name
+	})
+	return infoList, nil
+}
+
+// the Secret is not included.
+func GetWebhook(webhookName string) (*WebhookInfo, error) {
+	cblog.Info("call GetWebhook()")
+
+	webhookName, err := EmptyCheckAndTrim("webhookName", webhookName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if err := loadWebhookMap(); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	webhookMutex.RLock()
+	defer webhookMutex.RUnlock()
+
+	webhookInfo, ok := webhookMap[webhookName]
+	if !ok {
+		err := fmt.Errorf("The Webhook(%s) does not exist!", webhookName)
+		cblog.Error(err)
+		return nil, err
+	}
+	info := *webhookInfo
+	info.Secret = ""
+	return &info, nil
+}
+
+// the delivery history of the webhook is deleted together.
+func DeleteWebhook(webhookName string) (bool, error) {
+	cblog.Info("call DeleteWebhook()")
+
+	webhookName, err := EmptyCheckAndTrim("webhookName", webhookName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	if err := loadWebhookMap(); err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// the same order as saveWebhookDelivery(): deliveryMutex => webhookMutex
+	deliveryMutex.Lock()
+	defer deliveryMutex.Unlock()
+	webhookMutex.Lock()
+	defer webhookMutex.Unlock()
+
+	if _, ok := webhookMap[webhookName]; !ok {
+		err := fmt.Errorf("The Webhook(%s) does not exist!", webhookName)
+		cblog.Error(err)
+		return false, err
+	}
+
+	if err := eventStore.Delete(webhookKeyPrefix + webhookName); err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	delete(webhookMap, webhookName)
+
+	deliveryList, err := listWebhookDeliveryValue(webhookName)
+	if err != nil {
+		cblog.Error(err)
+		return true, nil
+	}
+	for _, delivery := range deliveryList {
+		eventStore.Delete(webhookDeliveryKeyPrefix + webhookName + "/" + delivery.DeliveryId)
+	}
+	return true, nil
+}
+
+// the delivery history of the webhook, the latest first.
+func ListWebhookDelivery(webhookName string) ([]*WebhookDelivery, error) {
+	cblog.Info("call ListWebhookDelivery()")
+
+	webhookName, err := EmptyCheckAndTrim("webhookName", webhookName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if _, err := GetWebhook(webhookName); err != nil {
+		return nil, err
+	}
+
+	deliveryMutex.Lock()
+	defer deliveryMutex.Unlock()
+	deliveryList, err := listWebhookDeliveryValue(webhookName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return deliveryList, nil
+}
+
+func checkWebhookInfo(webhookInfo *WebhookInfo) error {
+	var err error
+	webhookInfo.Name, err = EmptyCheckAndTrim("Name", webhookInfo.Name)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(webhookInfo.Name, "/") {
+		return fmt.Errorf("The Webhook Name(%s) can not include '/'!", webhookInfo.Name)
+	}
+
+	webhookInfo.URL, err = EmptyCheckAndTrim("URL", webhookInfo.URL)
+	if err != nil {
+		return err
+	}
+	parsedURL, err := url.Parse(webhookInfo.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("The URL(%s) should be an http or https URL!", webhookInfo.URL)
+	}
+
+	for i, eventType := range webhookInfo.EventTypes {
+		eventType = strings.ToLower(strings.TrimSpace(eventType))
+		found := false
+		for _, one := range eventTypeList {
+			if one == eventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s is not an event type, it should be one of %v!", eventType, eventTypeList)
+		}
+		webhookInfo.EventTypes[i] = eventType
+	}
+
+	if webhookInfo.MaxRetries < 0 || webhookInfo.RetryIntervalSec < 0 {
+		return fmt.Errorf("MaxRetries and RetryIntervalSec can not be negative!")
+	}
+	if webhookInfo.MaxRetries == 0 {
+		webhookInfo.MaxRetries = defaultWebhookMaxRetries
+	}
+	if webhookInfo.MaxRetries > maxWebhookMaxRetries {
+		webhookInfo.MaxRetries = maxWebhookMaxRetries
+	}
+	if webhookInfo.RetryIntervalSec == 0 {
+		webhookInfo.RetryIntervalSec = defaultWebhookRetryInterval
+	}
+	return nil
+}
+
+// binds the encrypted Secret to the webhook
+func webhookSecretAAD(webhookName string) string {
+	return "webhook/" + webhookName
+}
+
+func generateWebhookSecret() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(randomBytes), nil
+}
+
+// load the stored webhooks into the cache at the first call.
+func loadWebhookMap() error {
+	webhookMutex.Lock()
+	defer webhookMutex.Unlock()
+
+	if webhookMap != nil {
+		return nil
+	}
+
+	keyValueList, err := eventStore.GetList(webhookKeyPrefix, true)
+	if err != nil {
+		return err
+	}
+	// a broken webhook is skipped, so it does not stop the others.
+	loadedMap := map[string]*WebhookInfo{}
+	for _, keyValue := range keyValueList {
+		webhookInfo := &WebhookInfo{}
+		if err := json.Unmarshal([]byte(keyValue.Value), webhookInfo); err != nil {
+			cblog.Errorf("The Webhook(%s) is skipped: %v", keyValue.Key, err)
+			continue
+		}
+		secret, err := cim.DecryptSecret(webhookSecretAAD(webhookInfo.Name), webhookInfo.Secret)
+		if err != nil {
+			cblog.Errorf("The Webhook(%s) is skipped: failed to decrypt the Secret: %v", webhookInfo.Name, err)
+			continue
+		}
+		webhookInfo.Secret = secret
+		loadedMap[webhookInfo.Name] = webhookInfo
+	}
+	webhookMap = loadedMap
+	return nil
+}
+
+// the webhooks subscribing the event
+func getEventWebhookList(event *ResourceEvent) []*WebhookInfo {
+	if err := loadWebhookMap(); err != nil {
+		cblog.Error(err)
+		return nil
+	}
+
+	webhookMutex.RLock()
+	defer webhookMutex.RUnlock()
+
+	webhookList := []*WebhookInfo{}
+	for _, webhookInfo := range webhookMap {
+		if len(webhookInfo.EventTypes) > 0 && !containsFold(webhookInfo.EventTypes, event.EventType) {
+			continue
+		}
+		if len(webhookInfo.ConnectionNames) > 0 && !containsFold(webhookInfo.ConnectionNames, event.ConnectionName) {
+			continue
+		}
+		if len(webhookInfo.ResourceTypes) > 0 && !containsFold(webhookInfo.ResourceTypes, event.ResourceType) {
+			continue
+		}
+		info := *webhookInfo
+		webhookList = append(webhookList, &info)
+	}
+	return webhookList
+}
+
+func hasWebhook() bool {
+	if err := loadWebhookMap(); err != nil {
+		return false
+	}
+	webhookMutex.RLock()
+	defer webhookMutex.RUnlock()
+	return len(webhookMap) > 0
+}
+
+// deliver the event to the webhook with the retries.
+// The interval of the retries is doubled at each retry up to 300 seconds.
+func deliverWebhook(webhookInfo *WebhookInfo, event *ResourceEvent) {
+	delivery := &WebhookDelivery{
+		DeliveryId:  event.EventId,
+		WebhookName: webhookInfo.Name,
+		Event:       event,
+		Status:      DELIVERY_PENDING,
+	}
+	runWebhookDelivery(webhookInfo, delivery)
+}
+
+// attempt the delivery until it succeeds or the retries are exhausted.
+// The pending delivery resumed at the server start continues its Attempts.
+func runWebhookDelivery(webhookInfo *WebhookInfo, delivery *WebhookDelivery) {
+	event := delivery.Event
+	body, err := json.Marshal(event)
+	if err != nil {
+		delivery.Status = DELIVERY_FAILED
+		delivery.Error = err.Error()
+		saveWebhookDelivery(delivery)
+		return
+	}
+
+	for {
+		// the webhook may be deleted, or deleted and created again, while waiting for the retry
+		if !isWebhookSubscribed(webhookInfo) {
+			cblog.Infof("Webhook(%s): the delivery of the event(%s) is stopped, the Webhook was deleted.", webhookInfo.Name, event.EventId)
+			return
+		}
+
+		delivery.Attempts++
+		delivery.LastAttemptTime = time.Now()
+		delivery.ResponseCode, err = postWebhook(webhookInfo, event, body)
+		if err == nil {
+			delivery.Status = DELIVERY_SUCCEEDED
+			delivery.Error = ""
+			saveWebhookDelivery(delivery)
+			return
+		}
+		delivery.Error = err.Error()
+
+		if delivery.Attempts > webhookInfo.MaxRetries {
+			delivery.Status = DELIVERY_FAILED
+			saveWebhookDelivery(delivery)
+			cblog.Errorf("Webhook(%s): failed to deliver the event(%s): %s", webhookInfo.Name, event.EventId, delivery.Error)
+			return
+		}
+		saveWebhookDelivery(delivery)
+
+		time.Sleep(webhookRetryInterval(webhookInfo, delivery.Attempts))
+	}
+}
+
+// true if the webhook is still the same subscription.
+func isWebhookSubscribed(webhookInfo *WebhookInfo) bool {
+	webhookMutex.RLock()
+	defer webhookMutex.RUnlock()
+
+	current, ok := webhookMap[webhookInfo.Name]
+	return ok && current.CreatedTime.Equal(webhookInfo.CreatedTime)
+}
+
+// RetryIntervalSec * 2^(attempts-1), up to 300 seconds
+func webhookRetryInterval(webhookInfo *WebhookInfo, attempts int) time.Duration {
+	interval := time.Duration(webhookInfo.RetryIntervalSec) * time.Second
+	for i := 1; i < attempts && interval < maxWebhookRetryInterval*time.Second; i++ {
+		interval *= 2
+	}
+	if interval > maxWebhookRetryInterval*time.Second {
+		interval = maxWebhookRetryInterval * time.Second
+	}
+	return interval
+}
+
+// resume the deliveries, which were pending when the server was stopped.
+// called at the server start.
+func ResumeWebhookDeliveries() {
+	if err := loadWebhookMap(); err != nil {
+		cblog.Error(err)
+		return
+	}
+
+	webhookMutex.RLock()
+	webhookList := []*WebhookInfo{}
+	for _, webhookInfo := range webhookMap {
+		info := *webhookInfo
+		webhookList = append(webhookList, &info)
+	}
+	webhookMutex.RUnlock()
+
+	for _, webhookInfo := range webhookList {
+		deliveryMutex.Lock()
+		deliveryList, err := listWebhookDeliveryValue(webhookInfo.Name)
+		deliveryMutex.Unlock()
+		if err != nil {
+			cblog.Error(err)
+			continue
+		}
+		for _, delivery := range deliveryList {
+			if delivery.Status != DELIVERY_PENDING {
+				continue
+			}
+			if delivery.Event == nil {
+				delivery.Status = DELIVERY_FAILED
+				delivery.Error = "The server was stopped while the delivery was pending, and the event is lost."
+				saveWebhookDelivery(delivery)
+				continue
+			}
+			go runWebhookDelivery(webhookInfo, delivery)
+		}
+	}
+}
+
+// post the signed body, the non-2xx response is an error.
+func postWebhook(webhookInfo *WebhookInfo, event *ResourceEvent, body []byte) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, webhookInfo.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhookEventHeader, event.EventType)
+	request.Header.Set(webhookDeliveryHeader, event.EventId)
+	request.Header.Set(webhookTimestampHeader, timestamp)
+	request.Header.Set(webhookSignatureHeader, SignWebhookPayload(webhookInfo.Secret, timestamp, body))
+
+	response, err := webhookClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("the response status is %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// the signature of the X-Spider-Signature header, the receiver can verify the payload with it.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// save the delivery and remove the oldest ones over the maximum.
+func saveWebhookDelivery(delivery *WebhookDelivery) {
+	deliveryMutex.Lock()
+	defer deliveryMutex.Unlock()
+
+	// the webhook may be deleted while delivering
+	webhookMutex.RLock()
+	_, ok := webhookMap[delivery.WebhookName]
+	webhookMutex.RUnlock()
+	if !ok {
+		return
+	}
+
+	keyPrefix := webhookDeliveryKeyPrefix + delivery.WebhookName + "/"
+	if err := putEventValue(keyPrefix+delivery.DeliveryId, delivery); err != nil {
+		cblog.Error(err)
+		return
+	}
+
+	deliveryList, err := listWebhookDeliveryValue(delivery.WebhookName)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	for i := maxWebhookDeliveries; i < len(deliveryList); i++ {
+		eventStore.Delete(keyPrefix + deliveryList[i].DeliveryId)
+	}
+}
+
+// the latest first
+func listWebhookDeliveryValue(webhookName string) ([]*WebhookDelivery, error) {
+	keyValueList, err := eventStore.GetList(webhookDeliveryKeyPrefix+webhookName+"/", true)
+	if err != nil {
+		return nil, err
+	}
+	deliveryList := []*WebhookDelivery{}
+	for _, keyValue := range keyValueList {
+		delivery := &WebhookDelivery{}
+		if err := json.Unmarshal([]byte(keyValue.Value), delivery); err != nil {
+			return nil, err
+		}
+		deliveryList = append(deliveryList, delivery)
+	}
+	// the EventId is ordered by the time
+	sort.Slice(deliveryList, func(i, j int) bool {
+		return deliveryList[i].DeliveryId > deliveryList[j].DeliveryId
+	})
+	return deliveryList, nil
+}
+
+func putEventValue(key string, value interface{}) error {
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return eventStore.Put(key, string(jsonValue))
+}
@@ -12,11 +12,15 @@ import (
         "sync"
         "bytes"
         "fmt"
+        "time"
+
+        "github.com/cloud-barista/cb-spider/cloud-control-manager/metrics"
 )
 
 
 //====================================================================
 type SPLOCK struct {
+        name	string		// ex) "vm", label of metrics
         rwMutex	sync.RWMutex	// lock for handling lockMap
 	lockMap	map[LockKey]*LockValue
 }
@@ -32,8 +36,9 @@ type LockValue struct {
 }
 //====================================================================
 
-func New() *SPLOCK {
+func New(name string) *SPLOCK {
 	var spLock = new (SPLOCK)
+	spLock.name = name
 	spLock.lockMap = make(map[LockKey]*LockValue)
 	return spLock
 }
@@ -46,9 +51,12 @@ spLock.rwMutex.Lock()
 		lockValue = spLock.lockMap[LockKey{conn, id}]
 	}
 	lockValue.count++
+	metrics.SetSPLockMapSize(spLock.name, len(spLock.lockMap))
 spLock.rwMutex.Unlock()
 
+	start := time.Now()
 	lockValue.lock.Lock()
+	metrics.ObserveSPLockWait(spLock.name, "lock", time.Since(start))
 }
 
 func (spLock *SPLOCK)Unlock(conn string, id string) {
@@ -58,6 +66,7 @@ spLock.rwMutex.Lock()
 	if lockValue.count == 0 {
 		delete(spLock.lockMap, LockKey{conn, id})
 	}
+	metrics.SetSPLockMapSize(spLock.name, len(spLock.lockMap))
 spLock.rwMutex.Unlock()
 
         lockValue.lock.Unlock()
@@ -72,9 +81,12 @@ spLock.rwMutex.Lock()
                 lockValue = spLock.lockMap[LockKey{conn, id}]
         }
         lockValue.count++
+	metrics.SetSPLockMapSize(spLock.name, len(spLock.lockMap))
 spLock.rwMutex.Unlock()
 
+	start := time.Now()
         lockValue.lock.RLock()
+	metrics.ObserveSPLockWait(spLock.name, "rlock", time.Since(start))
 }
 
 func (spLock *SPLOCK)RUnlock(conn string, id string) {
@@ -84,6 +96,7 @@ spLock.rwMutex.Lock()
         if lockValue.count == 0 {
                 delete(spLock.lockMap, LockKey{conn, id})
         }
+	metrics.SetSPLockMapSize(spLock.name, len(spLock.lockMap))
 spLock.rwMutex.Unlock()
 
         lockValue.lock.RUnlock()
@@ -0,0 +1,150 @@
+// VM SSH Host Key Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	sshrun "github.com/cloud-barista/cb-spider/cloud-control-manager/vm-ssh"
+
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// start a SSH server which accepts the handshake only, returns the address and the host key.
+func startHostKeyTestServer(t *testing.T) (string, ssh.PublicKey) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	serverConfig := &ssh.ServerConfig{NoClientAuth: true}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				sshConn, _, _, err := ssh.NewServerConn(conn, serverConfig)
+				if err == nil {
+					sshConn.Close()
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+func dialVMHostKey(t *testing.T, vmName string, serverPort string) error {
+	hostKeyCallback, hostKeyAlgorithms, err := cmrt.VMHostKeyCallback(infraTestConnection, vmName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	client, err := ssh.Dial("tcp", serverPort, &ssh.ClientConfig{
+		User:              "cb-user",
+		HostKeyCallback:   hostKeyCallback,
+		HostKeyAlgorithms: hostKeyAlgorithms,
+	})
+	if err != nil {
+		return err
+	}
+	client.Close()
+	return nil
+}
+
+func TestVMHostKey(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	vmName := "infra-vm-01"
+	if _, err := cmrt.GetVMHostKey(infraTestConnection, "not-exist-vm"); err == nil {
+		t.Error("GetVMHostKey() of not existing VM should return an error!")
+	}
+	if _, err := cmrt.GetVMHostKey(infraTestConnection, vmName); err == nil {
+		t.Error("GetVMHostKey() before the first connection should return an error!")
+	}
+
+	// the first connection pins the host key
+	serverPort1, hostKey1 := startHostKeyTestServer(t)
+	if err := dialVMHostKey(t, vmName, serverPort1); err != nil {
+		t.Fatal(err.Error())
+	}
+	hostKeyInfo, err := cmrt.GetVMHostKey(infraTestConnection, vmName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if hostKeyInfo.Fingerprint != ssh.FingerprintSHA256(hostKey1) || hostKeyInfo.ServerPort != serverPort1 {
+		t.Errorf("the pinned host key: %#v", hostKeyInfo)
+	}
+	if err := dialVMHostKey(t, vmName, serverPort1); err != nil {
+		t.Errorf("the connection with the pinned host key: %v", err)
+	}
+
+	// the changed host key is rejected
+	serverPort2, hostKey2 := startHostKeyTestServer(t)
+	err = dialVMHostKey(t, vmName, serverPort2)
+	if !sshrun.IsHostKeyChanged(err) {
+		t.Fatalf("the connection with the changed host key: %v", err)
+	}
+	hostKeyInfo, _ = cmrt.GetVMHostKey(infraTestConnection, vmName)
+	if hostKeyInfo == nil || hostKeyInfo.Fingerprint != ssh.FingerprintSHA256(hostKey1) {
+		t.Errorf("the pinned host key is changed: %#v", hostKeyInfo)
+	}
+
+	// reset, then the next connection pins the new host key
+	if result, err := cmrt.ResetVMHostKey(infraTestConnection, vmName); err != nil || !result {
+		t.Fatalf("ResetVMHostKey(): %v, %v", result, err)
+	}
+	if err := dialVMHostKey(t, vmName, serverPort2); err != nil {
+		t.Fatal(err.Error())
+	}
+	hostKeyInfo, err = cmrt.GetVMHostKey(infraTestConnection, vmName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if hostKeyInfo.Fingerprint != ssh.FingerprintSHA256(hostKey2) {
+		t.Errorf("the re-pinned host key: %#v", hostKeyInfo)
+	}
+
+	// the pinned host key is removed with the VM
+	if _, err := cmrt.DestroyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.GetVMHostKey(infraTestConnection, vmName); err == nil {
+		t.Error("the host key of the re-created VM should not be pinned!")
+	}
+}
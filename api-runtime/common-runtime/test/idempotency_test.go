@@ -0,0 +1,158 @@
+// Idempotency Key Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cbstore "github.com/cloud-barista/cb-store"
+
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotentRequest(t *testing.T) {
+	setupInfraConnection(t)
+
+	scope := "REST:POST /spider/vm"
+	key := "idempotency-test-" + time.Now().Format("150405.000000")
+	requestHash := cmrt.IdempotencyRequestHash([]byte("/spider/vm"), []byte(`{"Name":"vm-01"}`))
+
+	// the first request is reserved
+	reservedRecord, reserved, err := cmrt.BeginIdempotentRequest(scope, key, requestHash)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reserved || reservedRecord == nil || reservedRecord.Status != cmrt.IdempotencyInProgress {
+		t.Fatalf("the first request should be run, got: %+v", reservedRecord)
+	}
+
+	// the concurrent duplicate is InProgress
+	record, reserved, err := cmrt.BeginIdempotentRequest(scope, key, requestHash)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if reserved || record == nil || record.Status != cmrt.IdempotencyInProgress {
+		t.Fatalf("the duplicate should be InProgress, got: %+v", record)
+	}
+
+	// the same key of a different request is rejected
+	otherHash := cmrt.IdempotencyRequestHash([]byte("/spider/vm"), []byte(`{"Name":"vm-02"}`))
+	if _, _, err := cmrt.BeginIdempotentRequest(scope, key, otherHash); !errors.Is(err, cmrt.ErrIdempotencyKeyMismatch) {
+		t.Fatalf("the mismatch should be rejected, got: %v", err)
+	}
+
+	// the same key in another scope is another request
+	record, reserved, err = cmrt.BeginIdempotentRequest("REST:POST /spider/nlb", key, otherHash)
+	if err != nil || !reserved {
+		t.Fatalf("the other scope should be run, got: %+v, %v", record, err)
+	}
+	cmrt.ReleaseIdempotentRequest(record)
+
+	// the completed result is replayed
+	if err := cmrt.CompleteIdempotentRequest(reservedRecord, 200, `{"IId":{"NameId":"vm-01"}}`); err != nil {
+		t.Fatal(err.Error())
+	}
+	// the result is stored encrypted, ex) PrivateKey of KeyPairInfo
+	keyValueList, err := cbstore.GetStore().GetList("/resource-info-spaces/idempotency/", true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, keyValue := range keyValueList {
+		if strings.Contains(keyValue.Value, "vm-01") {
+			t.Errorf("the result is stored in plain text: %s", keyValue.Value)
+		}
+	}
+	record, reserved, err = cmrt.BeginIdempotentRequest(scope, " "+key+" ", requestHash)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if reserved || record == nil || record.Status != cmrt.IdempotencyCompleted || record.StatusCode != 200 || record.Result != `{"IId":{"NameId":"vm-01"}}` {
+		t.Fatalf("the completed result should be replayed, got: %+v", record)
+	}
+
+	// the completed result is not released
+	cmrt.ReleaseIdempotentRequest(reservedRecord)
+	record, reserved, err = cmrt.BeginIdempotentRequest(scope, key, requestHash)
+	if err != nil || reserved || record.Status != cmrt.IdempotencyCompleted {
+		t.Fatalf("the completed result should be kept, got: %+v, %v", record, err)
+	}
+
+	// the released key can be used again
+	otherKey := key + "-released"
+	reservedRecord, _, err = cmrt.BeginIdempotentRequest(scope, otherKey, requestHash)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cmrt.ReleaseIdempotentRequest(reservedRecord)
+	record, reserved, err = cmrt.BeginIdempotentRequest(scope, otherKey, otherHash)
+	if err != nil || !reserved {
+		t.Fatalf("the released key should be run, got: %+v, %v", record, err)
+	}
+	cmrt.ReleaseIdempotentRequest(record)
+}
+
+func TestIdempotentRequestExpire(t *testing.T) {
+	setupInfraConnection(t)
+
+	scope := "GRPC:/cbspider.CCM/StartVM"
+	key := "idempotency-expire-" + time.Now().Format("150405.000000")
+	requestHash := cmrt.IdempotencyRequestHash([]byte("{}"))
+
+	orgTimeout := cmrt.IdempotencyInProgressTimeout
+	orgTTL := cmrt.IdempotencyTTL
+	defer func() {
+		cmrt.IdempotencyInProgressTimeout = orgTimeout
+		cmrt.IdempotencyTTL = orgTTL
+	}()
+
+	// the InProgress key of a stopped server is released after the timeout
+	cmrt.IdempotencyInProgressTimeout = 50 * time.Millisecond
+	timedOutRecord, _, err := cmrt.BeginIdempotentRequest(scope, key, requestHash)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	time.Sleep(100 * time.Millisecond)
+	record, reserved, err := cmrt.BeginIdempotentRequest(scope, key, requestHash)
+	if err != nil || !reserved {
+		t.Fatalf("the timed out key should be run, got: %+v, %v", record, err)
+	}
+
+	// the late release of the timed out request does not release the new reservation
+	cmrt.IdempotencyInProgressTimeout = orgTimeout
+	cmrt.ReleaseIdempotentRequest(timedOutRecord)
+	if record, reserved, err := cmrt.BeginIdempotentRequest(scope, key, requestHash); err != nil || reserved || record.Status != cmrt.IdempotencyInProgress {
+		t.Fatalf("the new reservation should be kept, got: %+v, %v", record, err)
+	}
+
+	// the late completion of the timed out request does not overwrite the new reservation
+	if err := cmrt.CompleteIdempotentRequest(timedOutRecord, 0, "{}"); err == nil {
+		t.Error("the completion of the timed out request should return an error!")
+	}
+
+	// the completed result is expired after the TTL
+	cmrt.IdempotencyTTL = 50 * time.Millisecond
+	if err := cmrt.CompleteIdempotentRequest(record, 0, "{}"); err != nil {
+		t.Fatal(err.Error())
+	}
+	time.Sleep(100 * time.Millisecond)
+	record, reserved, err = cmrt.BeginIdempotentRequest(scope, key, requestHash)
+	if err != nil || !reserved {
+		t.Fatalf("the expired key should be run, got: %+v, %v", record, err)
+	}
+	cmrt.ReleaseIdempotentRequest(record)
+
+	// invalid keys
+	for _, invalidKey := range []string{" ", strings.Repeat("k", 256)} {
+		if _, _, err := cmrt.BeginIdempotentRequest(scope, invalidKey, requestHash); !errors.Is(err, cmrt.ErrIdempotencyKeyInvalid) {
+			t.Errorf("the invalid key should be rejected, got: %v", err)
+		}
+	}
+}
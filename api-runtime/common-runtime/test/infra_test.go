@@ -0,0 +1,237 @@
+// Infra Plan/Apply/Destroy Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	dim "github.com/cloud-barista/cb-spider/cloud-info-manager/driver-info-manager"
+	rim "github.com/cloud-barista/cb-spider/cloud-info-manager/region-info-manager"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+
+	"context"
+	"encoding/base64"
+	"os"
+	"testing"
+)
+
+const infraTestConnection = "infra-test-mock-config01"
+
+const infraTestDoc = `
+ConnectionName: infra-test-mock-config01
+VPCList:
+  - Name: infra-vpc-01
+    IPv4_CIDR: 192.168.0.0/16
+    SubnetInfoList:
+      - Name: infra-subnet-01
+        IPv4_CIDR: 192.168.1.0/24
+SecurityGroupList:
+  - Name: infra-sg-01
+    VPCName: infra-vpc-01
+    SecurityRules:
+      - {Direction: inbound, IPProtocol: TCP, FromPort: "22", ToPort: "22", CIDR: 0.0.0.0/0}
+KeyPairList:
+  - Name: infra-keypair-01
+VMList:
+  - Name: infra-vm-01
+    ImageName: mock-vmimage-01
+    VMSpecName: mock-vmspec-01
+    VPCName: infra-vpc-01
+    SubnetName: infra-subnet-01
+    SecurityGroupNames: [infra-sg-01]
+    KeyPairName: infra-keypair-01
+    TagList: [{Key: env, Value: ""}]
+`
+
+func setupInfraConnection(t *testing.T) {
+	// the Credentials are encrypted with the test key
+	if os.Getenv("SPIDER_KEY") == "" && os.Getenv("SPIDER_KEY_FILE") == "" {
+		os.Setenv("SPIDER_KEY", base64.StdEncoding.EncodeToString([]byte("infra-test-spider-key-0123456789")))
+		cim.SetSecretKeyProvider(nil)
+	}
+
+	dim.RegisterCloudDriver("infra-test-mock-driver01", "MOCK", "mock-driver-v1.0.so")
+	cim.RegisterCredential("infra-test-mock-credential01", "MOCK", []icbs.KeyValue{{"MockName", "infra-test-mock01"}})
+	rim.RegisterRegion("infra-test-mock-region01", "MOCK", []icbs.KeyValue{{"Region", "default"}})
+	ccim.CreateConnectionConfig(infraTestConnection, "MOCK", "infra-test-mock-driver01", "infra-test-mock-credential01", "infra-test-mock-region01")
+	if _, err := ccim.GetConnectionConfig(infraTestConnection); err != nil {
+		t.Fatal(err.Error())
+	}
+	// the other tests share the cloud info
+	t.Cleanup(func() {
+		ccim.DeleteConnectionConfig(infraTestConnection)
+		cim.UnRegisterCredential("infra-test-mock-credential01")
+		rim.UnRegisterRegion("infra-test-mock-region01")
+		dim.UnRegisterCloudDriver("infra-test-mock-driver01")
+	})
+}
+
+func countAction(plan *cmrt.InfraPlan, action string) int {
+	count := 0
+	for _, item := range plan.ItemList {
+		if item.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+func TestInfraPlanApplyDestroy(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	plan, err := cmrt.PlanInfra(doc, cmrt.INFRA_APPLY)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// VPC => SG => KeyPair => VM
+	orderList := []string{"vpc", "sg", "keypair", "vm"}
+	if len(plan.ItemList) != len(orderList) || countAction(plan, cmrt.INFRA_CREATE) != len(orderList) {
+		t.Fatalf("Plan: %s, %v", plan.Summary, plan.ItemList)
+	}
+	for idx, rsType := range orderList {
+		if plan.ItemList[idx].ResourceType != rsType {
+			t.Errorf("Plan item %d is not %s. It is %s.", idx, rsType, plan.ItemList[idx].ResourceType)
+		}
+	}
+
+	plan, err = cmrt.ApplyInfra(context.Background(), doc)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(plan.PrivateKeyList) != 1 || plan.PrivateKeyList[0].Key != "infra-keypair-01" {
+		t.Errorf("PrivateKeyList: %v", plan.PrivateKeyList)
+	}
+
+	// the applied resources exist
+	plan, err = cmrt.PlanInfra(doc, cmrt.INFRA_APPLY)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// the Subnet of the existing VPC is also shown
+	if countAction(plan, cmrt.INFRA_EXISTS) != len(plan.ItemList) || plan.ItemList[1].ResourceType != "subnet" {
+		t.Errorf("Plan after apply: %s", plan.Summary)
+	}
+
+	plan, err = cmrt.DestroyInfra(context.Background(), doc)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// reverse order
+	for idx, rsType := range orderList {
+		item := plan.ItemList[len(orderList)-1-idx]
+		if item.ResourceType != rsType || item.Status != cmrt.INFRA_DONE {
+			t.Errorf("Destroy item: %v", item)
+		}
+	}
+
+	plan, err = cmrt.PlanInfra(doc, cmrt.INFRA_DESTROY)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if countAction(plan, cmrt.INFRA_NOTFOUND) != len(orderList) {
+		t.Errorf("Plan after destroy: %s", plan.Summary)
+	}
+}
+
+func TestInfraDestroyNotOwned(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	// the resources were not created by the other infra
+	otherDoc := *doc
+	otherDoc.Name = "infra-test-other"
+	plan, err := cmrt.DestroyInfra(context.Background(), &otherDoc)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if countAction(plan, cmrt.INFRA_NOTOWNED) != len(plan.ItemList) {
+		t.Errorf("Destroy of the other infra: %s", plan.Summary)
+	}
+
+	plan, err = cmrt.PlanInfra(doc, cmrt.INFRA_DESTROY)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if countAction(plan, cmrt.INFRA_DELETE) != len(plan.ItemList) {
+		t.Errorf("Plan after the destroy of the other infra: %s", plan.Summary)
+	}
+}
+
+func TestInfraApplyRollback(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// the VM with a wrong image fails after the VPC, SG and KeyPair are created
+	doc.VMList[0].ImageName = "infra-test-none-image"
+
+	plan, err := cmrt.ApplyInfra(context.Background(), doc)
+	if err == nil {
+		cmrt.DestroyInfra(context.Background(), doc)
+		t.Fatal("ApplyInfra() with a wrong image should return an error!")
+	}
+	if plan == nil {
+		t.Fatal(err.Error())
+	}
+	for _, item := range plan.ItemList {
+		expected := cmrt.INFRA_ROLLEDBACK
+		if item.ResourceType == "vm" {
+			expected = cmrt.INFRA_FAILED
+		}
+		if item.Status != expected {
+			t.Errorf("Status of %s:%s is not %s. It is %s.", item.ResourceType, item.Name, expected, item.Status)
+		}
+	}
+
+	plan, err = cmrt.PlanInfra(doc, cmrt.INFRA_APPLY)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if countAction(plan, cmrt.INFRA_CREATE) != len(plan.ItemList) {
+		t.Errorf("Plan after rollback: %s", plan.Summary)
+	}
+}
+
+func TestInfraDocCheck(t *testing.T) {
+	setupInfraConnection(t)
+
+	if _, err := cmrt.ParseInfraDoc([]byte("ConnectionName: a\nVMLst: []\n")); err == nil {
+		t.Error("ParseInfraDoc() with an unknown field should return an error!")
+	}
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	doc.VMList[0].SecurityGroupNames = []string{"infra-sg-none"}
+	if _, err := cmrt.PlanInfra(doc, cmrt.INFRA_APPLY); err == nil {
+		t.Error("PlanInfra() with a not existing SecurityGroup should return an error!")
+	}
+
+	doc.VMList = append(doc.VMList, doc.VMList[0])
+	if _, err := cmrt.PlanInfra(doc, cmrt.INFRA_APPLY); err == nil {
+		t.Error("PlanInfra() with a duplicated VM should return an error!")
+	}
+}
@@ -0,0 +1,77 @@
+// Multi-Cloud Inventory Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"context"
+	"testing"
+)
+
+func TestListInventory(t *testing.T) {
+	setupInfraConnection(t)
+
+	_, err := cmrt.CreateVPC(infraTestConnection, "vpc", cres.VPCReqInfo{
+		IId:            cres.IID{"inventory-vpc-01", ""},
+		IPv4_CIDR:      "10.0.0.0/16",
+		SubnetInfoList: []cres.SubnetInfo{{IId: cres.IID{"inventory-subnet-01", ""}, IPv4_CIDR: "10.0.1.0/24"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteResource(context.Background(), infraTestConnection, "vpc", "inventory-vpc-01", "true")
+
+	reqInfo := cmrt.InventoryReqInfo{
+		ConnectionNames: []string{infraTestConnection},
+		RegionNames:     []string{"default"}, // the CSP region of the region config
+		ResourceTypes:   []string{"VPC", "keypair"},
+	}
+	inventoryInfo, err := cmrt.ListInventory(context.Background(), reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(inventoryInfo.InventoryList) != 1 || len(inventoryInfo.ErrorList) != 0 {
+		t.Fatalf("InventoryList: %v, ErrorList: %v", inventoryInfo.InventoryList, inventoryInfo.ErrorList)
+	}
+	inventory := inventoryInfo.InventoryList[0]
+	if inventory.Provider != "MOCK" || inventory.Region != "default" {
+		t.Errorf("Provider: %s, Region: %s", inventory.Provider, inventory.Region)
+	}
+	found := false
+	for _, vpcInfo := range inventory.VPCList {
+		if vpcInfo.IId.NameId == "inventory-vpc-01" {
+			found = true
+		}
+	}
+	if !found || inventory.ResourceCount["vpc"] != len(inventory.VPCList) {
+		t.Errorf("VPCList: %v, ResourceCount: %v", inventory.VPCList, inventory.ResourceCount)
+	}
+	if _, ok := inventory.ResourceCount["vm"]; ok || inventory.VMList != nil {
+		t.Error("the VM is not in the ResourceTypes")
+	}
+
+	// filtered out by the provider
+	reqInfo.ProviderNames = []string{"GCP"}
+	inventoryInfo, err = cmrt.ListInventory(context.Background(), reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(inventoryInfo.InventoryList) != 0 {
+		t.Errorf("InventoryList of GCP: %v", inventoryInfo.InventoryList)
+	}
+
+	if _, err := cmrt.ListInventory(context.Background(), cmrt.InventoryReqInfo{ResourceTypes: []string{"subnet"}}); err == nil {
+		t.Error("ListInventory() with a wrong resource type should return an error!")
+	}
+	if _, err := cmrt.ListInventory(context.Background(), cmrt.InventoryReqInfo{ConnectionNames: []string{"inventory-test-none-config"}}); err == nil {
+		t.Error("ListInventory() with a not existing connection should return an error!")
+	}
+}
@@ -0,0 +1,174 @@
+// Async Job Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func waitJob(t *testing.T, jobId string) *cmrt.JobInfo {
+	for i := 0; i < 50; i++ {
+		jobInfo, err := cmrt.GetJob(jobId)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if jobInfo.Status == cmrt.JobSucceeded || jobInfo.Status == cmrt.JobFailed {
+			return jobInfo
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Job %s is not finished.", jobId)
+	return nil
+}
+
+func TestJobSucceeded(t *testing.T) {
+	release := make(chan bool)
+	jobInfo, err := cmrt.SubmitJob("job-test-config-01", "vm", "vm-01", func() (interface{}, error) {
+		<-release
+		return map[string]string{"Name": "vm-01"}, nil
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if jobInfo.Status != cmrt.JobPending {
+		t.Errorf("Job Status is not %s. It is %s.", cmrt.JobPending, jobInfo.Status)
+	}
+
+	close(release)
+	jobInfo = waitJob(t, jobInfo.JobId)
+	if jobInfo.Status != cmrt.JobSucceeded {
+		t.Errorf("Job Status is not %s. It is %s.", cmrt.JobSucceeded, jobInfo.Status)
+	}
+
+	var result map[string]string
+	err = json.Unmarshal(jobInfo.Result, &result)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if result["Name"] != "vm-01" {
+		t.Errorf("Job Result is not vm-01. It is %s.", string(jobInfo.Result))
+	}
+}
+
+func TestJobFailed(t *testing.T) {
+	jobInfo, err := cmrt.SubmitJob("job-test-config-01", "vm", "vm-02", func() (interface{}, error) {
+		return nil, fmt.Errorf("vm-02 already exists!")
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	jobInfo = waitJob(t, jobInfo.JobId)
+	if jobInfo.Status != cmrt.JobFailed {
+		t.Errorf("Job Status is not %s. It is %s.", cmrt.JobFailed, jobInfo.Status)
+	}
+	if jobInfo.Error != "vm-02 already exists!" {
+		t.Errorf("Job Error is not the error of the operation. It is %s.", jobInfo.Error)
+	}
+}
+
+func TestJobList(t *testing.T) {
+	jobInfo, err := cmrt.SubmitJob("job-test-config-02", "cluster", "cluster-01", func() (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	waitJob(t, jobInfo.JobId)
+
+	jobInfoList, err := cmrt.ListJob("job-test-config-02")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	found := false
+	for _, info := range jobInfoList {
+		if info.ConnectionName != "job-test-config-02" {
+			t.Errorf("%s Job of %s is listed.", info.JobId, info.ConnectionName)
+		}
+		if info.JobId == jobInfo.JobId {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("%s Job is not listed.", jobInfo.JobId)
+	}
+
+	_, err = cmrt.GetJob("not-exist-job")
+	if err == nil {
+		t.Errorf("GetJob() with an invalid ID must be failed.")
+	}
+}
+
+func TestJobExpire(t *testing.T) {
+	defer cmrt.SetJobTTL(24 * time.Hour)
+
+	jobInfo, err := cmrt.SubmitJob("job-test-config-03", "vm", "vm-03", func() (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	waitJob(t, jobInfo.JobId)
+
+	// the finished Job is expired after the TTL
+	cmrt.SetJobTTL(50 * time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	if _, err := cmrt.GetJob(jobInfo.JobId); err == nil {
+		t.Errorf("%s Job is not expired.", jobInfo.JobId)
+	}
+	jobInfoList, err := cmrt.ListJob("job-test-config-03")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(jobInfoList) != 0 {
+		t.Errorf("The expired Jobs are listed: %d", len(jobInfoList))
+	}
+}
+
+func TestRecoverJobs(t *testing.T) {
+	release := make(chan bool)
+	defer close(release)
+
+	started := make(chan bool)
+	jobInfo, err := cmrt.SubmitJob("job-test-config-04", "vm", "vm-04", func() (interface{}, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	<-started
+
+	// the Running Job of a stopped server is Failed at the server start
+	cmrt.RecoverJobs()
+	jobInfo, err = cmrt.GetJob(jobInfo.JobId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if jobInfo.Status != cmrt.JobFailed || jobInfo.Error == "" {
+		t.Errorf("The Running Job is not Failed. It is %s(%s).", jobInfo.Status, jobInfo.Error)
+	}
+
+	// the late finish of the operation does not change the Failed Job
+	release <- true
+	time.Sleep(100 * time.Millisecond)
+	jobInfo, err = cmrt.GetJob(jobInfo.JobId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if jobInfo.Status != cmrt.JobFailed {
+		t.Errorf("The Failed Job is changed to %s.", jobInfo.Status)
+	}
+}
@@ -0,0 +1,70 @@
+// PriceInfo Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+func TestPriceInfo(t *testing.T) {
+	setupInfraConnection(t)
+
+	priceList, err := cmrt.ListDiskPrice(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(priceList) != 3 || priceList[0].Unit != cres.PRICE_UNIT_GB_MONTH {
+		t.Errorf("Disk Price List: %v", priceList)
+	}
+
+	priceInfo, err := cmrt.GetVMSpecPrice(infraTestConnection, "mock-vmspec-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if priceInfo.OnDemandPrice != "2.4800" || priceInfo.Unit != cres.PRICE_UNIT_HOUR {
+		t.Errorf("VMSpec Price: %v", priceInfo)
+	}
+}
+
+func TestEstimateVMCost(t *testing.T) {
+	setupInfraConnection(t)
+
+	// 2.48/Hour + 100GB * 0.1/GB-Month
+	reqInfo := cres.VMReqInfo{VMSpecName: "mock-vmspec-01", RootDiskType: "SSD", RootDiskSize: "100"}
+	estimateInfo, err := cmrt.EstimateVMCost(infraTestConnection, reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(estimateInfo.ItemList) != 2 || estimateInfo.Currency != "USD" || estimateInfo.MonthlyCost != "1820.4000" || len(estimateInfo.NoteList) != 0 {
+		t.Errorf("Estimate: %v, ItemList: %v", estimateInfo, estimateInfo.ItemList)
+	}
+	if item := estimateInfo.ItemList[1]; item.ResourceType != cmrt.COST_ROOTDISK || item.MonthlyCost != "10.0000" {
+		t.Errorf("Root Disk Item: %v", item)
+	}
+
+	// the default root disk is noted
+	reqInfo = cres.VMReqInfo{VMSpecName: "mock-vmspec-04", RootDiskSize: "default"}
+	estimateInfo, err = cmrt.EstimateVMCost(infraTestConnection, reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(estimateInfo.ItemList) != 1 || estimateInfo.HourlyCost != "0.0208" || len(estimateInfo.NoteList) != 1 {
+		t.Errorf("Estimate: %v", estimateInfo)
+	}
+
+	if _, err := cmrt.EstimateVMCost(infraTestConnection, cres.VMReqInfo{}); err == nil {
+		t.Error("EstimateVMCost() without the VMSpecName should return an error!")
+	}
+	if _, err := cmrt.EstimateVMCost(infraTestConnection, cres.VMReqInfo{VMSpecName: "mock-vmspec-01", RootDiskType: "NVME", RootDiskSize: "10"}); err == nil {
+		t.Error("EstimateVMCost() with a not priced disk type should return an error!")
+	}
+}
@@ -0,0 +1,215 @@
+// Drift and Orphan Reconcile Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"context"
+	"testing"
+)
+
+func findDriftItem(itemList []*cmrt.DriftItem, rsType string, name string) *cmrt.DriftItem {
+	for _, item := range itemList {
+		if item.ResourceType == rsType && item.IId.NameId == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestReconcileDrift(t *testing.T) {
+	setupInfraConnection(t)
+
+	// (1) resources created by Spider
+	_, err := cmrt.CreateVPC(infraTestConnection, "vpc", cres.VPCReqInfo{
+		IId:            cres.IID{"rc-vpc-01", ""},
+		IPv4_CIDR:      "10.0.0.0/16",
+		SubnetInfoList: []cres.SubnetInfo{{IId: cres.IID{"rc-subnet-01", ""}, IPv4_CIDR: "10.0.1.0/24"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteResource(context.Background(), infraTestConnection, "vpc", "rc-vpc-01", "true")
+
+	sgInfo, err := cmrt.CreateSecurity(infraTestConnection, "sg", cres.SecurityReqInfo{
+		IId:           cres.IID{"rc-sg-01", "rc-sg-01"},
+		VpcIID:        cres.IID{"rc-vpc-01", ""},
+		SecurityRules: &[]cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "22", ToPort: "22", CIDR: "0.0.0.0/0"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteResource(context.Background(), infraTestConnection, "sg", "rc-sg-01", "true")
+
+	keyInfo, err := cmrt.CreateKey(infraTestConnection, "keypair", cres.KeyPairReqInfo{IId: cres.IID{"rc-key-01", ""}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// (2) changes outside Spider
+	cldConn, err := ccm.GetCloudConnection(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cldConn.Close()
+	keyHandler, _ := cldConn.CreateKeyPairHandler()
+	sgHandler, _ := cldConn.CreateSecurityHandler()
+
+	// the KeyPair vanished
+	if _, err := keyHandler.DeleteKey(cres.IID{keyInfo.IId.SystemId, keyInfo.IId.SystemId}); err != nil {
+		t.Fatal(err.Error())
+	}
+	// a KeyPair not in Spider
+	if _, err := keyHandler.CreateKey(cres.KeyPairReqInfo{IId: cres.IID{"rc-orphan-key-01", ""}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteResource(context.Background(), infraTestConnection, "keypair", "rc-orphan-key-01", "true")
+	// a rule added outside Spider
+	sgIID := cres.IID{sgInfo.IId.SystemId, sgInfo.IId.SystemId}
+	if _, err := sgHandler.AddRules(sgIID, &[]cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "80", ToPort: "80", CIDR: "0.0.0.0/0"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// (3) report only without the policy
+	report, err := cmrt.RunReconcile(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	stale := findDriftItem(report.StaleList, "keypair", "rc-key-01")
+	if stale == nil || stale.Action != "" {
+		t.Errorf("StaleList: %v", report.StaleList)
+	}
+	if findDriftItem(report.OrphanList, "keypair", "rc-orphan-key-01") == nil {
+		t.Errorf("OrphanList: %v", report.OrphanList)
+	}
+	changed := findDriftItem(report.ChangedList, "sg", "rc-sg-01")
+	if changed == nil || changed.Detail != "added: [inbound/TCP/80-80/0.0.0.0/0], removed: []" {
+		t.Errorf("ChangedList: %v", report.ChangedList)
+	}
+
+	lastReport, err := cmrt.GetDriftReport(infraTestConnection)
+	if err != nil || !lastReport.StartTime.Equal(report.StartTime) {
+		t.Errorf("GetDriftReport(): %v, %v", lastReport, err)
+	}
+
+	// (4) the policy to unregister the stale IIDs and register the orphans
+	_, err = cmrt.SetReconcilePolicy(cmrt.ReconcilePolicy{
+		ConnectionName:      infraTestConnection,
+		IntervalSec:         3600,
+		ResourceTypeList:    []string{"KeyPair"},
+		AutoUnregisterStale: true,
+		AutoRegisterOrphan:  true,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.RemoveReconcilePolicy(infraTestConnection)
+
+	// an orphan found first is handled by the next reconciliation
+	if _, err := keyHandler.CreateKey(cres.KeyPairReqInfo{IId: cres.IID{"rc-orphan-key-02", ""}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteResource(context.Background(), infraTestConnection, "keypair", "rc-orphan-key-02", "true")
+
+	report, err = cmrt.RunReconcile(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(report.ChangedList) != 0 {
+		t.Errorf("the SecurityGroup is not in the ResourceTypeList: %v", report.ChangedList)
+	}
+	if stale := findDriftItem(report.StaleList, "keypair", "rc-key-01"); stale == nil || stale.Action != cmrt.DRIFT_UNREGISTERED {
+		t.Errorf("StaleList: %v", report.StaleList)
+	}
+	if orphan := findDriftItem(report.OrphanList, "keypair", "rc-orphan-key-01"); orphan == nil || orphan.Action != cmrt.DRIFT_REGISTERED {
+		t.Errorf("OrphanList: %v", report.OrphanList)
+	}
+	if _, err := cmrt.GetKey(infraTestConnection, "keypair", "rc-orphan-key-01"); err != nil {
+		t.Errorf("the orphan KeyPair is not registered: %v", err)
+	}
+	if orphan := findDriftItem(report.OrphanList, "keypair", "rc-orphan-key-02"); orphan == nil || orphan.Action != cmrt.DRIFT_PENDING {
+		t.Errorf("the new orphan should be pending: %v", report.OrphanList)
+	}
+	report, err = cmrt.RunReconcile(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if orphan := findDriftItem(report.OrphanList, "keypair", "rc-orphan-key-02"); orphan == nil || orphan.Action != cmrt.DRIFT_REGISTERED {
+		t.Errorf("the orphan found again should be registered: %v", report.OrphanList)
+	}
+
+	// (5) the rules changed by Spider are not drift
+	if _, err := cmrt.RemoveRules(infraTestConnection, "rc-sg-01", []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "80", ToPort: "80", CIDR: "0.0.0.0/0"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.RemoveReconcilePolicy(infraTestConnection); err != nil {
+		t.Fatal(err.Error())
+	}
+	// a rule added outside Spider after RemoveRules()
+	if _, err := sgHandler.AddRules(sgIID, &[]cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "8080", ToPort: "8080", CIDR: "0.0.0.0/0"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	report, err = cmrt.RunReconcile(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	changed = findDriftItem(report.ChangedList, "sg", "rc-sg-01")
+	if changed == nil || changed.Detail != "added: [inbound/TCP/8080-8080/0.0.0.0/0], removed: []" {
+		t.Errorf("ChangedList after RemoveRules(): %v", report.ChangedList)
+	}
+
+	if _, err := cmrt.AddRules(infraTestConnection, "rc-sg-01", []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "443", ToPort: "443", CIDR: "0.0.0.0/0"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	report, err = cmrt.RunReconcile(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// only the rule of AddRules() is added to the baseline
+	changed = findDriftItem(report.ChangedList, "sg", "rc-sg-01")
+	if changed == nil || changed.Detail != "added: [inbound/TCP/8080-8080/0.0.0.0/0], removed: []" {
+		t.Errorf("ChangedList after AddRules(): %v", report.ChangedList)
+	}
+}
+
+func TestReconcileConnectionDeleted(t *testing.T) {
+	setupInfraConnection(t)
+
+	if _, err := cmrt.SetReconcilePolicy(cmrt.ReconcilePolicy{ConnectionName: infraTestConnection, IntervalSec: 3600}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := ccim.DeleteConnectionConfig(infraTestConnection); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.GetReconcilePolicy(infraTestConnection); err == nil {
+		cmrt.RemoveReconcilePolicy(infraTestConnection)
+		t.Error("the reconcile policy of the deleted connection should be removed!")
+	}
+}
+
+func TestReconcilePolicyCheck(t *testing.T) {
+	setupInfraConnection(t)
+
+	if _, err := cmrt.SetReconcilePolicy(cmrt.ReconcilePolicy{ConnectionName: infraTestConnection, IntervalSec: 10}); err == nil {
+		t.Error("SetReconcilePolicy() with a short interval should return an error!")
+	}
+	if _, err := cmrt.SetReconcilePolicy(cmrt.ReconcilePolicy{ConnectionName: infraTestConnection, IntervalSec: 60, ResourceTypeList: []string{"subnet"}}); err == nil {
+		t.Error("SetReconcilePolicy() with a wrong resource type should return an error!")
+	}
+	if _, err := cmrt.SetReconcilePolicy(cmrt.ReconcilePolicy{ConnectionName: "reconcile-test-none-config", IntervalSec: 60}); err == nil {
+		t.Error("SetReconcilePolicy() with a not existing connection should return an error!")
+	}
+	if _, err := cmrt.RemoveReconcilePolicy("reconcile-test-none-config"); err == nil {
+		t.Error("RemoveReconcilePolicy() without the policy should return an error!")
+	}
+}
@@ -0,0 +1,40 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+func TestTagFilter(t *testing.T) {
+	tagList := []cres.KeyValue{{Key: "owner", Value: "team-a"}, {Key: "env", Value: ""}}
+
+	testCases := []struct {
+		tagStrList []string
+		matched    bool
+	}{
+		{[]string{"owner:team-a"}, true},
+		{[]string{"owner:team-b"}, false},
+		{[]string{"owner"}, true},
+		{[]string{"owner:"}, false},
+		{[]string{"env:"}, true},
+		{[]string{"env"}, true},
+		{[]string{"owner", "env:"}, true},
+		{[]string{"region"}, false},
+	}
+	for _, testCase := range testCases {
+		tagFilter := cmrt.ParseTagFilter(testCase.tagStrList)
+		if matched := cmrt.MatchTagFilter(tagList, tagFilter); matched != testCase.matched {
+			t.Errorf("MatchTagFilter() of %v: %v", testCase.tagStrList, matched)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// VMSpec Search Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"context"
+	"testing"
+)
+
+func TestSearchVMSpec(t *testing.T) {
+	setupInfraConnection(t)
+
+	// mock-vmspec-01: 4vCPU, 32768MB, 2 x NVIDIA V100
+	// mock-vmspec-02: 4vCPU, 32768MB, 1 x NVIDIA V100
+	// mock-vmspec-03: 8vCPU, 62464MB
+	// mock-vmspec-04: 8vCPU, 1024MB
+	reqInfo := cmrt.VMSpecSearchReqInfo{
+		ConnectionNames: []string{infraTestConnection},
+		MinVCpu:         4,
+		MinMem:          32768,
+	}
+	searchInfo, err := cmrt.SearchVMSpec(context.Background(), reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	nameList := []string{}
+	for _, result := range searchInfo.SpecList {
+		nameList = append(nameList, result.VMSpecInfo.Name)
+	}
+	if len(nameList) != 3 || nameList[0] != "mock-vmspec-01" || nameList[1] != "mock-vmspec-02" || nameList[2] != "mock-vmspec-03" {
+		t.Fatalf("SpecList: %v", nameList)
+	}
+	if searchInfo.SpecList[0].Distance != 0 || searchInfo.SpecList[2].Distance <= 0 {
+		t.Errorf("Distance: %v, %v", searchInfo.SpecList[0].Distance, searchInfo.SpecList[2].Distance)
+	}
+	if searchInfo.SpecList[0].Provider != "MOCK" || searchInfo.SpecList[0].Connection != infraTestConnection {
+		t.Errorf("Provider: %s, Connection: %s", searchInfo.SpecList[0].Provider, searchInfo.SpecList[0].Connection)
+	}
+	if key := searchInfo.SpecList[2].Equivalent.Key; key != "8vCPU-61GiB" {
+		t.Errorf("Equivalent of mock-vmspec-03: %s", key)
+	}
+	if len(searchInfo.EquivalentList) != 3 || searchInfo.EquivalentList[0].Equivalent.Key != "4vCPU-32GiB-2xNVIDIA V100" {
+		t.Errorf("EquivalentList: %v", searchInfo.EquivalentList)
+	}
+
+	// the closest GPU count first
+	reqInfo = cmrt.VMSpecSearchReqInfo{
+		ConnectionNames: []string{infraTestConnection},
+		MinGpu:          1,
+		GpuModel:        "v100",
+		Limit:           1,
+	}
+	searchInfo, err = cmrt.SearchVMSpec(context.Background(), reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(searchInfo.SpecList) != 1 || searchInfo.SpecList[0].VMSpecInfo.Name != "mock-vmspec-02" {
+		t.Errorf("SpecList: %v", searchInfo.SpecList)
+	}
+
+	// filtered out by the provider
+	reqInfo.ProviderNames = []string{"AWS", "GCP", "AZURE"}
+	searchInfo, err = cmrt.SearchVMSpec(context.Background(), reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(searchInfo.SpecList) != 0 {
+		t.Errorf("SpecList of AWS, GCP and AZURE: %v", searchInfo.SpecList)
+	}
+
+	if _, err := cmrt.SearchVMSpec(context.Background(), cmrt.VMSpecSearchReqInfo{MinVCpu: 8, MaxVCpu: 4}); err == nil {
+		t.Error("SearchVMSpec() with MinVCpu > MaxVCpu should return an error!")
+	}
+}
@@ -0,0 +1,723 @@
+// VM SSH Command Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	sshrun "github.com/cloud-barista/cb-spider/cloud-control-manager/vm-ssh"
+	cbstore "github.com/cloud-barista/cb-store"
+
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// the files of the test SSH server, path => file
+type testFiles struct {
+	sync.Mutex
+	files map[string]testFile
+}
+
+type testFile struct {
+	mode string
+	data []byte
+}
+
+// start a SSH server which runs the test commands, returns the address and the files.
+//
+//	"echo-test": stdout "out", stderr "err", exit code 3
+//	"cat": copy the stdin to the stdout
+//	"sleep": wait for the connection to be closed
+//	"scp -qt <path>", "scp -f <path>": receive and send a file
+func startCommandTestServer(t *testing.T) (string, *testFiles) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	serverConfig := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	files := &testFiles{files: map[string]testFile{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveCommandTestConn(conn, serverConfig, files)
+		}
+	}()
+	return listener.Addr().String(), files
+}
+
+func serveCommandTestConn(conn net.Conn, serverConfig *ssh.ServerConfig, files *testFiles) {
+	defer conn.Close()
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, serverConfig)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	closed := make(chan struct{})
+	go func() {
+		sshConn.Wait()
+		close(closed)
+	}()
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				var payload struct{ Command string }
+				ssh.Unmarshal(req.Payload, &payload)
+				req.Reply(true, nil)
+
+				exitCode := uint32(0)
+				switch {
+				case strings.HasPrefix(payload.Command, "scp -qt "):
+					remotePath, _ := strconv.Unquote(strings.TrimPrefix(payload.Command, "scp -qt "))
+					exitCode = files.receive(channel, remotePath)
+				case strings.HasPrefix(payload.Command, "scp -f "):
+					remotePath := strings.Trim(strings.TrimPrefix(payload.Command, "scp -f "), "'")
+					exitCode = files.send(channel, remotePath)
+				case payload.Command == "echo-test":
+					io.WriteString(channel, "out")
+					io.WriteString(channel.Stderr(), "err")
+					exitCode = 3
+				case payload.Command == "cat":
+					io.Copy(channel, channel)
+				case payload.Command == "sleep":
+					<-closed
+					return
+				default:
+					io.WriteString(channel.Stderr(), "command not found")
+					exitCode = 127
+				}
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{exitCode}))
+				return
+			}
+		}()
+	}
+}
+
+// the sink of the scp protocol
+func (f *testFiles) receive(channel ssh.Channel, remotePath string) uint32 {
+	r := bufio.NewReader(channel)
+	channel.Write([]byte{0})
+	header, err := r.ReadString('\n') // ex) "C0644 5 name"
+	if err != nil {
+		return 1
+	}
+	fields := strings.Fields(strings.TrimPrefix(header, "C"))
+	size, _ := strconv.Atoi(fields[1])
+	channel.Write([]byte{0})
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 1
+	}
+	r.ReadByte()
+
+	f.Lock()
+	f.files[remotePath] = testFile{mode: fields[0], data: data}
+	f.Unlock()
+	channel.Write([]byte{0})
+	return 0
+}
+
+// the source of the scp protocol
+func (f *testFiles) send(channel ssh.Channel, remotePath string) uint32 {
+	r := bufio.NewReader(channel)
+	r.ReadByte()
+
+	f.Lock()
+	file, ok := f.files[remotePath]
+	f.Unlock()
+	if !ok {
+		fmt.Fprintf(channel, "\x01scp: %s: No such file or directory\n", remotePath)
+		return 1
+	}
+	fmt.Fprintf(channel, "C%s %d %s\n", file.mode, len(file.data), path.Base(remotePath))
+	if status, _ := r.ReadByte(); status != 0 {
+		return 1
+	}
+	channel.Write(file.data)
+	channel.Write([]byte{0})
+	r.ReadByte()
+	return 0
+}
+
+func genTestPrivateKey(t *testing.T) []byte {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestSSHRunContext(t *testing.T) {
+	serverPort, _ := startCommandTestServer(t)
+	sshInfo := sshrun.SSHInfo{
+		UserName:   "cb-user",
+		PrivateKey: genTestPrivateKey(t),
+		ServerPort: serverPort,
+		Timeout:    5,
+	}
+
+	// the stdout, the stderr and the exit code are separated
+	result, err := sshrun.SSHRunContext(context.Background(), sshInfo, "echo-test", nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.Stdout != "out" || result.Stderr != "err" || result.ExitCode != 3 || result.TimedOut {
+		t.Errorf("echo-test: %#v", result)
+	}
+
+	// the stdin
+	result, err = sshrun.SSHRunContext(context.Background(), sshInfo, "cat", strings.NewReader("hello\nspider\n"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.Stdout != "hello\nspider\n" || result.ExitCode != 0 {
+		t.Errorf("cat: %#v", result)
+	}
+
+	// the timeout stops the command
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err = sshrun.SSHRunContext(ctx, sshInfo, "sleep", nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !result.TimedOut || result.ExitCode != -1 {
+		t.Errorf("sleep: %#v", result)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("the timed out command took %v", elapsed)
+	}
+}
+
+func TestRunVMCommand(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	vmName := "infra-vm-01"
+	reqInfo := cmrt.VMCommandReq{Command: "hostname"}
+
+	if _, err := cmrt.RunVMCommand(context.Background(), infraTestConnection, vmName, cmrt.VMCommandReq{}); err == nil {
+		t.Error("RunVMCommand() without the Command should return an error!")
+	}
+	if _, err := cmrt.RunVMCommand(context.Background(), infraTestConnection, vmName,
+		cmrt.VMCommandReq{Command: "hostname", Script: []string{"ls"}}); err == nil {
+		t.Error("RunVMCommand() with the Command and the Script should return an error!")
+	}
+	if _, err := cmrt.RunVMCommand(context.Background(), infraTestConnection, vmName,
+		cmrt.VMCommandReq{Command: "hostname", TimeoutSec: -1}); err == nil {
+		t.Error("RunVMCommand() with a negative TimeoutSec should return an error!")
+	}
+	if _, err := cmrt.RunVMCommand(context.Background(), infraTestConnection, "not-exist-vm", reqInfo); err == nil {
+		t.Error("RunVMCommand() of not existing VM should return an error!")
+	}
+
+	// the mock driver keeps a dummy private key
+	_, err = cmrt.RunVMCommand(context.Background(), infraTestConnection, vmName, reqInfo)
+	if err == nil || !strings.Contains(err.Error(), "private key") {
+		t.Errorf("RunVMCommand() with the dummy private key: %v", err)
+	}
+}
+
+func TestRunVMCommandFanOut(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	commandReq := cmrt.VMCommandReq{Command: "hostname"}
+
+	// one of the VMNames, the VMNamePrefix and the NLBName is required
+	if _, err := cmrt.RunVMCommandFanOut(context.Background(), infraTestConnection,
+		cmrt.VMCommandFanOutReq{CommandReq: commandReq}); err == nil {
+		t.Error("RunVMCommandFanOut() without the target VMs should return an error!")
+	}
+	if _, err := cmrt.RunVMCommandFanOut(context.Background(), infraTestConnection,
+		cmrt.VMCommandFanOutReq{VMNames: []string{"infra-vm-01"}, VMNamePrefix: "infra-", CommandReq: commandReq}); err == nil {
+		t.Error("RunVMCommandFanOut() with the VMNames and the VMNamePrefix should return an error!")
+	}
+	if _, err := cmrt.RunVMCommandFanOut(context.Background(), infraTestConnection,
+		cmrt.VMCommandFanOutReq{VMNamePrefix: "no-vm-", CommandReq: commandReq}); err == nil {
+		t.Error("RunVMCommandFanOut() without the matched VMs should return an error!")
+	}
+	if _, err := cmrt.RunVMCommandFanOut(context.Background(), infraTestConnection,
+		cmrt.VMCommandFanOutReq{VMNamePrefix: "infra-", Parallelism: 1000, CommandReq: commandReq}); err == nil {
+		t.Error("RunVMCommandFanOut() with too large Parallelism should return an error!")
+	}
+
+	// the failure of a VM does not stop the others, the duplicated names are run once.
+	result, err := cmrt.RunVMCommandFanOut(context.Background(), infraTestConnection, cmrt.VMCommandFanOutReq{
+		VMNames:     []string{"infra-vm-01", "not-exist-vm", " infra-vm-01 "},
+		Parallelism: 1,
+		CommandReq:  commandReq,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.TotalCount != 2 || result.ErrorCount != 2 || len(result.ResultList) != 2 {
+		t.Fatalf("the fan-out result: %#v", result)
+	}
+	for idx, vmName := range []string{"infra-vm-01", "not-exist-vm"} {
+		item := result.ResultList[idx]
+		if item.VMName != vmName || item.Status != cmrt.VMCommandError || item.ErrorMSG == "" || item.ElapsedTime == "" {
+			t.Errorf("the result of %s: %#v", vmName, item)
+		}
+	}
+
+	// the VMs are selected by the name prefix
+	result, err = cmrt.RunVMCommandFanOut(context.Background(), infraTestConnection,
+		cmrt.VMCommandFanOutReq{VMNamePrefix: "infra-vm-", CommandReq: commandReq})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.TotalCount != 1 || result.ResultList[0].VMName != "infra-vm-01" {
+		t.Errorf("the fan-out result by the prefix: %#v", result)
+	}
+}
+
+func TestSSHFileTransfer(t *testing.T) {
+	serverPort, files := startCommandTestServer(t)
+	sshInfo := sshrun.SSHInfo{
+		UserName:   "cb-user",
+		PrivateKey: genTestPrivateKey(t),
+		ServerPort: serverPort,
+		Timeout:    5,
+	}
+	content := "hello spider\n"
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+
+	// upload with the mode
+	info, err := sshrun.SSHUploadContext(context.Background(), sshInfo, strings.NewReader(content), int64(len(content)), "/tmp/hello.txt", "0600")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.SHA256 != checksum || info.Size != int64(len(content)) || info.Mode != "0600" {
+		t.Errorf("the upload info: %#v", info)
+	}
+	files.Lock()
+	file := files.files["/tmp/hello.txt"]
+	files.Unlock()
+	if string(file.data) != content || file.mode != "0600" {
+		t.Errorf("the uploaded file: %#v", file)
+	}
+
+	// download
+	buf := &bytes.Buffer{}
+	info, err = sshrun.SSHDownloadContext(context.Background(), sshInfo, "/tmp/hello.txt", 1024, buf)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if buf.String() != content || info.SHA256 != checksum || info.Mode != "0600" {
+		t.Errorf("the download info: %#v, %q", info, buf.String())
+	}
+
+	// the size limit
+	buf.Reset()
+	if _, err := sshrun.SSHDownloadContext(context.Background(), sshInfo, "/tmp/hello.txt", 3, buf); err == nil || buf.Len() != 0 {
+		t.Errorf("the download over the size limit: %v, %q", err, buf.String())
+	}
+
+	// the remote error
+	_, err = sshrun.SSHDownloadContext(context.Background(), sshInfo, "/tmp/not-exist.txt", 1024, buf)
+	if err == nil || !strings.Contains(err.Error(), "No such file") {
+		t.Errorf("the download of not existing file: %v", err)
+	}
+}
+
+func TestVMFileTransfer(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	vmName := "infra-vm-01"
+	content := "hello spider\n"
+	upload := func(reqInfo cmrt.VMFileUploadReq, size int64) error {
+		_, err := cmrt.UploadVMFile(context.Background(), infraTestConnection, vmName, reqInfo, strings.NewReader(content), size)
+		return err
+	}
+
+	for _, reqInfo := range []cmrt.VMFileUploadReq{
+		{RemotePath: ""},
+		{RemotePath: "/tmp/"},
+		{RemotePath: "/tmp/$HOME.txt"},
+		{RemotePath: "/tmp/hello.txt", Mode: "999"},
+		{RemotePath: "/tmp/hello.txt", Mode: "rwx"},
+		{RemotePath: "/tmp/hello.txt", TimeoutSec: -1},
+		{RemotePath: "/tmp/hello.txt", SHA256: "0123"},
+	} {
+		if err := upload(reqInfo, int64(len(content))); err == nil {
+			t.Errorf("UploadVMFile(%#v) should return an error!", reqInfo)
+		}
+	}
+	if err := upload(cmrt.VMFileUploadReq{RemotePath: "/tmp/hello.txt"}, cmrt.MaxVMFileSize+1); err == nil {
+		t.Error("UploadVMFile() over the size limit should return an error!")
+	}
+
+	// the file is checked, then the mock driver's dummy private key is rejected
+	sum := sha256.Sum256([]byte(content))
+	err = upload(cmrt.VMFileUploadReq{RemotePath: "/tmp/hello.txt", Mode: "0600", SHA256: hex.EncodeToString(sum[:])}, int64(len(content)))
+	if err == nil || !strings.Contains(err.Error(), "private key") {
+		t.Errorf("UploadVMFile() with the dummy private key: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := cmrt.DownloadVMFile(context.Background(), infraTestConnection, vmName,
+		cmrt.VMFileDownloadReq{RemotePath: "/tmp/hello.txt", MaxSize: cmrt.MaxVMFileSize + 1}, buf); err == nil {
+		t.Error("DownloadVMFile() with too large MaxSize should return an error!")
+	}
+	_, err = cmrt.DownloadVMFile(context.Background(), infraTestConnection, vmName, cmrt.VMFileDownloadReq{RemotePath: "/tmp/hello.txt"}, buf)
+	if err == nil || !strings.Contains(err.Error(), "private key") {
+		t.Errorf("DownloadVMFile() with the dummy private key: %v", err)
+	}
+}
+
+// start a SSH server which forwards the direct-tcpip channels only, returns the address.
+func startJumpTestServer(t *testing.T) string {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	serverConfig := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				sshConn, chans, reqs, err := ssh.NewServerConn(conn, serverConfig)
+				if err != nil {
+					return
+				}
+				defer sshConn.Close()
+				go ssh.DiscardRequests(reqs)
+
+				for newChannel := range chans {
+					if newChannel.ChannelType() != "direct-tcpip" {
+						newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+						continue
+					}
+					var payload struct {
+						Host       string
+						Port       uint32
+						OriginHost string
+						OriginPort uint32
+					}
+					ssh.Unmarshal(newChannel.ExtraData(), &payload)
+					target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+					if err != nil {
+						newChannel.Reject(ssh.ConnectionFailed, err.Error())
+						continue
+					}
+					channel, requests, err := newChannel.Accept()
+					if err != nil {
+						target.Close()
+						continue
+					}
+					go ssh.DiscardRequests(requests)
+					go func() {
+						io.Copy(target, channel)
+						target.Close()
+					}()
+					go func() {
+						io.Copy(channel, target)
+						channel.Close()
+					}()
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestSSHJumpHosts(t *testing.T) {
+	serverPort, files := startCommandTestServer(t)
+	jumpHosts := []sshrun.JumpHostInfo{
+		{UserName: "jump-user", PrivateKey: genTestPrivateKey(t), ServerPort: startJumpTestServer(t)},
+		{UserName: "jump-user", PrivateKey: genTestPrivateKey(t), ServerPort: startJumpTestServer(t)},
+	}
+	sshInfo := sshrun.SSHInfo{
+		UserName:   "cb-user",
+		PrivateKey: genTestPrivateKey(t),
+		ServerPort: serverPort,
+		Timeout:    5,
+		JumpHosts:  jumpHosts,
+	}
+
+	// the command through the two jump hosts
+	result, err := sshrun.SSHRunContext(context.Background(), sshInfo, "echo-test", nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.Stdout != "out" || result.ExitCode != 3 {
+		t.Errorf("echo-test through the jump hosts: %#v", result)
+	}
+	if _, err := sshrun.SSHRun(sshInfo, "echo-test"); err == nil {
+		t.Error("SSHRun() through the jump hosts should return the exit error!")
+	}
+
+	// the file through the jump hosts
+	content := "jump\n"
+	if _, err := sshrun.SSHUploadContext(context.Background(), sshInfo, strings.NewReader(content), int64(len(content)), "/tmp/jump.txt", "0644"); err != nil {
+		t.Fatal(err.Error())
+	}
+	files.Lock()
+	file := files.files["/tmp/jump.txt"]
+	files.Unlock()
+	if string(file.data) != content {
+		t.Errorf("the uploaded file through the jump hosts: %#v", file)
+	}
+
+	// the error of a jump host is distinguished from the target's
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	closedAddr := listener.Addr().String()
+	listener.Close()
+	sshInfo.JumpHosts = []sshrun.JumpHostInfo{jumpHosts[0], {UserName: "jump-user", PrivateKey: genTestPrivateKey(t), ServerPort: closedAddr}}
+	_, err = sshrun.SSHRunContext(context.Background(), sshInfo, "echo-test", nil)
+	if !sshrun.IsJumpHostError(err) || !strings.Contains(err.Error(), "jump host #2") {
+		t.Errorf("the error of the second jump host: %v", err)
+	}
+
+	sshInfo.JumpHosts = jumpHosts
+	sshInfo.ServerPort = closedAddr
+	_, err = sshrun.SSHRunContext(context.Background(), sshInfo, "echo-test", nil)
+	if err == nil || sshrun.IsJumpHostError(err) {
+		t.Errorf("the error of the target through the jump hosts: %v", err)
+	}
+
+	// the handshake of the target which does not answer is stopped by the timeout
+	silentListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer silentListener.Close()
+	go func() {
+		for {
+			conn, err := silentListener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	sshInfo.ServerPort = silentListener.Addr().String()
+	sshInfo.Timeout = 1
+	start := time.Now()
+	_, err = sshrun.SSHRunContext(context.Background(), sshInfo, "echo-test", nil)
+	if err == nil || time.Since(start) > 3*time.Second {
+		t.Errorf("the silent target through the jump hosts: %v, %v", err, time.Since(start))
+	}
+}
+
+func TestConnectionJumpHosts(t *testing.T) {
+	setupInfraConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	privateKey := strings.Split(strings.TrimSpace(string(genTestPrivateKey(t))), "\n")
+	addressJumpHost := cmrt.SSHJumpHostInfo{UserName: "ubuntu", ServerPort: "127.0.0.1:22", PrivateKey: privateKey}
+
+	for _, jumpHostList := range [][]cmrt.SSHJumpHostInfo{
+		{},
+		{{VMName: "not-exist-vm"}},
+		{{VMName: "infra-vm-01", ServerPort: "127.0.0.1:22"}},
+		{{UserName: "ubuntu", ServerPort: "127.0.0.1:22"}},
+		{{UserName: "ubuntu", ServerPort: "127.0.0.1:22", PrivateKey: []string{"invalid key"}}},
+		{{UserName: "ubuntu", ServerPort: "127.0.0.1:22", PrivateKey: privateKey, HostKey: "invalid host key"}},
+		{addressJumpHost, addressJumpHost, addressJumpHost, addressJumpHost, addressJumpHost, addressJumpHost},
+	} {
+		if _, err := cmrt.SetConnectionJumpHosts(infraTestConnection, jumpHostList); err == nil {
+			t.Errorf("SetConnectionJumpHosts(%#v) should return an error!", jumpHostList)
+		}
+	}
+	if _, err := cmrt.SetConnectionJumpHosts("not-exist-config", []cmrt.SSHJumpHostInfo{addressJumpHost}); err == nil {
+		t.Error("SetConnectionJumpHosts() of not existing connection should return an error!")
+	}
+
+	// the private keys are hidden
+	if _, err := cmrt.SetConnectionJumpHosts(infraTestConnection, []cmrt.SSHJumpHostInfo{{VMName: "infra-vm-01"}, addressJumpHost}); err != nil {
+		t.Fatal(err.Error())
+	}
+	jumpHostList, err := cmrt.GetConnectionJumpHosts(infraTestConnection)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(jumpHostList) != 2 || jumpHostList[0].VMName != "infra-vm-01" || jumpHostList[1].PrivateKey[0] != "Hidden for security." {
+		t.Errorf("the jump hosts of the connection: %#v", jumpHostList)
+	}
+
+	// the connection's jump hosts are used, the bastion VM's dummy private key is rejected.
+	_, err = cmrt.RunVMCommand(context.Background(), infraTestConnection, "infra-vm-01", cmrt.VMCommandReq{Command: "hostname"})
+	if err == nil || !strings.Contains(err.Error(), "jump host infra-vm-01") {
+		t.Errorf("RunVMCommand() through the jump hosts of the connection: %v", err)
+	}
+
+	if result, err := cmrt.DeleteConnectionJumpHosts(infraTestConnection); err != nil || !result {
+		t.Fatalf("DeleteConnectionJumpHosts(): %v, %v", result, err)
+	}
+	if _, err := cmrt.DeleteConnectionJumpHosts(infraTestConnection); err == nil {
+		t.Error("DeleteConnectionJumpHosts() without the jump hosts should return an error!")
+	}
+	jumpHostList, err = cmrt.GetConnectionJumpHosts(infraTestConnection)
+	if err != nil || len(jumpHostList) != 0 {
+		t.Errorf("the jump hosts after the deletion: %#v, %v", jumpHostList, err)
+	}
+}
+
+func TestConnectionJumpHostSecret(t *testing.T) {
+	setupInfraConnection(t)
+
+	// the private key is stored encrypted
+	privateKey := strings.Split(strings.TrimSpace(string(genTestPrivateKey(t))), "\n")
+	jumpServerPort, jumpHostKey := startHostKeyTestServer(t)
+	jumpHost := cmrt.SSHJumpHostInfo{UserName: "jump-user", ServerPort: jumpServerPort, PrivateKey: privateKey}
+	if _, err := cmrt.SetConnectionJumpHosts(infraTestConnection, []cmrt.SSHJumpHostInfo{jumpHost}); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteConnectionJumpHosts(infraTestConnection)
+	keyValue, err := cbstore.GetStore().Get("/resource-info-spaces/jumphosts/" + infraTestConnection)
+	if err != nil || keyValue == nil {
+		t.Fatalf("the stored jump hosts: %v, %v", keyValue, err)
+	}
+	if strings.Contains(keyValue.Value, privateKey[1]) || !strings.Contains(keyValue.Value, "spkey:") {
+		t.Errorf("the private key is stored in plain text: %s", keyValue.Value)
+	}
+
+	// the host key of the jump host is pinned on the first connection
+	hostKeyCallback, hostKeyAlgorithms, err := cmrt.JumpHostKeyCallback(infraTestConnection, jumpServerPort)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	client, err := ssh.Dial("tcp", jumpServerPort, &ssh.ClientConfig{
+		User:              "jump-user",
+		HostKeyCallback:   hostKeyCallback,
+		HostKeyAlgorithms: hostKeyAlgorithms,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	client.Close()
+	hostKeyKey := "/resource-info-spaces/hostkeys/" + infraTestConnection + "/jumphost/" + jumpServerPort
+	keyValue, err = cbstore.GetStore().Get(hostKeyKey)
+	if err != nil || keyValue == nil || !strings.Contains(keyValue.Value, ssh.FingerprintSHA256(jumpHostKey)) {
+		t.Fatalf("the pinned host key of the jump host: %v, %v", keyValue, err)
+	}
+
+	// the pinned host keys are removed with the jump hosts
+	if _, err := cmrt.DeleteConnectionJumpHosts(infraTestConnection); err != nil {
+		t.Fatal(err.Error())
+	}
+	if keyValue, _ := cbstore.GetStore().Get(hostKeyKey); keyValue != nil {
+		t.Errorf("the pinned host key after the deletion of the jump hosts: %s", keyValue.Value)
+	}
+}
@@ -0,0 +1,68 @@
+// Waiter Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaiterCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	waiter := cmrt.NewWaiterWithContext(ctx, 5, 240) // (sleep, timeout)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	if waiter.Wait() {
+		t.Errorf("Wait() must stop waiting after the cancel.")
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("Wait() did not stop at the cancel. Elapsed: %v", time.Since(start))
+	}
+	if !waiter.Canceled() || waiter.Err() != context.Canceled {
+		t.Errorf("Waiter is not canceled. Err: %v", waiter.Err())
+	}
+
+	// no more waiting after the cancel
+	if waiter.Wait() {
+		t.Errorf("Wait() must not wait after the cancel.")
+	}
+}
+
+func TestWaiterDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	waiter := cmrt.NewWaiterWithContext(ctx, 5, 240) // (sleep, timeout)
+
+	if waiter.Wait() {
+		t.Errorf("Wait() must stop waiting after the deadline.")
+	}
+	if waiter.Err() != context.DeadlineExceeded {
+		t.Errorf("Waiter Err is not %v. It is %v.", context.DeadlineExceeded, waiter.Err())
+	}
+}
+
+func TestWaiterTimeout(t *testing.T) {
+	waiter := cmrt.NewWaiter(1, 2) // (sleep, timeout)
+
+	count := 0
+	for waiter.Wait() {
+		count++
+	}
+	if count < 1 || waiter.Canceled() {
+		t.Errorf("Waiter must wait until the timeout. count: %d, canceled: %v", count, waiter.Canceled())
+	}
+}
@@ -0,0 +1,119 @@
+// Resource Status Watch Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"context"
+	"testing"
+	"time"
+)
+
+// wait for the event of the resource matched by the checkFunc, the other events are skipped.
+func waitWatchEvent(t *testing.T, watchChan <-chan *cmrt.WatchEvent, nameID string, checkFunc func(*cmrt.WatchEvent) bool) *cmrt.WatchEvent {
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case event, ok := <-watchChan:
+			if !ok {
+				t.Fatalf("the watch of %s is closed", nameID)
+			}
+			if event.ResourceName == nameID && checkFunc(event) {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("no watch event of %s", nameID)
+			return nil
+		}
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	setupInfraConnection(t)
+
+	pollInterval := cmrt.WatchPollInterval
+	cmrt.WatchPollInterval = 200 * time.Millisecond
+	t.Cleanup(func() { cmrt.WatchPollInterval = pollInterval })
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	if _, err := cmrt.WatchStatus(context.Background(), infraTestConnection, "disk", ""); err == nil {
+		t.Error("WatchStatus() of disk should return an error!")
+	}
+
+	// two watchers of a VM share a poller
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	watchChan1, err := cmrt.WatchStatus(ctx1, infraTestConnection, "vm", "infra-vm-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	watchChan2, err := cmrt.WatchStatus(ctx2, infraTestConnection, "VM", "infra-vm-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ctxAll, cancelAll := context.WithCancel(context.Background())
+	defer cancelAll()
+	watchChanAll, err := cmrt.WatchStatus(ctxAll, infraTestConnection, "vm", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if count := cmrt.CountStatusPoller(); count != 2 {
+		t.Errorf("the number of the pollers: %d", count)
+	}
+
+	// the current status first
+	for _, watchChan := range []<-chan *cmrt.WatchEvent{watchChan1, watchChan2, watchChanAll} {
+		waitWatchEvent(t, watchChan, "infra-vm-01", func(event *cmrt.WatchEvent) bool {
+			return event.OldStatus == "" && event.Status == string(cres.Running)
+		})
+	}
+
+	if _, err := cmrt.ControlVM(infraTestConnection, "vm", "infra-vm-01", "suspend"); err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, watchChan := range []<-chan *cmrt.WatchEvent{watchChan1, watchChan2} {
+		waitWatchEvent(t, watchChan, "infra-vm-01", func(event *cmrt.WatchEvent) bool {
+			return event.OldStatus != "" && event.Status == string(cres.Suspended)
+		})
+	}
+
+	// the last watcher stops the poller
+	cancel1()
+	cancel2()
+	for i := 0; i < 20 && cmrt.CountStatusPoller() != 1; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if count := cmrt.CountStatusPoller(); count != 1 {
+		t.Errorf("the number of the pollers after the cancel: %d", count)
+	}
+	// the channel is closed after the cancel
+	for range watchChan1 {
+	}
+
+	// the removed VM
+	if _, err := cmrt.DestroyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	waitWatchEvent(t, watchChanAll, "infra-vm-01", func(event *cmrt.WatchEvent) bool {
+		return event.Status == cmrt.WATCH_NOT_EXIST
+	})
+	cancelAll()
+}
@@ -0,0 +1,311 @@
+// Resource Event Webhook Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	cbstore "github.com/cloud-barista/cb-store"
+
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const webhookTestSecret = "webhook-test-secret"
+
+// the received events, the retried deliveries can be out of order
+type webhookReceiver struct {
+	mutex     sync.Mutex
+	eventList []*cmrt.ResourceEvent
+}
+
+// the local receiver fails the first request to check the retry.
+func startWebhookReceiver(t *testing.T) (*httptest.Server, *webhookReceiver) {
+	receiver := &webhookReceiver{}
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		signature := cmrt.SignWebhookPayload(webhookTestSecret, r.Header.Get("X-Spider-Timestamp"), body)
+		if r.Header.Get("X-Spider-Signature") != signature {
+			t.Errorf("wrong signature: %s", r.Header.Get("X-Spider-Signature"))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		event := &cmrt.ResourceEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			t.Error(err.Error())
+		}
+		if r.Header.Get("X-Spider-Event") != event.EventType {
+			t.Errorf("X-Spider-Event: %s, EventType: %s", r.Header.Get("X-Spider-Event"), event.EventType)
+		}
+		receiver.mutex.Lock()
+		receiver.eventList = append(receiver.eventList, event)
+		receiver.mutex.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return server, receiver
+}
+
+// wait for the event of the type and the resource matched by the checkFunc.
+func waitEvent(t *testing.T, receiver *webhookReceiver, eventType string, rsType string, nameID string,
+	checkFunc func(*cmrt.ResourceEvent) bool) *cmrt.ResourceEvent {
+
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		receiver.mutex.Lock()
+		for _, event := range receiver.eventList {
+			if event.EventType == eventType && event.ResourceType == rsType && event.ResourceName == nameID &&
+				(checkFunc == nil || checkFunc(event)) {
+				receiver.mutex.Unlock()
+				return event
+			}
+		}
+		receiver.mutex.Unlock()
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("no %s event of %s(%s)", eventType, rsType, nameID)
+	return nil
+}
+
+func TestWebhookEvent(t *testing.T) {
+	setupInfraConnection(t)
+	server, receiver := startWebhookReceiver(t)
+
+	webhookInfo, err := cmrt.CreateWebhook(cmrt.WebhookInfo{
+		Name:             "webhook-test-01",
+		URL:              server.URL,
+		Secret:           webhookTestSecret,
+		ConnectionNames:  []string{infraTestConnection},
+		RetryIntervalSec: 1,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { cmrt.DeleteWebhook("webhook-test-01") })
+	if webhookInfo.MaxRetries != 3 || webhookInfo.Secret != webhookTestSecret {
+		t.Errorf("Webhook: %v", webhookInfo)
+	}
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraTestDoc))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ApplyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	waitEvent(t, receiver, cmrt.EVENT_CREATED, "vpc", "infra-vpc-01", nil)
+	waitEvent(t, receiver, cmrt.EVENT_CREATED, "vm", "infra-vm-01", nil)
+
+	// the creation of an existing VPC fails
+	_, err = cmrt.CreateVPC(infraTestConnection, "vpc", cres.VPCReqInfo{IId: cres.IID{"infra-vpc-01", ""}, IPv4_CIDR: "10.0.0.0/16"})
+	if err == nil {
+		t.Fatal("CreateVPC() with an existing VPC should return an error!")
+	}
+	if event := waitEvent(t, receiver, cmrt.EVENT_FAILED, "vpc", "infra-vpc-01", nil); event.Operation != "create" || event.Message == "" {
+		t.Errorf("failed event: %v", event)
+	}
+
+	// the status is changed by the control
+	if _, err := cmrt.GetVMStatus(infraTestConnection, "vm", "infra-vm-01"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.ControlVM(infraTestConnection, "vm", "infra-vm-01", "suspend"); err != nil {
+		t.Fatal(err.Error())
+	}
+	waitEvent(t, receiver, cmrt.EVENT_STATUS_CHANGED, "vm", "infra-vm-01", func(event *cmrt.ResourceEvent) bool {
+		return event.OldStatus == string(cres.Running)
+	})
+
+	if _, err := cmrt.DestroyInfra(context.Background(), doc); err != nil {
+		t.Fatal(err.Error())
+	}
+	waitEvent(t, receiver, cmrt.EVENT_DELETED, "vm", "infra-vm-01", nil)
+
+	// the first delivery is retried
+	retried := false
+	for i := 0; i < 30 && !retried; i++ {
+		deliveryList, err := cmrt.ListWebhookDelivery("webhook-test-01")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, delivery := range deliveryList {
+			if delivery.Status == cmrt.DELIVERY_SUCCEEDED && delivery.Attempts == 2 {
+				retried = true
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !retried {
+		t.Error("no retried delivery in the delivery history")
+	}
+
+	if info, err := cmrt.GetWebhook("webhook-test-01"); err != nil || info.Secret != "" {
+		t.Errorf("GetWebhook(): %v, %v", info, err)
+	}
+}
+
+func TestWebhookResume(t *testing.T) {
+	setupInfraConnection(t)
+	server, receiver := startWebhookReceiver(t)
+
+	// no events of the connections
+	_, err := cmrt.CreateWebhook(cmrt.WebhookInfo{
+		Name:             "webhook-test-03",
+		URL:              server.URL,
+		Secret:           webhookTestSecret,
+		ConnectionNames:  []string{"webhook-test-none-config"},
+		RetryIntervalSec: 1,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { cmrt.DeleteWebhook("webhook-test-03") })
+
+	// the Secret is stored encrypted
+	keyValue, err := cbstore.GetStore().Get("/event-spaces/webhooks/webhook-test-03")
+	if err != nil || keyValue == nil {
+		t.Fatalf("the stored Webhook: %v, %v", keyValue, err)
+	}
+	if strings.Contains(keyValue.Value, webhookTestSecret) {
+		t.Error("the Secret should be stored encrypted!")
+	}
+
+	// a delivery pending when the server was stopped
+	event := &cmrt.ResourceEvent{
+		EventId:        "1665968400000000000-000001",
+		EventType:      cmrt.EVENT_CREATED,
+		ConnectionName: "webhook-test-none-config",
+		ResourceType:   "vm",
+		ResourceName:   "webhook-test-vm-01",
+		Time:           time.Now(),
+	}
+	delivery := cmrt.WebhookDelivery{
+		DeliveryId:      event.EventId,
+		WebhookName:     "webhook-test-03",
+		Event:           event,
+		Status:          cmrt.DELIVERY_PENDING,
+		Attempts:        1,
+		LastAttemptTime: time.Now(),
+	}
+	jsonValue, _ := json.Marshal(delivery)
+	if err := cbstore.GetStore().Put("/event-spaces/deliveries/webhook-test-03/"+event.EventId, string(jsonValue)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	cmrt.ResumeWebhookDeliveries()
+	waitEvent(t, receiver, cmrt.EVENT_CREATED, "vm", "webhook-test-vm-01", nil)
+
+	resumed := false
+	for i := 0; i < 30 && !resumed; i++ {
+		deliveryList, err := cmrt.ListWebhookDelivery("webhook-test-03")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		resumed = len(deliveryList) == 1 && deliveryList[0].Status == cmrt.DELIVERY_SUCCEEDED && deliveryList[0].Attempts == 3
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !resumed {
+		t.Error("the pending delivery is not resumed")
+	}
+}
+
+// the delivery of the deleted webhook is not retried.
+func TestWebhookDeleted(t *testing.T) {
+	setupInfraConnection(t)
+
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := cmrt.CreateWebhook(cmrt.WebhookInfo{
+		Name:             "webhook-test-04",
+		URL:              server.URL,
+		ConnectionNames:  []string{"webhook-test-none-config"},
+		RetryIntervalSec: 1,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { cmrt.DeleteWebhook("webhook-test-04") })
+
+	event := &cmrt.ResourceEvent{
+		EventId:        "1665968400000000000-000002",
+		EventType:      cmrt.EVENT_CREATED,
+		ConnectionName: "webhook-test-none-config",
+		ResourceType:   "vm",
+		ResourceName:   "webhook-test-vm-02",
+		Time:           time.Now(),
+	}
+	delivery := cmrt.WebhookDelivery{
+		DeliveryId:  event.EventId,
+		WebhookName: "webhook-test-04",
+		Event:       event,
+		Status:      cmrt.DELIVERY_PENDING,
+	}
+	jsonValue, _ := json.Marshal(delivery)
+	if err := cbstore.GetStore().Put("/event-spaces/deliveries/webhook-test-04/"+event.EventId, string(jsonValue)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	cmrt.ResumeWebhookDeliveries()
+	for i := 0; i < 50 && atomic.LoadInt32(&requestCount) == 0; i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if _, err := cmrt.DeleteWebhook("webhook-test-04"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the retry after 1 second is not attempted
+	time.Sleep(2 * time.Second)
+	if count := atomic.LoadInt32(&requestCount); count != 1 {
+		t.Errorf("the delivery of the deleted Webhook is attempted %d times, want 1", count)
+	}
+}
+
+func TestWebhookCheck(t *testing.T) {
+	setupInfraConnection(t)
+
+	if _, err := cmrt.CreateWebhook(cmrt.WebhookInfo{Name: "webhook-test-02", URL: "ftp://localhost/events"}); err == nil {
+		t.Error("CreateWebhook() with a non-http URL should return an error!")
+	}
+	if _, err := cmrt.CreateWebhook(cmrt.WebhookInfo{Name: "webhook-test-02", URL: "http://localhost/events", EventTypes: []string{"changed"}}); err == nil {
+		t.Error("CreateWebhook() with a wrong event type should return an error!")
+	}
+
+	webhookInfo, err := cmrt.CreateWebhook(cmrt.WebhookInfo{Name: "webhook-test-02", URL: "http://localhost/events"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cmrt.DeleteWebhook("webhook-test-02")
+	if len(webhookInfo.Secret) != 64 {
+		t.Errorf("the generated Secret: %s", webhookInfo.Secret)
+	}
+	if _, err := cmrt.CreateWebhook(cmrt.WebhookInfo{Name: "webhook-test-02", URL: "http://localhost/events"}); err == nil {
+		t.Error("CreateWebhook() with an existing name should return an error!")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	cr "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
 	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
 	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/config"
+	grpc_idempotency "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/interceptors/idempotency"
 	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
 	grpc_service "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/service"
 	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
@@ -48,7 +49,7 @@ func RunServer() {
 		return
 	}
 
-	cbserver, closer, err := gc.NewCBServer(spidersrv)
+	cbserver, closer, err := gc.NewCBServer(spidersrv, grpc_idempotency.UnaryServerInterceptor())
 	if err != nil {
 		logger.Error("failed to create grpc server: ", err)
 		return
@@ -50,8 +50,8 @@ type CBServer struct {
 
 // ===== [ Public Functions ] =====
 
-// NewCBServer - 초기화된 grpc 서버의 인스턴스 생성
-func NewCBServer(gConf *config.GrpcServerConfig) (*CBServer, io.Closer, error) {
+// NewCBServer - 초기화된 grpc 서버의 인스턴스 생성, extraUnaryIntercepters 는 recovery 인터셉터 전에 추가
+func NewCBServer(gConf *config.GrpcServerConfig, extraUnaryIntercepters ...grpc.UnaryServerInterceptor) (*CBServer, io.Closer, error) {
 
 	var (
 		tracer      opentracing.Tracer             = nil
@@ -120,6 +120,9 @@ func NewCBServer(gConf *config.GrpcServerConfig) (*CBServer, io.Closer, error) {
 
 	}
 
+	// 추가 인터셉터 설정 (ex: idempotency key)
+	unaryIntercepters = append(unaryIntercepters, extraUnaryIntercepters...)
+
 	// recovery 인터셉터 기본 설정
 	unaryIntercepters = append(unaryIntercepters, grpc_recovery.UnaryServerInterceptor())
 	streamIntercepters = append(streamIntercepters, grpc_recovery.StreamServerInterceptor())
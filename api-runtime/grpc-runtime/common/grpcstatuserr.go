@@ -9,6 +9,9 @@
 package common
 
 import (
+	"context"
+	"errors"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -30,6 +33,15 @@ func ConvGrpcStatusErr(err error, tag string, method string) error {
 	logger := logger.NewLogger()
 
 	if err != nil {
+		// canceled by the client or by the deadline
+		if errors.Is(err, context.Canceled) {
+			logger.Error(tag, " canceled while calling ", method, " method: ", err)
+			return status.Errorf(codes.Canceled, "%s canceled while calling %s method: %v ", tag, method, err)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			logger.Error(tag, " deadline exceeded while calling ", method, " method: ", err)
+			return status.Errorf(codes.DeadlineExceeded, "%s deadline exceeded while calling %s method: %v ", tag, method, err)
+		}
 		if errStatus, ok := status.FromError(err); ok {
 			logger.Error(tag, " error while calling ", method, " method: ", errStatus.Message())
 			return status.Errorf(errStatus.Code(), "%s error while calling %s method: %v ", tag, method, errStatus.Message())
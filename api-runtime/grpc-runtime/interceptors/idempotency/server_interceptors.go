@@ -0,0 +1,148 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+)
+
+// ===== [ Constants and Variables ] =====
+
+const (
+	// MetadataKey - 클라이언트가 idempotency key 를 전달하는 metadata 키
+	MetadataKey = "idempotency-key"
+	// ReplayedKey - 저장된 결과를 반환하는 경우 설정되는 header metadata 키
+	ReplayedKey = "idempotent-replayed"
+
+	scopePrefix = "GRPC:"
+)
+
+// idempotency key 를 지원하는 생성 메소드와 응답 메시지 생성 함수
+var createMethods = map[string]func() interface{}{
+	"/cbspider.CCM/CreateVPC":        func() interface{} { return &pb.VPCInfoResponse{} },
+	"/cbspider.CCM/RegisterVPC":      func() interface{} { return &pb.VPCInfoResponse{} },
+	"/cbspider.CCM/AddSubnet":        func() interface{} { return &pb.VPCInfoResponse{} },
+	"/cbspider.CCM/CreateSecurity":   func() interface{} { return &pb.SecurityInfoResponse{} },
+	"/cbspider.CCM/RegisterSecurity": func() interface{} { return &pb.SecurityInfoResponse{} },
+	"/cbspider.CCM/CreateKey":        func() interface{} { return &pb.KeyPairInfoResponse{} },
+	"/cbspider.CCM/RegisterKey":      func() interface{} { return &pb.KeyPairInfoResponse{} },
+	"/cbspider.CCM/StartVM":          func() interface{} { return &pb.VMInfoResponse{} },
+	"/cbspider.CCM/RegisterVM":       func() interface{} { return &pb.VMInfoResponse{} },
+	"/cbspider.CCM/CreateNLB":        func() interface{} { return &pb.NLBInfoResponse{} },
+	"/cbspider.CCM/RegisterNLB":      func() interface{} { return &pb.NLBInfoResponse{} },
+	"/cbspider.CCM/CreateDisk":       func() interface{} { return &pb.DiskInfoResponse{} },
+	"/cbspider.CCM/RegisterDisk":     func() interface{} { return &pb.DiskInfoResponse{} },
+	"/cbspider.CCM/SnapshotVM":       func() interface{} { return &pb.MyImageInfoResponse{} },
+	"/cbspider.CCM/RegisterMyImage":  func() interface{} { return &pb.MyImageInfoResponse{} },
+	"/cbspider.CCM/CreateCluster":    func() interface{} { return &pb.ClusterInfoResponse{} },
+	"/cbspider.CCM/RegisterCluster":  func() interface{} { return &pb.ClusterInfoResponse{} },
+	"/cbspider.CCM/AddNodeGroup":     func() interface{} { return &pb.ClusterInfoResponse{} },
+}
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// getKey - metadata 에서 idempotency key 추출
+func getKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// ===== [ Public Functions ] =====
+
+// UnaryServerInterceptor - 같은 idempotency key 를 가진 생성 요청을 한번만 처리하는 Unary 서버 인터셉터
+//
+// 중복 요청은 첫번째 요청의 결과를 반환하고, 첫번째 요청이 처리 중이면 Aborted 상태를 반환한다.
+// 다른 요청에 사용된 key 는 FailedPrecondition 상태를 반환한다.
+// 실패한 요청의 key 는 해제되어 같은 key 로 재시도할 수 있다.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		newResponse, ok := createMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		key := getKey(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		reqJSON, err := json.Marshal(req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		scope := scopePrefix + info.FullMethod
+		record, reserved, err := cmrt.BeginIdempotentRequest(scope, key, cmrt.IdempotencyRequestHash(reqJSON))
+		if err != nil {
+			switch {
+			case errors.Is(err, cmrt.ErrIdempotencyKeyInvalid):
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			case errors.Is(err, cmrt.ErrIdempotencyKeyMismatch):
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		if !reserved {
+			if record.Status == cmrt.IdempotencyInProgress {
+				return nil, status.Errorf(codes.Aborted, "The request with the idempotency-key(%s) is %s since %s", record.Key, record.Status, record.CreatedTime.Format(time.RFC3339))
+			}
+			resp := newResponse()
+			if err := json.Unmarshal([]byte(record.Result), resp); err != nil {
+				return nil, status.Errorf(codes.Internal, "%v", err)
+			}
+			grpc.SetHeader(ctx, metadata.Pairs(ReplayedKey, "true"))
+			return resp, nil
+		}
+
+		// 핸들러가 실패하거나 panic 이 발생하면 key 해제
+		completed := false
+		defer func() {
+			if !completed {
+				cmrt.ReleaseIdempotentRequest(record)
+			}
+		}()
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		respJSON, err := json.Marshal(resp)
+		if err != nil {
+			return resp, nil
+		}
+		if err := cmrt.CompleteIdempotentRequest(record, int(codes.OK), string(respJSON)); err != nil {
+			return resp, nil
+		}
+		completed = true
+		return resp, nil
+	}
+}
@@ -0,0 +1,94 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	"context"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// AnyCall - CSP 전용 기능 호출
+func (s *CCMService) AnyCall(ctx context.Context, req *pb.AnyCallRequest) (*pb.AnyCallInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.AnyCall()")
+
+	var keyValueList []cres.KeyValue
+	err := gc.CopySrcToDest(&req.Item.IKeyValueList, &keyValueList)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AnyCall()")
+	}
+
+	// Grpc RegInfo => Driver ReqInfo
+	reqInfo := cres.AnyCallInfo{
+		FID:           req.Item.Fid,
+		IKeyValueList: keyValueList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AnyCall(req.ConnectionName, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AnyCall()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.AnyCallInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AnyCall()")
+	}
+
+	resp := &pb.AnyCallInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// GetCSPResourceName - 자원의 CSP 이름 조회
+func (s *CCMService) GetCSPResourceName(ctx context.Context, req *pb.CSPResourceNameQryRequest) (*pb.StringResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetCSPResourceName()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetCSPResourceName(req.ConnectionName, req.ResourceType, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetCSPResourceName()")
+	}
+
+	resp := &pb.StringResponse{Result: result}
+	return resp, nil
+}
+
+// GetAllSPLockInfo - Spider 자원 Lock 정보 목록
+func (s *CCMService) GetAllSPLockInfo(ctx context.Context, req *pb.Empty) (*pb.SPLockInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetAllSPLockInfo()")
+
+	// Call common-runtime API
+	result := cmrt.GetAllSPLockInfo()
+
+	resp := &pb.SPLockInfoResponse{Items: result}
+	return resp, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
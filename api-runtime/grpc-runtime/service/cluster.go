@@ -0,0 +1,360 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	"context"
+	"strconv"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// CreateCluster - Cluster 생성
+func (s *CCMService) CreateCluster(ctx context.Context, req *pb.ClusterCreateRequest) (*pb.ClusterInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.CreateCluster()")
+
+	var tagList []cres.KeyValue
+	err := gc.CopySrcToDest(&req.Item.TagList, &tagList)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateCluster()")
+	}
+
+	// Grpc RegInfo => Driver ReqInfo
+	reqInfo := cres.ClusterInfo{
+		IId:     cres.IID{NameId: req.Item.Name, SystemId: req.Item.Name},
+		Version: req.Item.Version,
+		Network: cres.NetworkInfo{
+			VpcIID:            cres.IID{NameId: req.Item.VpcName, SystemId: ""},
+			SubnetIIDs:        convertNameToIIDs(req.Item.SubnetNames),
+			SecurityGroupIIDs: convertNameToIIDs(req.Item.SecurityGroupNames),
+		},
+		NodeGroupList: []cres.NodeGroupInfo{},
+		TagList:       tagList,
+	}
+	for _, nodeGroup := range req.Item.NodeGroupList {
+		reqInfo.NodeGroupList = append(reqInfo.NodeGroupList, convertNodeGroupInfo(nodeGroup))
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateCluster(ctx, req.ConnectionName, rsCluster, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateCluster()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.ClusterInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateCluster()")
+	}
+
+	resp := &pb.ClusterInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ListCluster - Cluster 목록
+func (s *CCMService) ListCluster(ctx context.Context, req *pb.ClusterAllQryRequest) (*pb.ListClusterInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListCluster()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListCluster(req.ConnectionName, "", rsCluster)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListCluster()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj []*pb.ClusterInfo
+	err = gc.CopySrcToDest(&result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListCluster()")
+	}
+
+	resp := &pb.ListClusterInfoResponse{Items: grpcObj}
+	return resp, nil
+}
+
+// GetCluster - Cluster 조회
+func (s *CCMService) GetCluster(ctx context.Context, req *pb.ClusterQryRequest) (*pb.ClusterInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetCluster()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetCluster(req.ConnectionName, rsCluster, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetCluster()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.ClusterInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetCluster()")
+	}
+
+	resp := &pb.ClusterInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// DeleteCluster - Cluster 삭제
+func (s *CCMService) DeleteCluster(ctx context.Context, req *pb.ClusterQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteCluster()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteResource(ctx, req.ConnectionName, rsCluster, req.Name, req.Force)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteCluster()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ListAllCluster - 관리 Cluster 목록
+func (s *CCMService) ListAllCluster(ctx context.Context, req *pb.ClusterAllQryRequest) (*pb.AllResourceInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListAllCluster()")
+
+	// Call common-runtime API
+	allResourceList, err := cmrt.ListAllResource(req.ConnectionName, rsCluster)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllCluster()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.AllResourceInfoResponse
+	err = gc.CopySrcToDest(&allResourceList, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllCluster()")
+	}
+
+	return &grpcObj, nil
+}
+
+// DeleteCSPCluster - CSP Cluster 삭제
+func (s *CCMService) DeleteCSPCluster(ctx context.Context, req *pb.CSPClusterQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteCSPCluster()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteCSPResource(req.ConnectionName, rsCluster, req.Id)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteCSPCluster()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// RegisterCluster - Cluster 등록
+func (s *CCMService) RegisterCluster(ctx context.Context, req *pb.ClusterRegisterRequest) (*pb.ClusterInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.RegisterCluster()")
+
+	userIId := cres.IID{NameId: req.Item.Name, SystemId: req.Item.CspId}
+
+	// Call common-runtime API
+	result, err := cmrt.RegisterCluster(req.ConnectionName, req.Item.VpcName, userIId)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterCluster()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.ClusterInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterCluster()")
+	}
+
+	resp := &pb.ClusterInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// UnregisterCluster - Cluster 제거
+func (s *CCMService) UnregisterCluster(ctx context.Context, req *pb.ClusterUnregiserQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.UnregisterCluster()")
+
+	// Call common-runtime API
+	result, err := cmrt.UnregisterResource(req.ConnectionName, rsCluster, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.UnregisterCluster()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// AddNodeGroup - Cluster NodeGroup 추가
+func (s *CCMService) AddNodeGroup(ctx context.Context, req *pb.NodeGroupAddRequest) (*pb.ClusterInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.AddNodeGroup()")
+
+	// Call common-runtime API
+	result, err := cmrt.AddNodeGroup(req.ConnectionName, rsNodeGroup, req.Name, convertNodeGroupInfo(req.Item))
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AddNodeGroup()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.ClusterInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AddNodeGroup()")
+	}
+
+	resp := &pb.ClusterInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// RemoveNodeGroup - Cluster NodeGroup 제거
+func (s *CCMService) RemoveNodeGroup(ctx context.Context, req *pb.NodeGroupQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.RemoveNodeGroup()")
+
+	// Call common-runtime API
+	result, err := cmrt.RemoveNodeGroup(req.ConnectionName, req.Name, req.NodeGroupName, req.Force)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RemoveNodeGroup()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// SetNodeGroupAutoScaling - NodeGroup AutoScaling 설정
+func (s *CCMService) SetNodeGroupAutoScaling(ctx context.Context, req *pb.NodeGroupAutoScalingRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.SetNodeGroupAutoScaling()")
+
+	on, _ := strconv.ParseBool(req.Item.OnAutoScaling)
+
+	// Call common-runtime API
+	result, err := cmrt.SetNodeGroupAutoScaling(req.ConnectionName, req.Name, req.NodeGroupName, on)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.SetNodeGroupAutoScaling()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ChangeNodeGroupScaling - NodeGroup Scaling 크기 변경
+func (s *CCMService) ChangeNodeGroupScaling(ctx context.Context, req *pb.NodeGroupScalingRequest) (*pb.NodeGroupInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ChangeNodeGroupScaling()")
+
+	desiredNodeSize, _ := strconv.Atoi(req.Item.DesiredNodeSize)
+	minNodeSize, _ := strconv.Atoi(req.Item.MinNodeSize)
+	maxNodeSize, _ := strconv.Atoi(req.Item.MaxNodeSize)
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeNodeGroupScaling(req.ConnectionName, req.Name, req.NodeGroupName,
+		desiredNodeSize, minNodeSize, maxNodeSize)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeNodeGroupScaling()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NodeGroupInfo
+	err = gc.CopySrcToDest(&result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeNodeGroupScaling()")
+	}
+
+	resp := &pb.NodeGroupInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// UpgradeCluster - Cluster 버전 업그레이드
+func (s *CCMService) UpgradeCluster(ctx context.Context, req *pb.ClusterUpgradeRequest) (*pb.ClusterInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.UpgradeCluster()")
+
+	// Call common-runtime API
+	result, err := cmrt.UpgradeCluster(req.ConnectionName, req.Name, req.Item.Version)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.UpgradeCluster()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.ClusterInfo
+	err = gc.CopySrcToDest(&result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.UpgradeCluster()")
+	}
+
+	resp := &pb.ClusterInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// convertNameToIIDs - 이름 목록을 IID 목록으로 변환
+func convertNameToIIDs(nameList []string) []cres.IID {
+	iidList := []cres.IID{}
+	for _, name := range nameList {
+		iidList = append(iidList, cres.IID{NameId: name, SystemId: ""})
+	}
+	return iidList
+}
+
+// convertNodeGroupInfo - Grpc NodeGroup 요청정보를 Driver NodeGroupInfo 로 변환
+func convertNodeGroupInfo(nodeGroup *pb.NodeGroupCreateInfo) cres.NodeGroupInfo {
+	if nodeGroup == nil {
+		return cres.NodeGroupInfo{}
+	}
+
+	onAutoScaling, _ := strconv.ParseBool(nodeGroup.OnAutoScaling)
+	desiredNodeSize, _ := strconv.Atoi(nodeGroup.DesiredNodeSize)
+	minNodeSize, _ := strconv.Atoi(nodeGroup.MinNodeSize)
+	maxNodeSize, _ := strconv.Atoi(nodeGroup.MaxNodeSize)
+
+	return cres.NodeGroupInfo{
+		IId:          cres.IID{NameId: nodeGroup.Name, SystemId: ""},
+		ImageIID:     cres.IID{NameId: nodeGroup.ImageName, SystemId: ""},
+		VMSpecName:   nodeGroup.VmSpecName,
+		RootDiskType: nodeGroup.RootDiskType,
+		RootDiskSize: nodeGroup.RootDiskSize,
+		KeyPairIID:   cres.IID{NameId: nodeGroup.KeyPairName, SystemId: ""},
+
+		OnAutoScaling:   onAutoScaling,
+		DesiredNodeSize: desiredNodeSize,
+		MinNodeSize:     minNodeSize,
+		MaxNodeSize:     maxNodeSize,
+	}
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,263 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	"context"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// CreateDisk - Disk 생성
+func (s *CCMService) CreateDisk(ctx context.Context, req *pb.DiskCreateRequest) (*pb.DiskInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.CreateDisk()")
+
+	var tagList []cres.KeyValue
+	err := gc.CopySrcToDest(&req.Item.TagList, &tagList)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateDisk()")
+	}
+
+	// Grpc RegInfo => Driver ReqInfo
+	reqInfo := cres.DiskInfo{
+		IId:      cres.IID{NameId: req.Item.Name, SystemId: ""},
+		DiskType: req.Item.DiskType,
+		DiskSize: req.Item.DiskSize,
+		TagList:  tagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateDisk(req.ConnectionName, rsDisk, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateDisk()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.DiskInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateDisk()")
+	}
+
+	resp := &pb.DiskInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ListDisk - Disk 목록
+func (s *CCMService) ListDisk(ctx context.Context, req *pb.DiskAllQryRequest) (*pb.ListDiskInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListDisk()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListDisk(req.ConnectionName, rsDisk)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListDisk()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj []*pb.DiskInfo
+	err = gc.CopySrcToDest(&result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListDisk()")
+	}
+
+	resp := &pb.ListDiskInfoResponse{Items: grpcObj}
+	return resp, nil
+}
+
+// GetDisk - Disk 조회
+func (s *CCMService) GetDisk(ctx context.Context, req *pb.DiskQryRequest) (*pb.DiskInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetDisk()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetDisk(req.ConnectionName, rsDisk, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetDisk()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.DiskInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetDisk()")
+	}
+
+	resp := &pb.DiskInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// DeleteDisk - Disk 삭제
+func (s *CCMService) DeleteDisk(ctx context.Context, req *pb.DiskQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteDisk()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteResource(ctx, req.ConnectionName, rsDisk, req.Name, req.Force)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteDisk()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ListAllDisk - 관리 Disk 목록
+func (s *CCMService) ListAllDisk(ctx context.Context, req *pb.DiskAllQryRequest) (*pb.AllResourceInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListAllDisk()")
+
+	// Call common-runtime API
+	allResourceList, err := cmrt.ListAllResource(req.ConnectionName, rsDisk)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllDisk()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.AllResourceInfoResponse
+	err = gc.CopySrcToDest(&allResourceList, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllDisk()")
+	}
+
+	return &grpcObj, nil
+}
+
+// DeleteCSPDisk - CSP Disk 삭제
+func (s *CCMService) DeleteCSPDisk(ctx context.Context, req *pb.CSPDiskQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteCSPDisk()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteCSPResource(req.ConnectionName, rsDisk, req.Id)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteCSPDisk()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// RegisterDisk - Disk 등록
+func (s *CCMService) RegisterDisk(ctx context.Context, req *pb.DiskRegisterRequest) (*pb.DiskInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.RegisterDisk()")
+
+	userIId := cres.IID{NameId: req.Item.Name, SystemId: req.Item.CspId}
+
+	// Call common-runtime API
+	result, err := cmrt.RegisterDisk(req.ConnectionName, userIId)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterDisk()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.DiskInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterDisk()")
+	}
+
+	resp := &pb.DiskInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// UnregisterDisk - Disk 제거
+func (s *CCMService) UnregisterDisk(ctx context.Context, req *pb.DiskUnregiserQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.UnregisterDisk()")
+
+	// Call common-runtime API
+	result, err := cmrt.UnregisterResource(req.ConnectionName, rsDisk, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.UnregisterDisk()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ChangeDiskSize - Disk 크기 변경
+func (s *CCMService) ChangeDiskSize(ctx context.Context, req *pb.DiskSizeChangeRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ChangeDiskSize()")
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeDiskSize(req.ConnectionName, req.Name, req.Item.Size_)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeDiskSize()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// AttachDisk - Disk 연결
+func (s *CCMService) AttachDisk(ctx context.Context, req *pb.DiskAttachRequest) (*pb.DiskInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.AttachDisk()")
+
+	// Call common-runtime API
+	result, err := cmrt.AttachDisk(req.ConnectionName, req.Name, req.Item.VmName)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AttachDisk()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.DiskInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AttachDisk()")
+	}
+
+	resp := &pb.DiskInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// DetachDisk - Disk 분리
+func (s *CCMService) DetachDisk(ctx context.Context, req *pb.DiskAttachRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DetachDisk()")
+
+	// Call common-runtime API
+	result, err := cmrt.DetachDisk(req.ConnectionName, req.Name, req.Item.VmName)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DetachDisk()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,116 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	"context"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// PlanInfra - Infra 문서의 실행 계획 조회
+func (s *CCMService) PlanInfra(ctx context.Context, req *pb.InfraRequest) (*pb.InfraPlanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.PlanInfra()")
+
+	doc, err := parseInfraDoc(req)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.PlanInfra()")
+	}
+
+	mode := cmrt.INFRA_APPLY
+	if req.Destroy {
+		mode = cmrt.INFRA_DESTROY
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.PlanInfra(doc, mode)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.PlanInfra()")
+	}
+
+	return convertInfraPlan(result, "CCMService.PlanInfra()")
+}
+
+// ApplyInfra - Infra 문서의 자원 생성 (실패시 생성된 자원 삭제)
+func (s *CCMService) ApplyInfra(ctx context.Context, req *pb.InfraRequest) (*pb.InfraPlanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ApplyInfra()")
+
+	doc, err := parseInfraDoc(req)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ApplyInfra()")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ApplyInfra(ctx, doc)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ApplyInfra()")
+	}
+
+	return convertInfraPlan(result, "CCMService.ApplyInfra()")
+}
+
+// DestroyInfra - Infra 문서의 자원 삭제 (역순)
+func (s *CCMService) DestroyInfra(ctx context.Context, req *pb.InfraRequest) (*pb.InfraPlanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DestroyInfra()")
+
+	doc, err := parseInfraDoc(req)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DestroyInfra()")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DestroyInfra(ctx, doc)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DestroyInfra()")
+	}
+
+	return convertInfraPlan(result, "CCMService.DestroyInfra()")
+}
+
+// ===== [ Private Functions ] =====
+
+// parseInfraDoc - YAML/JSON 문서를 Infra 문서로 변환 (ConnectionName 지정시 문서의 ConnectionName 대체)
+func parseInfraDoc(req *pb.InfraRequest) (*cmrt.InfraDoc, error) {
+	doc, err := cmrt.ParseInfraDoc([]byte(req.Document))
+	if err != nil {
+		return nil, err
+	}
+	if req.ConnectionName != "" {
+		doc.ConnectionName = req.ConnectionName
+	}
+	return doc, nil
+}
+
+// convertInfraPlan - CCM 객체에서 GRPC 메시지로 복사
+func convertInfraPlan(plan *cmrt.InfraPlan, funcName string) (*pb.InfraPlanResponse, error) {
+	var resp pb.InfraPlanResponse
+	err := gc.CopySrcToDest(plan, &resp)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", funcName)
+	}
+	return &resp, nil
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,200 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	"context"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// SnapshotVM - VM 스냅샷으로 MyImage 생성
+func (s *CCMService) SnapshotVM(ctx context.Context, req *pb.MyImageCreateRequest) (*pb.MyImageInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.SnapshotVM()")
+
+	// Grpc RegInfo => Driver ReqInfo
+	reqInfo := cres.MyImageInfo{
+		IId:      cres.IID{NameId: req.Item.Name, SystemId: req.Item.Name},
+		SourceVM: cres.IID{NameId: req.Item.SourceVm, SystemId: req.Item.SourceVm},
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.SnapshotVM(req.ConnectionName, rsMyImage, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.SnapshotVM()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.MyImageInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.SnapshotVM()")
+	}
+
+	resp := &pb.MyImageInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ListMyImage - MyImage 목록
+func (s *CCMService) ListMyImage(ctx context.Context, req *pb.MyImageAllQryRequest) (*pb.ListMyImageInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListMyImage()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListMyImage(req.ConnectionName, rsMyImage)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListMyImage()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj []*pb.MyImageInfo
+	err = gc.CopySrcToDest(&result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListMyImage()")
+	}
+
+	resp := &pb.ListMyImageInfoResponse{Items: grpcObj}
+	return resp, nil
+}
+
+// GetMyImage - MyImage 조회
+func (s *CCMService) GetMyImage(ctx context.Context, req *pb.MyImageQryRequest) (*pb.MyImageInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetMyImage()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetMyImage(req.ConnectionName, rsMyImage, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetMyImage()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.MyImageInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetMyImage()")
+	}
+
+	resp := &pb.MyImageInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// DeleteMyImage - MyImage 삭제
+func (s *CCMService) DeleteMyImage(ctx context.Context, req *pb.MyImageQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteMyImage()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteResource(ctx, req.ConnectionName, rsMyImage, req.Name, req.Force)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteMyImage()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ListAllMyImage - 관리 MyImage 목록
+func (s *CCMService) ListAllMyImage(ctx context.Context, req *pb.MyImageAllQryRequest) (*pb.AllResourceInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListAllMyImage()")
+
+	// Call common-runtime API
+	allResourceList, err := cmrt.ListAllResource(req.ConnectionName, rsMyImage)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllMyImage()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.AllResourceInfoResponse
+	err = gc.CopySrcToDest(&allResourceList, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllMyImage()")
+	}
+
+	return &grpcObj, nil
+}
+
+// DeleteCSPMyImage - CSP MyImage 삭제
+func (s *CCMService) DeleteCSPMyImage(ctx context.Context, req *pb.CSPMyImageQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteCSPMyImage()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteCSPResource(req.ConnectionName, rsMyImage, req.Id)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteCSPMyImage()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// RegisterMyImage - MyImage 등록
+func (s *CCMService) RegisterMyImage(ctx context.Context, req *pb.MyImageRegisterRequest) (*pb.MyImageInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.RegisterMyImage()")
+
+	userIId := cres.IID{NameId: req.Item.Name, SystemId: req.Item.CspId}
+
+	// Call common-runtime API
+	result, err := cmrt.RegisterMyImage(req.ConnectionName, userIId)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterMyImage()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.MyImageInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterMyImage()")
+	}
+
+	resp := &pb.MyImageInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// UnregisterMyImage - MyImage 제거
+func (s *CCMService) UnregisterMyImage(ctx context.Context, req *pb.MyImageUnregiserQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.UnregisterMyImage()")
+
+	// Call common-runtime API
+	result, err := cmrt.UnregisterResource(req.ConnectionName, rsMyImage, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.UnregisterMyImage()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
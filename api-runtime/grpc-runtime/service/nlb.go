@@ -0,0 +1,422 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// CreateNLB - NLB 생성
+func (s *CCMService) CreateNLB(ctx context.Context, req *pb.NLBCreateRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.CreateNLB()")
+
+	var tagList []cres.KeyValue
+	err := gc.CopySrcToDest(&req.Item.TagList, &tagList)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateNLB()")
+	}
+
+	healthChecker, err := convertHealthCheckerInfo(req.Item.HealthChecker)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateNLB()")
+	}
+
+	// Grpc RegInfo => Driver ReqInfo
+	reqInfo := cres.NLBInfo{
+		IId:           cres.IID{NameId: req.Item.Name, SystemId: req.Item.Name},
+		VpcIID:        cres.IID{NameId: req.Item.VpcName, SystemId: ""},
+		Type:          req.Item.Type,
+		Scope:         req.Item.Scope,
+		Listener:      convertListenerInfo(req.Item.Listener),
+		VMGroup:       convertVMGroupInfo(req.Item.VmGroup),
+		HealthChecker: healthChecker,
+		TagList:       tagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateNLB(ctx, req.ConnectionName, rsNLB, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateNLB()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.CreateNLB()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ListNLB - NLB 목록
+func (s *CCMService) ListNLB(ctx context.Context, req *pb.NLBAllQryRequest) (*pb.ListNLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListNLB()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListNLB(req.ConnectionName, rsNLB)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListNLB()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj []*pb.NLBInfo
+	err = gc.CopySrcToDest(&result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListNLB()")
+	}
+
+	resp := &pb.ListNLBInfoResponse{Items: grpcObj}
+	return resp, nil
+}
+
+// GetNLB - NLB 조회
+func (s *CCMService) GetNLB(ctx context.Context, req *pb.NLBQryRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetNLB()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetNLB(req.ConnectionName, rsNLB, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetNLB()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetNLB()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// DeleteNLB - NLB 삭제
+func (s *CCMService) DeleteNLB(ctx context.Context, req *pb.NLBQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteNLB()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteResource(ctx, req.ConnectionName, rsNLB, req.Name, req.Force)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteNLB()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ListAllNLB - 관리 NLB 목록
+func (s *CCMService) ListAllNLB(ctx context.Context, req *pb.NLBAllQryRequest) (*pb.AllResourceInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ListAllNLB()")
+
+	// Call common-runtime API
+	allResourceList, err := cmrt.ListAllResource(req.ConnectionName, rsNLB)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllNLB()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.AllResourceInfoResponse
+	err = gc.CopySrcToDest(&allResourceList, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListAllNLB()")
+	}
+
+	return &grpcObj, nil
+}
+
+// DeleteCSPNLB - CSP NLB 삭제
+func (s *CCMService) DeleteCSPNLB(ctx context.Context, req *pb.CSPNLBQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.DeleteCSPNLB()")
+
+	// Call common-runtime API
+	result, _, err := cmrt.DeleteCSPResource(req.ConnectionName, rsNLB, req.Id)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.DeleteCSPNLB()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// RegisterNLB - NLB 등록
+func (s *CCMService) RegisterNLB(ctx context.Context, req *pb.NLBRegisterRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.RegisterNLB()")
+
+	userIId := cres.IID{NameId: req.Item.Name, SystemId: req.Item.CspId}
+
+	// Call common-runtime API
+	result, err := cmrt.RegisterNLB(req.ConnectionName, req.Item.VpcName, userIId)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterNLB()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RegisterNLB()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// UnregisterNLB - NLB 제거
+func (s *CCMService) UnregisterNLB(ctx context.Context, req *pb.NLBUnregiserQryRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.UnregisterNLB()")
+
+	// Call common-runtime API
+	result, err := cmrt.UnregisterResource(req.ConnectionName, rsNLB, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.UnregisterNLB()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// AddNLBVMs - NLB VM 추가
+func (s *CCMService) AddNLBVMs(ctx context.Context, req *pb.NLBVMsRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.AddNLBVMs()")
+
+	var vmNames []string
+	if req.Item != nil {
+		vmNames = req.Item.Vms
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AddNLBVMs(req.ConnectionName, req.Name, vmNames)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AddNLBVMs()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.AddNLBVMs()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// RemoveNLBVMs - NLB VM 제거
+func (s *CCMService) RemoveNLBVMs(ctx context.Context, req *pb.NLBVMsRequest) (*pb.BooleanResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.RemoveNLBVMs()")
+
+	var vmNames []string
+	if req.Item != nil {
+		vmNames = req.Item.Vms
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RemoveNLBVMs(req.ConnectionName, req.Name, vmNames)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.RemoveNLBVMs()")
+	}
+
+	resp := &pb.BooleanResponse{Result: result}
+	return resp, nil
+}
+
+// ChangeListener - NLB Listener 변경
+func (s *CCMService) ChangeListener(ctx context.Context, req *pb.NLBListenerChangeRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ChangeListener()")
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeListener(req.ConnectionName, req.Name, convertListenerInfo(req.Item))
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeListener()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeListener()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ChangeVMGroup - NLB VMGroup 변경
+func (s *CCMService) ChangeVMGroup(ctx context.Context, req *pb.NLBVMGroupChangeRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ChangeVMGroup()")
+
+	// Grpc RegInfo => Driver ReqInfo
+	reqInfo := cres.VMGroupInfo{}
+	if req.Item != nil {
+		reqInfo.Protocol = req.Item.Protocol
+		reqInfo.Port = req.Item.Port
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeVMGroup(req.ConnectionName, req.Name, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeVMGroup()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeVMGroup()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ChangeHealthChecker - NLB HealthChecker 변경
+func (s *CCMService) ChangeHealthChecker(ctx context.Context, req *pb.NLBHealthCheckerChangeRequest) (*pb.NLBInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.ChangeHealthChecker()")
+
+	reqInfo, err := convertHealthCheckerInfo(req.Item)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeHealthChecker()")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeHealthChecker(req.ConnectionName, req.Name, reqInfo)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeHealthChecker()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.NLBInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ChangeHealthChecker()")
+	}
+
+	resp := &pb.NLBInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// GetVMGroupHealthInfo - NLB VMGroup Health 조회
+func (s *CCMService) GetVMGroupHealthInfo(ctx context.Context, req *pb.NLBQryRequest) (*pb.HealthInfoResponse, error) {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.GetVMGroupHealthInfo()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetVMGroupHealthInfo(req.ConnectionName, req.Name)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetVMGroupHealthInfo()")
+	}
+
+	// CCM 객체에서 GRPC 메시지로 복사
+	var grpcObj pb.HealthInfo
+	err = gc.CopySrcToDest(result, &grpcObj)
+	if err != nil {
+		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.GetVMGroupHealthInfo()")
+	}
+
+	resp := &pb.HealthInfoResponse{Item: &grpcObj}
+	return resp, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// convertListenerInfo - Grpc Listener 요청정보를 Driver ListenerInfo 로 변환
+func convertListenerInfo(listener *pb.NLBProtocolPortInfo) cres.ListenerInfo {
+	if listener == nil {
+		return cres.ListenerInfo{}
+	}
+	return cres.ListenerInfo{Protocol: listener.Protocol, Port: listener.Port}
+}
+
+// convertVMGroupInfo - Grpc VMGroup 요청정보를 Driver VMGroupInfo 로 변환
+func convertVMGroupInfo(vmGroup *pb.NLBVMGroupCreateInfo) cres.VMGroupInfo {
+	if vmGroup == nil {
+		return cres.VMGroupInfo{}
+	}
+
+	vmIIDList := []cres.IID{}
+	for _, vmName := range vmGroup.Vms {
+		vmIIDList = append(vmIIDList, cres.IID{NameId: vmName, SystemId: ""})
+	}
+	return cres.VMGroupInfo{Protocol: vmGroup.Protocol, Port: vmGroup.Port, VMs: &vmIIDList}
+}
+
+// convertHealthCheckerInfo - Grpc HealthChecker 요청정보를 Driver HealthCheckerInfo 로 변환 ("default", "", "-1" => -1)
+func convertHealthCheckerInfo(healthChecker *pb.NLBHealthCheckerCreateInfo) (cres.HealthCheckerInfo, error) {
+	if healthChecker == nil {
+		return cres.HealthCheckerInfo{Interval: -1, Timeout: -1, Threshold: -1}, nil
+	}
+
+	valueList := []int{}
+	for _, strValue := range []string{healthChecker.Interval, healthChecker.Timeout, healthChecker.Threshold} {
+		switch strings.ToLower(strValue) {
+		case "default", "", "-1":
+			valueList = append(valueList, -1)
+		default:
+			value, err := strconv.Atoi(strValue)
+			if err != nil {
+				return cres.HealthCheckerInfo{}, err
+			}
+			valueList = append(valueList, value)
+		}
+	}
+
+	return cres.HealthCheckerInfo{
+		Protocol:  healthChecker.Protocol,
+		Port:      healthChecker.Port,
+		Interval:  valueList[0],
+		Timeout:   valueList[1],
+		Threshold: valueList[2],
+	}, nil
+}
+
+// ===== [ Public Functions ] =====
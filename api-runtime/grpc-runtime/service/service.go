@@ -18,6 +18,11 @@ const (
 	rsSG    string = "sg"
 	rsKey   string = "keypair"
 	rsVM    string = "vm"
+	rsNLB   string = "nlb"
+	rsDisk  string = "disk"
+	rsMyImage   string = "myimage"
+	rsCluster   string = "cluster"
+	rsNodeGroup string = "nodegroup"
 )
 
 // ===== [ Types ] =====
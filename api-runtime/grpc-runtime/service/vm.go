@@ -60,7 +60,7 @@ func (s *CCMService) StartVM(ctx context.Context, req *pb.VMCreateRequest) (*pb.
 	}
 
 	// Call common-runtime API
-	result, err := cmrt.StartVM(req.ConnectionName, rsVM, reqInfo)
+	result, err := cmrt.StartVM(ctx, req.ConnectionName, rsVM, reqInfo)
 	if err != nil {
 		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.StartVM()")
 	}
@@ -99,7 +99,7 @@ func (s *CCMService) ListVM(ctx context.Context, req *pb.VMAllQryRequest) (*pb.L
 	logger.Debug("calling CCMService.ListVM()")
 
 	// Call common-runtime API
-	result, err := cmrt.ListVM(req.ConnectionName, rsVM)
+	result, err := cmrt.ListVM(ctx, req.ConnectionName, rsVM)
 	if err != nil {
 		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.ListVM()")
 	}
@@ -184,7 +184,7 @@ func (s *CCMService) TerminateVM(ctx context.Context, req *pb.VMQryRequest) (*pb
 	logger.Debug("calling CCMService.TerminateVM()")
 
 	// Call common-runtime API
-	_, result, err := cmrt.DeleteResource(req.ConnectionName, rsVM, req.Name, req.Force)
+	_, result, err := cmrt.DeleteResource(ctx, req.ConnectionName, rsVM, req.Name, req.Force)
 	if err != nil {
 		return nil, gc.ConvGrpcStatusErr(err, "", "CCMService.TerminateVM()")
 	}
@@ -0,0 +1,59 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package service
+
+import (
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// WatchStatus - VM/NLB/Cluster 상태 변경 스트림 (Name 미지정시 해당 자원 전체)
+func (s *CCMService) WatchStatus(req *pb.WatchRequest, stream pb.CCM_WatchStatusServer) error {
+	logger := logger.NewLogger()
+
+	logger.Debug("calling CCMService.WatchStatus()")
+
+	// Call common-runtime API
+	watchChan, err := cmrt.WatchStatus(stream.Context(), req.ConnectionName, req.ResourceType, req.Name)
+	if err != nil {
+		return gc.ConvGrpcStatusErr(err, "", "CCMService.WatchStatus()")
+	}
+
+	for event := range watchChan {
+		var resp pb.WatchEventResponse
+		err := gc.CopySrcToDest(event, &resp)
+		if err != nil {
+			return gc.ConvGrpcStatusErr(err, "", "CCMService.WatchStatus()")
+		}
+		if err := stream.Send(&resp); err != nil {
+			return err
+		}
+	}
+	// the slow watcher is closed before the cancel, the client should watch again to get the current status.
+	if stream.Context().Err() == nil {
+		return status.Errorf(codes.Unavailable, "The watch is closed by the slow receiving, watch again!")
+	}
+	return nil
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
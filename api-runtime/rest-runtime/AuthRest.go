@@ -0,0 +1,370 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	aim "github.com/cloud-barista/cb-spider/cloud-info-manager/auth-info-manager"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Rest Auth
+
+// The admin of API_USERNAME/API_PASSWORD, it can access all APIs.
+type envAdmin struct {
+	username string
+	password string
+}
+
+// the context key of the authenticated token(*aim.TokenInfo)
+const authTokenKey = "authToken"
+
+// the routes only for the admin, all methods.
+var adminPathPrefixList = []string{
+	"/spider/auth/",
+	"/spider/credential",
+	"/spider/webhook", // the events of all connections
+}
+
+// the routes of the cloud info, only the admin can change them.
+var cloudInfoPathPrefixList = []string{
+	"/spider/driver",
+	"/spider/region",
+	"/spider/connectionconfig",
+	"/spider/calllog/level",
+}
+
+// the POST routes without changes, a readonly token can call them.
+var readOnlyPostPathPrefixList = []string{
+	"/spider/priceinfo/vmcost",
+}
+
+// the routes without a connection, a connection-scoped token can read them.
+var unscopedPathPrefixList = []string{
+	"/spider/endpointinfo",
+	"/spider/healthcheck",
+	"/spider/cloudos",
+	"/spider/driver",
+	"/spider/region",
+	"/spider/connectionconfig",
+	"/spider/inventory",    // limited to the connections of the token by the handler
+	"/spider/vmspecsearch", // limited to the connections of the token by the handler
+	"/spider/adminweb",
+	"/spider/swagger",
+}
+
+func hasPathPrefix(path string, prefixList []string) bool {
+	for _, prefix := range prefixList {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// the role to call the route, c.Path() is the route path, ex) /spider/vm/:Name
+func requiredRole(method string, routePath string) string {
+	if hasPathPrefix(routePath, adminPathPrefixList) {
+		return aim.ADMIN
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return aim.READONLY
+	}
+	if method == http.MethodPost && hasPathPrefix(routePath, readOnlyPostPathPrefixList) {
+		return aim.READONLY
+	}
+	if hasPathPrefix(routePath, cloudInfoPathPrefixList) {
+		return aim.ADMIN
+	}
+	return aim.OPERATOR
+}
+
+// middleware to authenticate the request and to check the permission of the route.
+// The auth is enabled when API_USERNAME/API_PASSWORD is set or at least one token is registered.
+//   - Authorization: Bearer <token>
+//   - Authorization: Basic <TokenName:token>, or <API_USERNAME:API_PASSWORD> as the admin
+//
+// The token APIs are not opened without the auth,
+// so the first token is created by the admin of API_USERNAME/API_PASSWORD.
+func authMiddleware(admin envAdmin) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if admin.username == "" && !aim.HasToken() {
+				if strings.HasPrefix(c.Path(), "/spider/auth/") {
+					return echo.NewHTTPError(http.StatusForbidden,
+						"Set API_USERNAME and API_PASSWORD of the server to create the first API token!")
+				}
+				return next(c)
+			}
+
+			tokenInfo, err := authenticate(c, admin)
+			if err != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Basic realm=Restricted")
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			}
+			if tokenInfo == nil { // the admin of API_USERNAME/API_PASSWORD
+				return next(c)
+			}
+
+			role := requiredRole(c.Request().Method, c.Path())
+			if !aim.HasPermission(tokenInfo.Role, role) {
+				return echo.NewHTTPError(http.StatusForbidden,
+					"The token("+tokenInfo.TokenName+") of the "+tokenInfo.Role+" role can not call this API, it requires the "+role+" role!")
+			}
+
+			if len(tokenInfo.ConnectionNameList) > 0 {
+				connectionName, err := getRequestConnectionName(c)
+				if err != nil {
+					var httpErr *echo.HTTPError
+					if errors.As(err, &httpErr) {
+						return httpErr
+					}
+					return echo.NewHTTPError(http.StatusForbidden, err.Error())
+				}
+				if connectionName == "" {
+					if !(role == aim.READONLY && hasPathPrefix(c.Path(), unscopedPathPrefixList)) {
+						return echo.NewHTTPError(http.StatusForbidden,
+							"The token("+tokenInfo.TokenName+") is scoped to connections, the ConnectionName is required!")
+					}
+				} else if !tokenInfo.AllowConnection(connectionName) {
+					return echo.NewHTTPError(http.StatusForbidden,
+						"The token("+tokenInfo.TokenName+") can not access the connection("+connectionName+")!")
+				}
+			}
+
+			c.Set(authTokenKey, tokenInfo)
+			return next(c)
+		}
+	}
+}
+
+// the connections of a multi-connection request limited by a connection-scoped token.
+// empty connectionNames: all connections of the token
+func scopeConnectionNames(c echo.Context, connectionNames []string) ([]string, error) {
+	tokenInfo, ok := c.Get(authTokenKey).(*aim.TokenInfo)
+	if !ok || len(tokenInfo.ConnectionNameList) == 0 {
+		return connectionNames, nil
+	}
+	if len(connectionNames) == 0 {
+		return tokenInfo.ConnectionNameList, nil
+	}
+	for _, connectionName := range connectionNames {
+		if !tokenInfo.AllowConnection(connectionName) {
+			return nil, echo.NewHTTPError(http.StatusForbidden,
+				"The token("+tokenInfo.TokenName+") can not access the connection("+connectionName+")!")
+		}
+	}
+	return connectionNames, nil
+}
+
+// returns nil TokenInfo for the admin of API_USERNAME/API_PASSWORD.
+func authenticate(c echo.Context, admin envAdmin) (*aim.TokenInfo, error) {
+	authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
+	if strings.HasPrefix(authHeader, "Bearer ") {
+		return aim.Authenticate("", strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer ")))
+	}
+
+	username, password, ok := c.Request().BasicAuth()
+	if !ok {
+		return nil, echo.ErrUnauthorized
+	}
+
+	// Be careful to use constant time comparison to prevent timing attacks
+	if admin.username != "" &&
+		subtle.ConstantTimeCompare([]byte(username), []byte(admin.username)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(password), []byte(admin.password)) == 1 {
+		return nil, nil
+	}
+	return aim.Authenticate(username, password)
+}
+
+// the connection name of the request: path param, query param or body.
+// The handlers bind the ConnectionName from the body or the query(GET/DELETE),
+// so all of them are checked, and an error is returned if they are different.
+func getRequestConnectionName(c echo.Context) (string, error) {
+	nameList := []string{c.Param("ConnectConfig")}
+	if strings.HasPrefix(c.Path(), "/spider/connectionconfig/") {
+		nameList = append(nameList, c.Param("ConfigName"))
+	}
+	nameList = append(nameList, c.QueryParam("ConnectionName"), c.QueryParam("connection"))
+
+	bodyName, err := getBodyConnectionName(c)
+	if err != nil {
+		return "", err
+	}
+	nameList = append(nameList, bodyName)
+
+	connectionName := ""
+	for _, name := range nameList {
+		if name == "" {
+			continue
+		}
+		if connectionName != "" && name != connectionName {
+			return "", fmt.Errorf("The ConnectionNames of the request are different: %s, %s!", connectionName, name)
+		}
+		connectionName = name
+	}
+	return connectionName, nil
+}
+
+// the max size of the body read by the middlewares before the handler, the file upload is limited by vmFileBodyLimitMiddleware.
+const maxBufferedBodySize = 10 * 1024 * 1024
+
+// read the body up to maxBufferedBodySize, and restore it for the handler.
+// The body over the limit is rejected with 413(Request Entity Too Large).
+func readBufferedBody(c echo.Context) ([]byte, error) {
+	req := c.Request()
+	if req.ContentLength > maxBufferedBodySize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("The size(%d bytes) of the request exceeds the limit(%d bytes)!", req.ContentLength, maxBufferedBodySize))
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxBufferedBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("The size of the request exceeds the limit(%d bytes)!", maxBytesErr.Limit))
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// the ConnectionName of the body by the Content-Type as c.Bind() decodes it.
+func getBodyConnectionName(c echo.Context) (string, error) {
+	req := c.Request()
+	if req.Body == nil || req.ContentLength == 0 {
+		return "", nil
+	}
+
+	ctype := req.Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(ctype, echo.MIMEApplicationForm), strings.HasPrefix(ctype, echo.MIMEMultipartForm):
+		// the parsed form is kept in the request for the handler
+		if strings.HasPrefix(ctype, echo.MIMEMultipartForm) {
+			if _, err := c.MultipartForm(); err != nil {
+				return "", err
+			}
+		}
+		return req.PostFormValue("ConnectionName"), nil
+	case !strings.HasPrefix(ctype, echo.MIMEApplicationJSON) &&
+		!strings.HasPrefix(ctype, echo.MIMEApplicationXML) && !strings.HasPrefix(ctype, echo.MIMETextXML):
+		return "", nil
+	}
+
+	body, err := readBufferedBody(c)
+	if err != nil {
+		return "", err
+	}
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	var bodyInfo struct {
+		ConnectionName string
+	}
+	if strings.HasPrefix(ctype, echo.MIMEApplicationJSON) {
+		err = json.Unmarshal(body, &bodyInfo)
+	} else {
+		err = xml.Unmarshal(body, &bodyInfo)
+	}
+	if err != nil {
+		// the handler will return the error of the body
+		return "", nil
+	}
+	return bodyInfo.ConnectionName, nil
+}
+
+//================ API Token Handler
+
+// ex) curl -sX POST http://localhost:1024/spider/auth/token -H 'Content-Type: application/json' -d '{"TokenName": "ops-token01", "Role": "operator", "ConnectionNameList": ["aws-ohio-config"]}'
+// The Token is returned only at this time.
+func CreateToken(c echo.Context) error {
+	cblog.Info("call CreateToken()")
+
+	var req struct {
+		TokenName          string
+		Role               string
+		ConnectionNameList []string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	tokenInfo, token, err := aim.CreateToken(req.TokenName, req.Role, req.ConnectionNameList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		aim.TokenInfo
+		Token string
+	}
+	jsonResult.TokenInfo = *tokenInfo
+	jsonResult.Token = token
+
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/auth/token
+func ListToken(c echo.Context) error {
+	cblog.Info("call ListToken()")
+
+	infoList, err := aim.ListToken()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*aim.TokenInfo `json:"token"`
+	}
+	jsonResult.Result = infoList
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/auth/token/ops-token01
+func GetToken(c echo.Context) error {
+	cblog.Info("call GetToken()")
+
+	tokenInfo, err := aim.GetToken(c.Param("TokenName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, tokenInfo)
+}
+
+// ex) curl -sX DELETE http://localhost:1024/spider/auth/token/ops-token01
+func RevokeToken(c echo.Context) error {
+	cblog.Info("call RevokeToken()")
+
+	result, err := aim.RevokeToken(c.Param("TokenName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+	return c.JSON(http.StatusOK, &resultInfo)
+}
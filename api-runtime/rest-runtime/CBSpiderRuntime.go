@@ -9,7 +9,6 @@
 package restruntime
 
 import (
-	"crypto/subtle"
 	"fmt"
 	"strings"
 	"time"
@@ -21,6 +20,8 @@ import (
 
 	cr "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
 	aw "github.com/cloud-barista/cb-spider/api-runtime/rest-runtime/admin-web"
+	aim "github.com/cloud-barista/cb-spider/cloud-info-manager/auth-info-manager"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
 	"github.com/cloud-barista/cb-store/config"
 	"github.com/sirupsen/logrus"
 
@@ -221,6 +222,9 @@ func RunServer() {
 		{"GET", "/connectionconfig", ListConnectionConfig},
 		{"GET", "/connectionconfig/:ConfigName", GetConnectionConfig},
 		{"DELETE", "/connectionconfig/:ConfigName", DeleteConnectionConfig},
+		{"PUT", "/connectionconfig/:ConfigName/jumphost", SetConnectionJumpHosts},
+		{"GET", "/connectionconfig/:ConfigName/jumphost", GetConnectionJumpHosts},
+		{"DELETE", "/connectionconfig/:ConfigName/jumphost", DeleteConnectionJumpHosts},
 
 		//-------------------------------------------------------------------//
 
@@ -235,6 +239,14 @@ func RunServer() {
 		{"GET", "/vmspec/:Name", GetVMSpec},
 		{"GET", "/vmorgspec", ListOrgVMSpec},
 		{"GET", "/vmorgspec/:Name", GetOrgVMSpec},
+		{"GET", "/vmspecsearch", SearchVMSpec},
+
+		//----------PriceInfo Handler
+		{"GET", "/priceinfo/vmspec", ListVMSpecPrice},
+		{"GET", "/priceinfo/vmspec/:Name", GetVMSpecPrice},
+		{"GET", "/priceinfo/disk", ListDiskPrice},
+		{"GET", "/priceinfo/disk/:Name", GetDiskPrice},
+		{"POST", "/priceinfo/vmcost", EstimateVMCost},
 
 		//----------VPC Handler
 		{"POST", "/regvpc", RegisterVPC},
@@ -302,6 +314,12 @@ func RunServer() {
 		{"GET", "/vm", ListVM},
 		{"GET", "/vm/:Name", GetVM},
 		{"DELETE", "/vm/:Name", TerminateVM},
+		{"GET", "/vm/:Name/hostkey", GetVMHostKey},
+		{"DELETE", "/vm/:Name/hostkey", ResetVMHostKey},
+		{"POST", "/vm/:Name/ssh", RunVMCommand},
+		{"POST", "/vm/:Name/file", UploadVMFile},
+		{"GET", "/vm/:Name/file", DownloadVMFile},
+		{"POST", "/vmssh", RunVMCommandFanOut},
 		//-- for management
 		{"GET", "/allvm", ListAllVM},
 		{"DELETE", "/cspvm/:Id", TerminateCSPVM},
@@ -395,10 +413,55 @@ func RunServer() {
 		{"GET", "/cspresourcename/:Name", GetCSPResourceName},
 		//----------AnyCall Handler
 		{"POST", "/anycall", AnyCall},
+		//----------Tag Handler
+		{"POST", "/tag", AddTag},
+		{"GET", "/tag", ListTag},
+		{"DELETE", "/tag/:Key", RemoveTag},
+
+		//----------Infra Handler
+		{"POST", "/infra/plan", PlanInfra},
+		{"POST", "/infra/apply", ApplyInfra},
+		{"POST", "/infra/destroy", DestroyInfra},
+
+		//----------Reconcile Handler
+		{"POST", "/reconcile/policy", SetReconcilePolicy},
+		{"GET", "/reconcile/policy", ListReconcilePolicy},
+		{"DELETE", "/reconcile/policy", RemoveReconcilePolicy},
+		{"POST", "/reconcile", RunReconcile},
+		{"GET", "/reconcile/report", GetDriftReport},
+
+		//----------Inventory Handler
+		{"GET", "/inventory", ListInventory},
+
+		//----------Webhook Handler
+		{"POST", "/webhook", CreateWebhook},
+		{"GET", "/webhook", ListWebhook},
+		{"GET", "/webhook/:Name", GetWebhook},
+		{"DELETE", "/webhook/:Name", DeleteWebhook},
+		{"GET", "/webhook/:Name/delivery", ListWebhookDelivery},
+
+		//----------Status Watch Handler
+		{"GET", "/watch/:ResourceType", WatchStatus},
+		{"GET", "/watch/:ResourceType/:Name", WatchStatus},
 
 		//-------------------------------------------------------------------//
+		//----------API Token
+		{"POST", "/auth/token", CreateToken},
+		{"GET", "/auth/token", ListToken},
+		{"GET", "/auth/token/:TokenName", GetToken},
+		{"DELETE", "/auth/token/:TokenName", RevokeToken},
+
 		//----------SPLock Info
 		{"GET", "/splockinfo", GetAllSPLockInfo},
+		//----------Prometheus Metrics
+		{"GET", "/metrics", GetMetrics},
+		//----------Call-Log History
+		{"GET", "/calllog", ListCallLog},
+		{"GET", "/calllog/level", GetCallLogLevel},
+		{"PUT", "/calllog/level", SetCallLogLevel},
+		//----------Async Job
+		{"GET", "/job", ListJob},
+		{"GET", "/job/:ID", GetJob},
 		//----------SSH RUN
 		{"POST", "/sshrun", SSHRun},
 
@@ -436,6 +499,20 @@ func RunServer() {
 	}
 	//======================================= setup routes
 
+	// check the master key of the Credential encryption, a warning is logged without the key.
+	if _, err := cim.GetCurrentKeyVersion(); err != nil {
+		cblog.Error(err)
+	}
+
+	// fail the Jobs which were running when the server was stopped
+	cr.RecoverJobs()
+
+	// resume the webhook deliveries which were pending when the server was stopped
+	cr.ResumeWebhookDeliveries()
+
+	// start the drift reconcilers of the stored policies
+	cr.StartReconcilers()
+
 	// Run API Server
 	ApiServer(routes)
 
@@ -448,6 +525,8 @@ func ApiServer(routes []route) {
 	// Middleware
 	e.Use(middleware.CORS())
 	e.Use(middleware.Logger())
+	e.Use(metricsMiddleware)
+	e.Use(requestIDMiddleware)
 	e.Use(middleware.Recover())
 
         cbspiderRoot := os.Getenv("CBSPIDER_ROOT")
@@ -463,19 +542,19 @@ func ApiServer(routes []route) {
 	API_USERNAME := os.Getenv("API_USERNAME")
 	API_PASSWORD := os.Getenv("API_PASSWORD")
 
+	// API_USERNAME/API_PASSWORD is the admin, and the API tokens are managed by /spider/auth/token.
+	admin := envAdmin{}
 	if API_USERNAME != "" && API_PASSWORD != "" {
+		admin = envAdmin{API_USERNAME, API_PASSWORD}
+	}
+	if admin.username != "" || aim.HasToken() {
 		cblog.Info("**** Rest Auth Enabled ****")
-		e.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
-			// Be careful to use constant time comparison to prevent timing attacks
-			if subtle.ConstantTimeCompare([]byte(username), []byte(API_USERNAME)) == 1 &&
-				subtle.ConstantTimeCompare([]byte(password), []byte(API_PASSWORD)) == 1 {
-				return true, nil
-			}
-			return false, nil
-		}))
 	} else {
 		cblog.Info("**** Rest Auth Disabled ****")
 	}
+	e.Use(vmFileBodyLimitMiddleware)
+	e.Use(authMiddleware(admin))
+	e.Use(idempotencyMiddleware)
 
 	for _, route := range routes {
 		// /driver => /spider/driver
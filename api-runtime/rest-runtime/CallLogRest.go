@@ -0,0 +1,159 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/xid"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Call-Log History
+
+const (
+	defaultCallLogLimit = 100
+	maxCallLogLimit     = 1000 // limit=0 is also this, not all records.
+)
+
+// middleware to give an ID to each request, the ID is left in the call-logs of the request.
+// The client's X-Request-Id is used if it exists.
+func requestIDMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+		if requestID == "" {
+			requestID = xid.New().String()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+		// the records of the calls with the context of this request get the request ID.
+		req := c.Request()
+		c.SetRequest(req.WithContext(cmrt.WithRequestID(req.Context(), requestID)))
+		return next(c)
+	}
+}
+
+// since: RFC3339 time or duration before now, ex) "2022-10-17T09:00:00+09:00", "30m", "24h"
+func parseSince(strSince string) (time.Time, error) {
+	if strSince == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(strSince); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	since, err := time.Parse(time.RFC3339, strSince)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("since(%s) is not a RFC3339 time or a duration!", strSince)
+	}
+	return since, nil
+}
+
+func parseIntParam(c echo.Context, name string, defaultValue int) (int, error) {
+	strValue := c.QueryParam(name)
+	if strValue == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(strValue)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s(%s) is not a non-negative integer!", name, strValue)
+	}
+	return value, nil
+}
+
+// ex) curl -sX GET "http://localhost:1024/spider/calllog?connection=aws-ohio-config&resourceType=VM&since=24h&errorOnly=true&offset=0&limit=10"
+func ListCallLog(c echo.Context) error {
+	cblog.Info("call ListCallLog()")
+
+	connectionName := c.QueryParam("connection")
+	if connectionName == "" {
+		connectionName = c.QueryParam("ConnectionName")
+	}
+
+	since, err := parseSince(c.QueryParam("since"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	errorOnly := false
+	if strErrorOnly := c.QueryParam("errorOnly"); strErrorOnly != "" {
+		errorOnly, err = strconv.ParseBool(strErrorOnly)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("errorOnly(%s) is not a boolean!", strErrorOnly))
+		}
+	}
+
+	offset, err := parseIntParam(c, "offset", 0)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	limit, err := parseIntParam(c, "limit", defaultCallLogLimit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if limit == 0 || limit > maxCallLogLimit {
+		limit = maxCallLogLimit
+	}
+
+	// Call common-runtime API
+	result, total, err := cmrt.ListCallLog(connectionName, c.QueryParam("resourceType"), since, errorOnly, offset, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Total  int                   `json:"total"`
+		Offset int                   `json:"offset"`
+		Limit  int                   `json:"limit"`
+		Result []*call.CALLLOGRECORD `json:"calllog"`
+	}
+	jsonResult.Total = total
+	jsonResult.Offset = offset
+	jsonResult.Limit = limit
+	jsonResult.Result = result
+
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+type callLogLevelInfo struct {
+	Level string // info | error
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/calllog/level
+func GetCallLogLevel(c echo.Context) error {
+	cblog.Info("call GetCallLogLevel()")
+
+	return c.JSON(http.StatusOK, &callLogLevelInfo{Level: cmrt.GetCallLogLevel()})
+}
+
+// ex) curl -sX PUT http://localhost:1024/spider/calllog/level -H 'Content-Type: application/json' -d '{"Level": "error"}'
+func SetCallLogLevel(c echo.Context) error {
+	cblog.Info("call SetCallLogLevel()")
+
+	var req callLogLevelInfo
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	err := cmrt.SetCallLogLevel(req.Level)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, &callLogLevelInfo{Level: cmrt.GetCallLogLevel()})
+}
@@ -19,6 +19,7 @@ import (
 
         "github.com/labstack/echo/v4"
 
+        "context"
         "strconv"
         "strings"
 )
@@ -121,6 +122,8 @@ type ClusterReq struct {
 
 		// (3) NodeGroupInfo List
 		NodeGroupList	        []NodeGroupReq
+
+		TagList			[]cres.KeyValue
         }
 }
 
@@ -162,6 +165,8 @@ func CreateCluster(c echo.Context) error {
 				}, 
 		// (3) NodeGroup Info List
                 NodeGroupList: 	convertNodeGroupList(req.ReqInfo.NodeGroupList),
+
+                TagList:	req.ReqInfo.TagList,
         }
 
         // Resource Name has namespace prefix when from Tumblebug
@@ -169,25 +174,43 @@ func CreateCluster(c echo.Context) error {
                 attachNameSpaceToName(req.NameSpace, &reqInfo)
         }
 
-        // Call common-runtime API
-        result, err := cmrt.CreateCluster(req.ConnectionName, rsCluster, reqInfo)
+        if isAsync(c) {
+                return submitJob(c, req.ConnectionName, rsCluster, req.ReqInfo.Name, func() (interface{}, error) {
+                        // the job should not be canceled by the finished request
+                        return createCluster(context.Background(), req.ConnectionName, req.NameSpace, reqInfo)
+                })
+        }
+
+        jsonResult, err := createCluster(c.Request().Context(), req.ConnectionName, req.NameSpace, reqInfo)
         if err != nil {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
 
+        return c.JSON(http.StatusOK, jsonResult)
+}
+
+type createClusterResult struct {
+	Connection string
+	ClusterInfo *cres.ClusterInfo
+}
+
+func createCluster(ctx context.Context, connectionName string, nameSpace string, reqInfo cres.ClusterInfo) (*createClusterResult, error) {
+        // Call common-runtime API
+        result, err := cmrt.CreateCluster(ctx, connectionName, rsCluster, reqInfo)
+        if err != nil {
+                return nil, err
+        }
+
         // Resource Name has namespace prefix when from Tumblebug
-        if req.NameSpace != "" {                
-                detachNameSpaceFromName(req.NameSpace, result)
+        if nameSpace != "" {                
+                detachNameSpaceFromName(nameSpace, result)
         }
 
-	var jsonResult struct {
-		Connection string
-		ClusterInfo *cres.ClusterInfo
-	}
-	jsonResult.Connection =  req.ConnectionName
+	var jsonResult createClusterResult
+	jsonResult.Connection =  connectionName
 	jsonResult.ClusterInfo =  result
 
-        return c.JSON(http.StatusOK, &jsonResult)
+        return &jsonResult, nil
 }
 
 func convertIIDs(names []string) []cres.IID {
@@ -278,6 +301,17 @@ func ListCluster(c echo.Context) error {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
 
+        // filter by tags, ex) ?tag=owner:team-a&tag=env
+        if tagFilter := getTagFilter(c); len(tagFilter) > 0 {
+                filteredResult := []*cres.ClusterInfo{}
+                for _, info := range result {
+                        if cmrt.MatchTagFilter(info.TagList, tagFilter) {
+                                filteredResult = append(filteredResult, info)
+                        }
+                }
+                result = filteredResult
+        }
+
         // Resource Name has namespace prefix when from Tumblebug
         if req.NameSpace != "" {
                 for _, clusterInfo := range result {
@@ -653,7 +687,7 @@ func DeleteCluster(c echo.Context) error {
                 clusterName = nameSpace + clusterName
         }
         // Call common-runtime API
-        result, _, err := cmrt.DeleteResource(req.ConnectionName, rsCluster, clusterName, c.QueryParam("force"))
+        result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsCluster, clusterName, c.QueryParam("force"))
         if err != nil {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
@@ -86,6 +86,8 @@ type DiskReq struct {
 
                 DiskType        string
                 DiskSize        string
+
+                TagList         []cres.KeyValue
         }
 }
 
@@ -103,6 +105,7 @@ func CreateDisk(c echo.Context) error {
                 IId:           cres.IID{req.ReqInfo.Name, req.ReqInfo.Name},
                 DiskType:           req.ReqInfo.DiskType,
                 DiskSize:           req.ReqInfo.DiskSize,
+                TagList:            req.ReqInfo.TagList,
         }
 
         // Call common-runtime API
@@ -136,6 +139,17 @@ func ListDisk(c echo.Context) error {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
 
+        // filter by tags, ex) ?tag=owner:team-a&tag=env
+        if tagFilter := getTagFilter(c); len(tagFilter) > 0 {
+                filteredResult := []*cres.DiskInfo{}
+                for _, info := range result {
+                        if cmrt.MatchTagFilter(info.TagList, tagFilter) {
+                                filteredResult = append(filteredResult, info)
+                        }
+                }
+                result = filteredResult
+        }
+
         var jsonResult struct {
                 Result []*cres.DiskInfo `json:"disk"`
         }
@@ -240,7 +254,7 @@ func DeleteDisk(c echo.Context) error {
         }
 
         // Call common-runtime API
-        result, _, err := cmrt.DeleteResource(req.ConnectionName, rsDisk, c.Param("Name"), c.QueryParam("force"))
+        result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsDisk, c.Param("Name"), c.QueryParam("force"))
         if err != nil {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
@@ -0,0 +1,77 @@
+// Rest Runtime Server for VM's SSH Host Key of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"net/http"
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"github.com/labstack/echo/v4"
+)
+
+//================ VM SSH Host Key Handler
+
+// ex) curl -sX GET 'http://localhost:1024/spider/vm/vm-01/hostkey?ConnectionName=aws-ohio-config'
+func GetVMHostKey(c echo.Context) error {
+	cblog.Info("call GetVMHostKey()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetVMHostKey(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// the next SSH connection to the VM pins the new host key.
+// ex) curl -sX DELETE 'http://localhost:1024/spider/vm/vm-01/hostkey?ConnectionName=aws-ohio-config'
+func ResetVMHostKey(c echo.Context) error {
+	cblog.Info("call ResetVMHostKey()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ResetVMHostKey(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+	return c.JSON(http.StatusOK, &resultInfo)
+}
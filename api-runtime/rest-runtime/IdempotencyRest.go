@@ -0,0 +1,143 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Idempotency Key of the Create APIs
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	idempotencyRESTScopeStart = "REST:POST "
+)
+
+// the create APIs, which accept the Idempotency-Key header.
+var idempotentPaths = map[string]bool{
+	"/spider/vpc":                     true,
+	"/spider/regvpc":                  true,
+	"/spider/vpc/:VPCName/subnet":     true,
+	"/spider/securitygroup":           true,
+	"/spider/regsecuritygroup":        true,
+	"/spider/keypair":                 true,
+	"/spider/regkeypair":              true,
+	"/spider/vm":                      true,
+	"/spider/regvm":                   true,
+	"/spider/nlb":                     true,
+	"/spider/regnlb":                  true,
+	"/spider/disk":                    true,
+	"/spider/regdisk":                 true,
+	"/spider/myimage":                 true,
+	"/spider/regmyimage":              true,
+	"/spider/cluster":                 true,
+	"/spider/regcluster":              true,
+	"/spider/cluster/:Name/nodegroup": true,
+}
+
+// the status of the duplicate request while the first request is running.
+type IdempotencyStatusInfo struct {
+	IdempotencyKey string
+	Status         string // InProgress
+	CreatedTime    time.Time
+}
+
+// middleware to run a create request with the same Idempotency-Key only once.
+// ex) curl -sX POST http://localhost:1024/spider/vm -H 'Content-Type: application/json' -H 'Idempotency-Key: 0b6d3f6e-vm-01' -d '{ "ConnectionName": "aws-ohio-config", "ReqInfo": { "Name": "vm-01", ... } }'
+//
+// The duplicate request gets the stored result of the first request with the 'Idempotent-Replayed: true' header,
+// or 409(Conflict) with the InProgress status while the first request is running.
+// The key used with a different request body is rejected with 422(Unprocessable Entity).
+// The failed request releases the key, so it can be retried with the same key.
+func idempotencyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		key := req.Header.Get(HeaderIdempotencyKey)
+		if key == "" || req.Method != http.MethodPost || !idempotentPaths[c.Path()] {
+			return next(c)
+		}
+
+		body, err := readBufferedBody(c)
+		if err != nil {
+			return err
+		}
+
+		scope := idempotencyRESTScopeStart + c.Path()
+		requestHash := cmrt.IdempotencyRequestHash([]byte(req.URL.RequestURI()), body)
+		record, reserved, err := cmrt.BeginIdempotentRequest(scope, key, requestHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, cmrt.ErrIdempotencyKeyInvalid):
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			case errors.Is(err, cmrt.ErrIdempotencyKeyMismatch):
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		c.Response().Header().Set(HeaderIdempotencyKey, key)
+
+		if !reserved {
+			if record.Status == cmrt.IdempotencyInProgress {
+				statusInfo := IdempotencyStatusInfo{
+					IdempotencyKey: record.Key,
+					Status:         record.Status,
+					CreatedTime:    record.CreatedTime,
+				}
+				return c.JSON(http.StatusConflict, &statusInfo)
+			}
+			c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+			return c.Blob(record.StatusCode, echo.MIMEApplicationJSONCharsetUTF8, []byte(record.Result))
+		}
+
+		// release the key if the handler fails or panics.
+		completed := false
+		defer func() {
+			if !completed {
+				cmrt.ReleaseIdempotentRequest(record)
+			}
+		}()
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+		if err := next(c); err != nil {
+			return err
+		}
+		status := c.Response().Status
+		if status < 200 || status >= 300 {
+			return nil
+		}
+		if err := cmrt.CompleteIdempotentRequest(record, status, recorder.body.String()); err != nil {
+			cblog.Error(err)
+			return nil
+		}
+		completed = true
+		return nil
+	}
+}
+
+// keeps the response body for the duplicate requests.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,148 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"context"
+	"io/ioutil"
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Infra Handler
+
+// resource type of the infra Job
+const rsInfra = "infra"
+
+// the infra document of the request body(YAML or JSON).
+// The ConnectionName query param overrides the ConnectionName of the document.
+func getInfraDoc(c echo.Context) (*cmrt.InfraDoc, error) {
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := cmrt.ParseInfraDoc(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if connectionName := c.QueryParam("ConnectionName"); connectionName != "" {
+		doc.ConnectionName = connectionName
+	}
+	return doc, nil
+}
+
+// ex) curl -sX POST http://localhost:1024/spider/infra/plan -H 'Content-Type: application/yaml' --data-binary @infra.yaml
+// ex) curl -sX POST 'http://localhost:1024/spider/infra/plan?destroy=true' -H 'Content-Type: application/yaml' --data-binary @infra.yaml
+func PlanInfra(c echo.Context) error {
+	cblog.Info("call PlanInfra()")
+
+	doc, err := getInfraDoc(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	mode := cmrt.INFRA_APPLY
+	if destroy, _ := strconv.ParseBool(c.QueryParam("destroy")); destroy {
+		mode = cmrt.INFRA_DESTROY
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.PlanInfra(doc, mode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX POST 'http://localhost:1024/spider/infra/apply?async=true' -H 'Content-Type: application/yaml' --data-binary @infra.yaml
+// The failed apply returns the plan with the status of each item and the rolled back resources.
+// The async apply does not return the private keys of the created KeyPairs.
+func ApplyInfra(c echo.Context) error {
+	cblog.Info("call ApplyInfra()")
+
+	doc, err := getInfraDoc(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if isAsync(c) {
+		// check the document before the Job
+		if _, err := cmrt.PlanInfra(doc, cmrt.INFRA_APPLY); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return submitJob(c, doc.ConnectionName, rsInfra, doc.Name, func() (interface{}, error) {
+			// the job should not be canceled by the finished request
+			plan, err := cmrt.ApplyInfra(context.Background(), doc)
+			if plan != nil {
+				// the Job result is kept in the store and read by GET /spider/job/:ID,
+				// so the private keys are returned only by the sync apply.
+				plan.PrivateKeyList = nil
+			}
+			return plan, err
+		})
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ApplyInfra(c.Request().Context(), doc)
+	if err != nil {
+		if result != nil {
+			return c.JSON(http.StatusInternalServerError, struct {
+				Message string `json:"message"`
+				*cmrt.InfraPlan
+			}{err.Error(), result})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX POST http://localhost:1024/spider/infra/destroy -H 'Content-Type: application/yaml' --data-binary @infra.yaml
+func DestroyInfra(c echo.Context) error {
+	cblog.Info("call DestroyInfra()")
+
+	doc, err := getInfraDoc(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if isAsync(c) {
+		// check the document before the Job
+		if _, err := cmrt.PlanInfra(doc, cmrt.INFRA_DESTROY); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return submitJob(c, doc.ConnectionName, rsInfra, doc.Name, func() (interface{}, error) {
+			// the job should not be canceled by the finished request
+			return cmrt.DestroyInfra(context.Background(), doc)
+		})
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DestroyInfra(c.Request().Context(), doc)
+	if err != nil {
+		if result != nil {
+			return c.JSON(http.StatusInternalServerError, struct {
+				Message string `json:"message"`
+				*cmrt.InfraPlan
+			}{err.Error(), result})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
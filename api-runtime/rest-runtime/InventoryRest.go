@@ -0,0 +1,86 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Inventory Handler
+
+// ex) curl -sX GET http://localhost:1024/spider/inventory
+// ex) curl -sX GET 'http://localhost:1024/spider/inventory?ProviderName=AWS&ProviderName=GCP&ResourceType=vm&ResourceType=disk'
+// ex) curl -sX GET 'http://localhost:1024/spider/inventory?ConnectionName=aws-ohio-config&ConnectionName=gcp-iowa-config&RegionName=us-east-2'
+func ListInventory(c echo.Context) error {
+	cblog.Info("call ListInventory()")
+
+	var req struct {
+		ConnectionNames []string
+		ProviderNames   []string
+		RegionNames     []string
+		ResourceTypes   []string
+		MaxWorkers      int
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	queryParams := c.QueryParams()
+	if len(req.ConnectionNames) == 0 {
+		req.ConnectionNames = queryParams["ConnectionName"]
+	}
+	if len(req.ProviderNames) == 0 {
+		req.ProviderNames = queryParams["ProviderName"]
+	}
+	if len(req.RegionNames) == 0 {
+		req.RegionNames = queryParams["RegionName"]
+	}
+	if len(req.ResourceTypes) == 0 {
+		req.ResourceTypes = queryParams["ResourceType"]
+	}
+	if req.MaxWorkers == 0 && c.QueryParam("MaxWorkers") != "" {
+		maxWorkers, err := strconv.Atoi(c.QueryParam("MaxWorkers"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		req.MaxWorkers = maxWorkers
+	}
+
+	// a connection-scoped token lists only its connections
+	connectionNames, err := scopeConnectionNames(c, req.ConnectionNames)
+	if err != nil {
+		return err
+	}
+	req.ConnectionNames = connectionNames
+
+	reqInfo := cmrt.InventoryReqInfo{
+		ConnectionNames: req.ConnectionNames,
+		ProviderNames:   req.ProviderNames,
+		RegionNames:     req.RegionNames,
+		ResourceTypes:   req.ResourceTypes,
+		MaxWorkers:      req.MaxWorkers,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListInventory(c.Request().Context(), reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
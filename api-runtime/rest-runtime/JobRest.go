@@ -0,0 +1,83 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"strconv"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Async Job
+
+// ex) POST /spider/vm?async=true
+func isAsync(c echo.Context) bool {
+	async, err := strconv.ParseBool(c.QueryParam("async"))
+	if err != nil {
+		return false
+	}
+	return async
+}
+
+// run the operation as a Job and return the Job Info with 202(Accepted)
+func submitJob(c echo.Context, connectionName string, rsType string, rsName string, jobFunc cmrt.JobFunc) error {
+	jobInfo, err := cmrt.SubmitJob(connectionName, rsType, rsName, jobFunc)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, jobInfo)
+}
+
+func ListJob(c echo.Context) error {
+	cblog.Info("call ListJob()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListJob(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*cmrt.JobInfo `json:"job"`
+	}
+	jsonResult.Result = result
+
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+func GetJob(c echo.Context) error {
+	cblog.Info("call GetJob()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetJob(c.Param("ID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,82 @@
+// Rest Runtime Server for SSH Jump Hosts of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"net/http"
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"github.com/labstack/echo/v4"
+)
+
+//================ SSH Jump Host Handler
+
+// The jump hosts are used for the SSH connections to the VMs of the connection,
+// when a request has no jump hosts.
+type JumpHostReq struct {
+	JumpHosts []cmrt.SSHJumpHostInfo
+}
+
+// ex) curl -sX PUT http://localhost:1024/spider/connectionconfig/aws-ohio-config/jumphost -H 'Content-Type: application/json' -d '{"JumpHosts": [{"VMName": "bastion-vm-01"}]}'
+func SetConnectionJumpHosts(c echo.Context) error {
+	cblog.Info("call SetConnectionJumpHosts()")
+
+	req := JumpHostReq{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.SetConnectionJumpHosts(c.Param("ConfigName"), req.JumpHosts)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []cmrt.SSHJumpHostInfo `json:"jumphost"`
+	}
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/connectionconfig/aws-ohio-config/jumphost
+func GetConnectionJumpHosts(c echo.Context) error {
+	cblog.Info("call GetConnectionJumpHosts()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetConnectionJumpHosts(c.Param("ConfigName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []cmrt.SSHJumpHostInfo `json:"jumphost"`
+	}
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX DELETE http://localhost:1024/spider/connectionconfig/aws-ohio-config/jumphost
+func DeleteConnectionJumpHosts(c echo.Context) error {
+	cblog.Info("call DeleteConnectionJumpHosts()")
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteConnectionJumpHosts(c.Param("ConfigName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+	return c.JSON(http.StatusOK, &resultInfo)
+}
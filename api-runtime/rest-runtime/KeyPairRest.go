@@ -233,7 +233,7 @@ func DeleteKey(c echo.Context) error {
 	}
 
 	// Call common-runtime API
-	result, _, err := cmrt.DeleteResource(req.ConnectionName, rsKey, c.Param("Name"), c.QueryParam("force"))
+	result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsKey, c.Param("Name"), c.QueryParam("force"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -0,0 +1,55 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"time"
+
+	"github.com/cloud-barista/cb-spider/cloud-control-manager/metrics"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Prometheus Metrics
+
+var metricsHandler = echo.WrapHandler(metrics.Handler())
+
+// ex) curl -sX GET http://localhost:1024/spider/metrics
+func GetMetrics(c echo.Context) error {
+	return metricsHandler(c)
+}
+
+// middleware to count the requests and to measure the latencies per route
+func metricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		// the error is written to the response after all middlewares
+		code := c.Response().Status
+		if err != nil {
+			code = http.StatusInternalServerError
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				code = httpErr.Code
+			}
+		}
+
+		// use the route path(ex. /spider/vm/:Name) to keep the label values bounded
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveRESTRequest(c.Request().Method, route, code, time.Since(start))
+
+		return err
+	}
+}
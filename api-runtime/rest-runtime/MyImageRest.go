@@ -104,6 +104,12 @@ func SnapshotVM(c echo.Context) error {
                 SourceVM:      cres.IID{req.ReqInfo.SourceVM, req.ReqInfo.SourceVM},
         }
 
+        if isAsync(c) {
+                return submitJob(c, req.ConnectionName, rsMyImage, req.ReqInfo.Name, func() (interface{}, error) {
+                        return cmrt.SnapshotVM(req.ConnectionName, rsMyImage, reqInfo)
+                })
+        }
+
         // Call common-runtime API
         result, err := cmrt.SnapshotVM(req.ConnectionName, rsMyImage, reqInfo)
         if err != nil {
@@ -211,7 +217,7 @@ func DeleteMyImage(c echo.Context) error {
         }
 
         // Call common-runtime API
-        result, _, err := cmrt.DeleteResource(req.ConnectionName, rsMyImage, c.Param("Name"), c.QueryParam("force"))
+        result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsMyImage, c.Param("Name"), c.QueryParam("force"))
         if err != nil {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
@@ -18,6 +18,7 @@ import (
 
         "github.com/labstack/echo/v4"
 
+        "context"
         "strconv"
         "strings"
 )
@@ -119,6 +120,8 @@ type NLBReq struct {
 		//------ Backend
 		VMGroup         VMGroupReq
 		HealthChecker   HealthCheckerReq  // for int mapping with string
+
+		TagList         []cres.KeyValue
         }
 }
 // for int mapping with string
@@ -155,6 +158,7 @@ func CreateNLB(c echo.Context) error {
                 Listener: 	req.ReqInfo.Listener,
                 VMGroup: 	convertVMGroupInfo(req.ReqInfo.VMGroup),
                 //HealthChecker: below
+                TagList: 	req.ReqInfo.TagList,
         }
 	healthChecker, err := convertHealthCheckerInfo(req.ReqInfo.HealthChecker)
 	if err != nil {
@@ -162,8 +166,15 @@ func CreateNLB(c echo.Context) error {
 	}
 	reqInfo.HealthChecker = healthChecker
 
+        if isAsync(c) {
+                return submitJob(c, req.ConnectionName, rsNLB, req.ReqInfo.Name, func() (interface{}, error) {
+                        // the job should not be canceled by the finished request
+                        return cmrt.CreateNLB(context.Background(), req.ConnectionName, rsNLB, reqInfo)
+                })
+        }
+
         // Call common-runtime API
-        result, err := cmrt.CreateNLB(req.ConnectionName, rsNLB, reqInfo)
+        result, err := cmrt.CreateNLB(c.Request().Context(), req.ConnectionName, rsNLB, reqInfo)
         if err != nil {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
@@ -247,6 +258,17 @@ func ListNLB(c echo.Context) error {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
 
+        // filter by tags, ex) ?tag=owner:team-a&tag=env
+        if tagFilter := getTagFilter(c); len(tagFilter) > 0 {
+                filteredResult := []*cres.NLBInfo{}
+                for _, info := range result {
+                        if cmrt.MatchTagFilter(info.TagList, tagFilter) {
+                                filteredResult = append(filteredResult, info)
+                        }
+                }
+                result = filteredResult
+        }
+
         var jsonResult struct {
                 Result []*cres.NLBInfo `json:"nlb"`
         }
@@ -505,7 +527,7 @@ func DeleteNLB(c echo.Context) error {
         }
 
         // Call common-runtime API
-        result, _, err := cmrt.DeleteResource(req.ConnectionName, rsNLB, c.Param("Name"), c.QueryParam("force"))
+        result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsNLB, c.Param("Name"), c.QueryParam("force"))
         if err != nil {
                 return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
         }
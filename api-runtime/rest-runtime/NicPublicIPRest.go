@@ -27,6 +27,7 @@ func createVNic(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateVNicHandler()
 	if err != nil {
@@ -56,6 +57,7 @@ func listVNic(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateVNicHandler()
 	if err != nil {
@@ -92,6 +94,7 @@ func getVNic(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateVNicHandler()
 	if err != nil {
@@ -121,6 +124,7 @@ func deleteVNic(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreateVNicHandler()
 	if err != nil {
@@ -156,6 +160,7 @@ func createPublicIP(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreatePublicIPHandler()
 	if err != nil {
@@ -185,6 +190,7 @@ func listPublicIP(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreatePublicIPHandler()
 	if err != nil {
@@ -221,6 +227,7 @@ func getPublicIP(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreatePublicIPHandler()
 	if err != nil {
@@ -250,6 +257,7 @@ func deletePublicIP(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+        defer cldConn.Close()
 
 	handler, err := cldConn.CreatePublicIPHandler()
 	if err != nil {
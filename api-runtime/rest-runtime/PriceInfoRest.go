@@ -0,0 +1,175 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ PriceInfo Handler
+
+// ex) curl -sX GET http://localhost:1024/spider/priceinfo/vmspec?ConnectionName=mock-config01
+func ListVMSpecPrice(c echo.Context) error {
+	cblog.Info("call ListVMSpecPrice()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListVMSpecPrice(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*cres.PriceInfo `json:"price"`
+	}
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/priceinfo/vmspec/mock-vmspec-01?ConnectionName=mock-config01
+func GetVMSpecPrice(c echo.Context) error {
+	cblog.Info("call GetVMSpecPrice()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetVMSpecPrice(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/priceinfo/disk?ConnectionName=mock-config01
+func ListDiskPrice(c echo.Context) error {
+	cblog.Info("call ListDiskPrice()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListDiskPrice(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*cres.PriceInfo `json:"price"`
+	}
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/priceinfo/disk/SSD?ConnectionName=mock-config01
+func GetDiskPrice(c echo.Context) error {
+	cblog.Info("call GetDiskPrice()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetDiskPrice(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// The request body is the same as the body of StartVM.
+// ex) curl -sX POST http://localhost:1024/spider/priceinfo/vmcost -H 'Content-Type: application/json' -d '{"ConnectionName": "mock-config01", "ReqInfo": {"VMSpecName": "mock-vmspec-01", "RootDiskType": "SSD", "RootDiskSize": "100"}}'
+func EstimateVMCost(c echo.Context) error {
+	cblog.Info("call EstimateVMCost()")
+
+	var req struct {
+		ConnectionName string
+		ReqInfo        struct {
+			Name          string
+			VMSpecName    string
+			RootDiskType  string
+			RootDiskSize  string
+			DataDiskNames []string
+		}
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	diskIIDList := []cres.IID{}
+	for _, diskName := range req.ReqInfo.DataDiskNames {
+		diskIIDList = append(diskIIDList, cres.IID{diskName, ""})
+	}
+
+	reqInfo := cres.VMReqInfo{
+		IId:          cres.IID{req.ReqInfo.Name, ""},
+		VMSpecName:   req.ReqInfo.VMSpecName,
+		RootDiskType: req.ReqInfo.RootDiskType,
+		RootDiskSize: req.ReqInfo.RootDiskSize,
+		DataDiskIIDs: diskIIDList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.EstimateVMCost(req.ConnectionName, reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,177 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Reconcile Handler
+
+// resource type of the reconcile Job
+const rsReconcile = "reconcile"
+
+// ex) curl -sX POST http://localhost:1024/spider/reconcile/policy -H 'Content-Type: application/json' -d '{"ConnectionName": "aws-ohio-config", "ReqInfo": {"IntervalSec": 3600, "ResourceTypeList": ["vpc", "sg", "vm"], "AutoUnregisterStale": true}}'
+func SetReconcilePolicy(c echo.Context) error {
+	cblog.Info("call SetReconcilePolicy()")
+
+	var req struct {
+		ConnectionName string
+		ReqInfo        struct {
+			IntervalSec         int
+			ResourceTypeList    []string
+			AutoUnregisterStale bool
+			AutoRegisterOrphan  bool
+		}
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Common Runtime Policy
+	policy := cmrt.ReconcilePolicy{
+		ConnectionName:      req.ConnectionName,
+		IntervalSec:         req.ReqInfo.IntervalSec,
+		ResourceTypeList:    req.ReqInfo.ResourceTypeList,
+		AutoUnregisterStale: req.ReqInfo.AutoUnregisterStale,
+		AutoRegisterOrphan:  req.ReqInfo.AutoRegisterOrphan,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.SetReconcilePolicy(policy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/reconcile/policy
+// ex) curl -sX GET http://localhost:1024/spider/reconcile/policy?ConnectionName=aws-ohio-config
+func ListReconcilePolicy(c echo.Context) error {
+	cblog.Info("call ListReconcilePolicy()")
+
+	connectionName := c.QueryParam("ConnectionName")
+
+	// Call common-runtime API
+	var infoList []*cmrt.ReconcilePolicy
+	var err error
+	if connectionName == "" {
+		infoList, err = cmrt.ListReconcilePolicy()
+	} else {
+		var info *cmrt.ReconcilePolicy
+		info, err = cmrt.GetReconcilePolicy(connectionName)
+		infoList = []*cmrt.ReconcilePolicy{info}
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*cmrt.ReconcilePolicy `json:"policy"`
+	}
+	jsonResult.Result = infoList
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX DELETE http://localhost:1024/spider/reconcile/policy -H 'Content-Type: application/json' -d '{"ConnectionName": "aws-ohio-config"}'
+func RemoveReconcilePolicy(c echo.Context) error {
+	cblog.Info("call RemoveReconcilePolicy()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RemoveReconcilePolicy(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// ex) curl -sX POST http://localhost:1024/spider/reconcile -H 'Content-Type: application/json' -d '{"ConnectionName": "aws-ohio-config"}'
+// Without the policy of the connection, only the drift report is made.
+func RunReconcile(c echo.Context) error {
+	cblog.Info("call RunReconcile()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	if isAsync(c) {
+		return submitJob(c, req.ConnectionName, rsReconcile, req.ConnectionName, func() (interface{}, error) {
+			return cmrt.RunReconcile(req.ConnectionName)
+		})
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RunReconcile(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/reconcile/report?ConnectionName=aws-ohio-config
+func GetDriftReport(c echo.Context) error {
+	cblog.Info("call GetDriftReport()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetDriftReport(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
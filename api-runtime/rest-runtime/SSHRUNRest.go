@@ -9,8 +9,14 @@
 package restruntime
 
 import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
 	sshrun "github.com/cloud-barista/cb-spider/cloud-control-manager/vm-ssh"
 
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
 	"strings"
 	// REST API (echo)
 	"net/http"
@@ -50,3 +56,261 @@ func SSHRun(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, result)
 }
+
+//================ SSH RUN by VM Name
+
+// The VM's SSH access point, user and private key are resolved by Spider.
+type VMSSHRunReq struct {
+	ConnectionName string
+	ReqInfo        struct {
+		Command    string   // ex) "hostname"
+		Script     []string // ex) ["cd /tmp", "ls -al"], instead of the Command
+		Stdin      string   // optional
+		TimeoutSec int      // optional, default: 60
+
+		JumpHosts []cmrt.SSHJumpHostInfo // optional, default: the jump hosts of the connection
+	}
+}
+
+// ex) curl -sX POST http://localhost:1024/spider/vm/vm-01/ssh -H 'Content-Type: application/json' -d '{"ConnectionName": "aws-ohio-config", "ReqInfo": {"Command": "uname -a", "TimeoutSec": 30}}'
+func RunVMCommand(c echo.Context) error {
+	cblog.Info("call RunVMCommand()")
+
+	req := VMSSHRunReq{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	reqInfo := cmrt.VMCommandReq{
+		Command:    req.ReqInfo.Command,
+		Script:     req.ReqInfo.Script,
+		Stdin:      req.ReqInfo.Stdin,
+		TimeoutSec: req.ReqInfo.TimeoutSec,
+		JumpHosts:  req.ReqInfo.JumpHosts,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RunVMCommand(c.Request().Context(), req.ConnectionName, c.Param("Name"), reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//================ SSH RUN on multiple VMs
+
+// The target VMs are given by one of the VMNames, the VMNamePrefix and the NLBName.
+type VMSSHFanOutRunReq struct {
+	ConnectionName string
+	ReqInfo        struct {
+		VMNames      []string // ex) ["vm-01", "vm-02"]
+		VMNamePrefix string   // ex) "web-"
+		NLBName      string   // ex) "nlb-01", the VMs of the NLB's VM group
+		Parallelism  int      // optional, default: 10
+
+		Command    string   // ex) "hostname"
+		Script     []string // ex) ["cd /tmp", "ls -al"], instead of the Command
+		Stdin      string   // optional
+		TimeoutSec int      // optional, default: 60, for each VM
+
+		JumpHosts []cmrt.SSHJumpHostInfo // optional, default: the jump hosts of the connection
+	}
+}
+
+// ex) curl -sX POST http://localhost:1024/spider/vmssh -H 'Content-Type: application/json' -d '{"ConnectionName": "aws-ohio-config", "ReqInfo": {"VMNamePrefix": "web-", "Parallelism": 5, "Command": "uptime"}}'
+func RunVMCommandFanOut(c echo.Context) error {
+	cblog.Info("call RunVMCommandFanOut()")
+
+	req := VMSSHFanOutRunReq{}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	reqInfo := cmrt.VMCommandFanOutReq{
+		VMNames:      req.ReqInfo.VMNames,
+		VMNamePrefix: req.ReqInfo.VMNamePrefix,
+		NLBName:      req.ReqInfo.NLBName,
+		Parallelism:  req.ReqInfo.Parallelism,
+		CommandReq: cmrt.VMCommandReq{
+			Command:    req.ReqInfo.Command,
+			Script:     req.ReqInfo.Script,
+			Stdin:      req.ReqInfo.Stdin,
+			TimeoutSec: req.ReqInfo.TimeoutSec,
+			JumpHosts:  req.ReqInfo.JumpHosts,
+		},
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RunVMCommandFanOut(c.Request().Context(), req.ConnectionName, reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+//================ VM File Transfer (SCP)
+
+// the form fields and the boundaries of the upload form
+const vmFileFormOverhead = 1024 * 1024
+
+// middleware to limit the body of the file upload before it is parsed,
+// so it runs before the middlewares which can parse the form(ex. auth).
+func vmFileBodyLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		if req.Method != http.MethodPost || c.Path() != "/spider/vm/:Name/file" {
+			return next(c)
+		}
+
+		limit := int64(cmrt.MaxVMFileSize + vmFileFormOverhead)
+		if req.ContentLength > limit {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("The size(%d bytes) of the request exceeds the limit(%d bytes)!", req.ContentLength, limit))
+		}
+		req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+		return next(c)
+	}
+}
+
+// multipart form: file, ConnectionName, RemotePath, Mode(optional), SHA256(optional), TimeoutSec(optional),
+// JumpHosts(optional, json), ex) -F 'JumpHosts=[{"VMName": "bastion-vm-01"}]'
+// ex) curl -sX POST http://localhost:1024/spider/vm/vm-01/file -F file=@./app.conf -F ConnectionName=aws-ohio-config -F RemotePath=/home/cb-user/app.conf -F Mode=0600
+func UploadVMFile(c echo.Context) error {
+	cblog.Info("call UploadVMFile()")
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("The size of the request exceeds the limit(%d bytes)!", maxBytesErr.Limit))
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if file.Size > cmrt.MaxVMFileSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("The size(%d bytes) of the file exceeds the limit(%d bytes)!", file.Size, cmrt.MaxVMFileSize))
+	}
+
+	reqInfo := cmrt.VMFileUploadReq{
+		RemotePath: c.FormValue("RemotePath"),
+		Mode:       c.FormValue("Mode"),
+		SHA256:     c.FormValue("SHA256"),
+	}
+	if timeoutSec := c.FormValue("TimeoutSec"); timeoutSec != "" {
+		if reqInfo.TimeoutSec, err = strconv.Atoi(timeoutSec); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+	if jumpHosts := c.FormValue("JumpHosts"); jumpHosts != "" {
+		if err := json.Unmarshal([]byte(jumpHosts), &reqInfo.JumpHosts); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer src.Close()
+
+	// Call common-runtime API
+	result, err := cmrt.UploadVMFile(c.Request().Context(), c.FormValue("ConnectionName"), c.Param("Name"), reqInfo, src, file.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// The file is streamed with the headers: X-Spider-File-Mode and X-Spider-File-Size,
+// and the trailer: X-Spider-File-SHA256. The connection is aborted if the download fails in the middle.
+// ex) curl -sX GET 'http://localhost:1024/spider/vm/vm-01/file?ConnectionName=aws-ohio-config&RemotePath=/etc/hostname' -o hostname
+func DownloadVMFile(c echo.Context) error {
+	cblog.Info("call DownloadVMFile()")
+
+	var req struct {
+		ConnectionName string
+		RemotePath     string
+		MaxSize        int64
+		TimeoutSec     int
+		JumpHosts      []cmrt.SSHJumpHostInfo // only by the json body
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+	if req.RemotePath == "" {
+		req.RemotePath = c.QueryParam("RemotePath")
+	}
+	if req.MaxSize == 0 && c.QueryParam("MaxSize") != "" {
+		maxSize, err := strconv.ParseInt(c.QueryParam("MaxSize"), 10, 64)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		req.MaxSize = maxSize
+	}
+	if req.TimeoutSec == 0 && c.QueryParam("TimeoutSec") != "" {
+		timeoutSec, err := strconv.Atoi(c.QueryParam("TimeoutSec"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		req.TimeoutSec = timeoutSec
+	}
+
+	reqInfo := cmrt.VMFileDownloadReq{
+		RemotePath: req.RemotePath,
+		MaxSize:    req.MaxSize,
+		TimeoutSec: req.TimeoutSec,
+		JumpHosts:  req.JumpHosts,
+	}
+
+	// Call common-runtime API
+	w := &vmFileResponseWriter{c: c, remotePath: req.RemotePath}
+	result, err := cmrt.DownloadVMFile(c.Request().Context(), req.ConnectionName, c.Param("Name"), reqInfo, w)
+	if err != nil {
+		if !w.started {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		// the status is already sent, so the client knows the failure by the aborted connection
+		cblog.Error(err)
+		panic(http.ErrAbortHandler)
+	}
+
+	c.Response().Header().Set(vmFileSHA256Header, result.SHA256)
+	return nil
+}
+
+const vmFileSHA256Header = "X-Spider-File-SHA256"
+
+// streams the downloaded file to the response, the headers are written before the content.
+type vmFileResponseWriter struct {
+	c          echo.Context
+	remotePath string
+	started    bool
+}
+
+func (w *vmFileResponseWriter) WriteFileHeader(mode string, size int64) error {
+	header := w.c.Response().Header()
+	header.Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", path.Base(w.remotePath)))
+	header.Set("X-Spider-File-Mode", mode)
+	header.Set("X-Spider-File-Size", strconv.FormatInt(size, 10))
+	// the checksum is known after the content
+	header.Set("Trailer", vmFileSHA256Header)
+	w.c.Response().WriteHeader(http.StatusOK)
+	w.started = true
+	return nil
+}
+
+func (w *vmFileResponseWriter) Write(p []byte) (int, error) {
+	return w.c.Response().Write(p)
+}
+
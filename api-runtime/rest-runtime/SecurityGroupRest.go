@@ -88,6 +88,7 @@ type securityGroupCreateReq struct {
 		VPCName       string
 		Direction     string
 		SecurityRules *[]cres.SecurityRuleInfo
+		TagList       []cres.KeyValue
 	}
 }
 
@@ -118,6 +119,7 @@ func CreateSecurity(c echo.Context) error {
 		VpcIID:        cres.IID{req.ReqInfo.VPCName, ""},
 		// deprecated; Direction:     req.ReqInfo.Direction,
 		SecurityRules: req.ReqInfo.SecurityRules,
+		TagList:       req.ReqInfo.TagList,
 	}
 
 	// Call common-runtime API
@@ -151,6 +153,17 @@ func ListSecurity(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	// filter by tags, ex) ?tag=owner:team-a&tag=env
+	if tagFilter := getTagFilter(c); len(tagFilter) > 0 {
+		filteredResult := []*cres.SecurityInfo{}
+		for _, info := range result {
+			if cmrt.MatchTagFilter(info.TagList, tagFilter) {
+				filteredResult = append(filteredResult, info)
+			}
+		}
+		result = filteredResult
+	}
+
 	var jsonResult struct {
 		Result []*cres.SecurityInfo `json:"securitygroup"`
 	}
@@ -227,7 +240,7 @@ func DeleteSecurity(c echo.Context) error {
 	}
 
 	// Call common-runtime API
-	result, _, err := cmrt.DeleteResource(req.ConnectionName, rsSG, c.Param("Name"), c.QueryParam("force"))
+	result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsSG, c.Param("Name"), c.QueryParam("force"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -0,0 +1,128 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strconv"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Tag Handler
+
+// ResourceType: vpc | sg | vm | nlb | disk | cluster
+//
+//	ex) curl -sX POST http://localhost:1024/spider/tag -H 'Content-Type: application/json' -d \
+//		'{"ConnectionName": "mock-config01", "ReqInfo": {"ResourceType": "vm", "ResourceName": "vm-01", "Tag": {"Key": "owner", "Value": "team-a"}}}'
+func AddTag(c echo.Context) error {
+	cblog.Info("call AddTag()")
+
+	var req struct {
+		ConnectionName string
+		ReqInfo        struct {
+			ResourceType string
+			ResourceName string
+			Tag          cres.KeyValue
+		}
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AddTag(req.ConnectionName, req.ReqInfo.ResourceType, req.ReqInfo.ResourceName, req.ReqInfo.Tag)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX GET "http://localhost:1024/spider/tag?ConnectionName=mock-config01&ResourceType=vm&ResourceName=vm-01"
+func ListTag(c echo.Context) error {
+	cblog.Info("call ListTag()")
+
+	var req struct {
+		ConnectionName string
+		ResourceType   string
+		ResourceName   string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+	if req.ResourceType == "" {
+		req.ResourceType = c.QueryParam("ResourceType")
+	}
+	if req.ResourceName == "" {
+		req.ResourceName = c.QueryParam("ResourceName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListTag(req.ConnectionName, req.ResourceType, req.ResourceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []cres.KeyValue `json:"tag"`
+	}
+	jsonResult.Result = result
+
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+//	ex) curl -sX DELETE http://localhost:1024/spider/tag/owner -H 'Content-Type: application/json' -d \
+//		'{"ConnectionName": "mock-config01", "ReqInfo": {"ResourceType": "vm", "ResourceName": "vm-01"}}'
+func RemoveTag(c echo.Context) error {
+	cblog.Info("call RemoveTag()")
+
+	var req struct {
+		ConnectionName string
+		ReqInfo        struct {
+			ResourceType string
+			ResourceName string
+		}
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RemoveTag(req.ConnectionName, req.ReqInfo.ResourceType, req.ReqInfo.ResourceName, c.Param("Key"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// the tag filter of the list APIs, all tags should be matched.
+// ex) GET /spider/vm?ConnectionName=mock-config01&tag=owner:team-a&tag=env
+// "tag=env:" matches only the empty value of env, "tag=env" matches any value of env.
+func getTagFilter(c echo.Context) []cmrt.TagFilter {
+	return cmrt.ParseTagFilter(c.QueryParams()["tag"])
+}
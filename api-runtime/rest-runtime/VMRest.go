@@ -18,6 +18,7 @@ import (
 
         "github.com/labstack/echo/v4"
 
+        "context"
         "strconv"
 )
 
@@ -126,6 +127,8 @@ func StartVM(c echo.Context) error {
 
 			VMUserId     string
 			VMUserPasswd string
+
+			TagList []cres.KeyValue
 		}
 	}
 
@@ -170,10 +173,19 @@ func StartVM(c echo.Context) error {
 
 		VMUserId:     req.ReqInfo.VMUserId,
 		VMUserPasswd: req.ReqInfo.VMUserPasswd,
+
+		TagList: req.ReqInfo.TagList,
+	}
+
+	if isAsync(c) {
+		return submitJob(c, req.ConnectionName, rsVM, req.ReqInfo.Name, func() (interface{}, error) {
+			// the job should not be canceled by the finished request
+			return cmrt.StartVM(context.Background(), req.ConnectionName, rsVM, reqInfo)
+		})
 	}
 
 	// Call common-runtime API
-	result, err := cmrt.StartVM(req.ConnectionName, rsVM, reqInfo)
+	result, err := cmrt.StartVM(c.Request().Context(), req.ConnectionName, rsVM, reqInfo)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -198,11 +210,22 @@ func ListVM(c echo.Context) error {
         }
 
 	// Call common-runtime API
-	result, err := cmrt.ListVM(req.ConnectionName, rsVM)
+	result, err := cmrt.ListVM(c.Request().Context(), req.ConnectionName, rsVM)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	// filter by tags, ex) ?tag=owner:team-a&tag=env
+	if tagFilter := getTagFilter(c); len(tagFilter) > 0 {
+		filteredResult := []*cres.VMInfo{}
+		for _, info := range result {
+			if cmrt.MatchTagFilter(info.TagList, tagFilter) {
+				filteredResult = append(filteredResult, info)
+			}
+		}
+		result = filteredResult
+	}
+
 	var jsonResult struct {
 		Result []*cres.VMInfo `json:"vm"`
 	}
@@ -305,7 +328,7 @@ func TerminateVM(c echo.Context) error {
 	}
 
 	// Call common-runtime API
-	_, result, err := cmrt.DeleteResource(req.ConnectionName, rsVM, c.Param("Name"), c.QueryParam("force"))
+	_, result, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsVM, c.Param("Name"), c.QueryParam("force"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -9,6 +9,7 @@
 package restruntime
 
 import (
+        "strconv"
 
         cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
         cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
@@ -123,3 +124,91 @@ func GetOrgVMSpec(c echo.Context) error {
 
 	return c.String(http.StatusOK, result)
 }
+
+// ex) curl -sX GET 'http://localhost:1024/spider/vmspecsearch?MinVCpu=4&MinMem=16384&ProviderName=AWS&ProviderName=GCP&ProviderName=AZURE'
+// ex) curl -sX GET 'http://localhost:1024/spider/vmspecsearch?MinGpu=1&GpuModel=V100&RegionName=us-east-2&Limit=10'
+func SearchVMSpec(c echo.Context) error {
+	cblog.Info("call SearchVMSpec()")
+
+	var req struct {
+		ConnectionNames []string
+		ProviderNames   []string
+		RegionNames     []string
+		MinVCpu         int
+		MaxVCpu         int
+		MinMem          int
+		MaxMem          int
+		MinGpu          int
+		MaxGpu          int
+		GpuModel        string
+		Limit           int
+		MaxWorkers      int
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	queryParams := c.QueryParams()
+	if len(req.ConnectionNames) == 0 {
+		req.ConnectionNames = queryParams["ConnectionName"]
+	}
+	if len(req.ProviderNames) == 0 {
+		req.ProviderNames = queryParams["ProviderName"]
+	}
+	if len(req.RegionNames) == 0 {
+		req.RegionNames = queryParams["RegionName"]
+	}
+	if req.GpuModel == "" {
+		req.GpuModel = c.QueryParam("GpuModel")
+	}
+	intParamList := []struct {
+		name  string
+		value *int
+	}{
+		{"MinVCpu", &req.MinVCpu}, {"MaxVCpu", &req.MaxVCpu},
+		{"MinMem", &req.MinMem}, {"MaxMem", &req.MaxMem},
+		{"MinGpu", &req.MinGpu}, {"MaxGpu", &req.MaxGpu},
+		{"Limit", &req.Limit}, {"MaxWorkers", &req.MaxWorkers},
+	}
+	for _, param := range intParamList {
+		if *param.value != 0 || c.QueryParam(param.name) == "" {
+			continue
+		}
+		value, err := strconv.Atoi(c.QueryParam(param.name))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, param.name+": "+err.Error())
+		}
+		*param.value = value
+	}
+
+	// a connection-scoped token searches only its connections
+	connectionNames, err := scopeConnectionNames(c, req.ConnectionNames)
+	if err != nil {
+		return err
+	}
+
+	reqInfo := cmrt.VMSpecSearchReqInfo{
+		ConnectionNames: connectionNames,
+		ProviderNames:   req.ProviderNames,
+		RegionNames:     req.RegionNames,
+		MinVCpu:         req.MinVCpu,
+		MaxVCpu:         req.MaxVCpu,
+		MinMem:          req.MinMem,
+		MaxMem:          req.MaxMem,
+		MinGpu:          req.MinGpu,
+		MaxGpu:          req.MaxGpu,
+		GpuModel:        req.GpuModel,
+		Limit:           req.Limit,
+		MaxWorkers:      req.MaxWorkers,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.SearchVMSpec(c.Request().Context(), reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -89,6 +89,7 @@ type vpcCreateReq struct {
                         Name      string
                         IPv4_CIDR string
                 }
+                TagList        []cres.KeyValue
         }
 }
 
@@ -124,6 +125,7 @@ func CreateVPC(c echo.Context) error {
 		IId:            cres.IID{req.ReqInfo.Name, ""},
 		IPv4_CIDR:      req.ReqInfo.IPv4_CIDR,
 		SubnetInfoList: subnetInfoList,
+		TagList:        req.ReqInfo.TagList,
 	}
 
 	// Call common-runtime API
@@ -157,6 +159,17 @@ func ListVPC(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	// filter by tags, ex) ?tag=owner:team-a&tag=env
+	if tagFilter := getTagFilter(c); len(tagFilter) > 0 {
+		filteredResult := []*cres.VPCInfo{}
+		for _, info := range result {
+			if cmrt.MatchTagFilter(info.TagList, tagFilter) {
+				filteredResult = append(filteredResult, info)
+			}
+		}
+		result = filteredResult
+	}
+
 	var jsonResult struct {
 		Result []*cres.VPCInfo `json:"vpc"`
 	}
@@ -234,7 +247,7 @@ func DeleteVPC(c echo.Context) error {
 	}
 
 	// Call common-runtime API
-	result, _, err := cmrt.DeleteResource(req.ConnectionName, rsVPC, c.Param("Name"), c.QueryParam("force"))
+	result, _, err := cmrt.DeleteResource(c.Request().Context(), req.ConnectionName, rsVPC, c.Param("Name"), c.QueryParam("force"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
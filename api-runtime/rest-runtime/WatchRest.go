@@ -0,0 +1,89 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"github.com/labstack/echo/v4"
+)
+
+// the interval of the SSE comment to keep the idle connection
+var watchKeepAliveInterval = 15 * time.Second
+
+//================ Status Watch Handler (Server-Sent Events)
+
+// ex) curl -sNX GET 'http://localhost:1024/spider/watch/vm?ConnectionName=aws-ohio-config'
+// ex) curl -sNX GET 'http://localhost:1024/spider/watch/nlb/spider-nlb-01?ConnectionName=aws-ohio-config'
+func WatchStatus(c echo.Context) error {
+	cblog.Info("call WatchStatus()")
+
+	var req struct {
+		ConnectionName string
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	ctx := c.Request().Context()
+	watchChan, err := cmrt.WatchStatus(ctx, req.ConnectionName, c.Param("ResourceType"), c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	ticker := time.NewTicker(watchKeepAliveInterval)
+	defer ticker.Stop()
+
+	eventID := 0
+	for {
+		select {
+		case event, ok := <-watchChan:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				cblog.Error(err)
+				continue
+			}
+			eventID++
+			if _, err := fmt.Fprintf(res, "id: %d\nevent: status\ndata: %s\n\n", eventID, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Rest Runtime Server of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Webhook Handler
+
+// ex) curl -sX POST http://localhost:1024/spider/webhook -H 'Content-Type: application/json' -d '{"Name": "orchestrator-hook", "URL": "http://localhost:8080/events", "EventTypes": ["created", "deleted", "status-changed"]}'
+// The Secret is returned only at this time.
+func CreateWebhook(c echo.Context) error {
+	cblog.Info("call CreateWebhook()")
+
+	var req struct {
+		Name             string
+		URL              string
+		Secret           string
+		EventTypes       []string
+		ConnectionNames  []string
+		ResourceTypes    []string
+		MaxRetries       int
+		RetryIntervalSec int
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	reqInfo := cmrt.WebhookInfo{
+		Name:             req.Name,
+		URL:              req.URL,
+		Secret:           req.Secret,
+		EventTypes:       req.EventTypes,
+		ConnectionNames:  req.ConnectionNames,
+		ResourceTypes:    req.ResourceTypes,
+		MaxRetries:       req.MaxRetries,
+		RetryIntervalSec: req.RetryIntervalSec,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateWebhook(reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/webhook
+func ListWebhook(c echo.Context) error {
+	cblog.Info("call ListWebhook()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListWebhook()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*cmrt.WebhookInfo `json:"webhook"`
+	}
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/webhook/orchestrator-hook
+func GetWebhook(c echo.Context) error {
+	cblog.Info("call GetWebhook()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetWebhook(c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ex) curl -sX DELETE http://localhost:1024/spider/webhook/orchestrator-hook
+func DeleteWebhook(c echo.Context) error {
+	cblog.Info("call DeleteWebhook()")
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteWebhook(c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// ex) curl -sX GET http://localhost:1024/spider/webhook/orchestrator-hook/delivery
+func ListWebhookDelivery(c echo.Context) error {
+	cblog.Info("call ListWebhookDelivery()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListWebhookDelivery(c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult struct {
+		Result []*cmrt.WebhookDelivery `json:"delivery"`
+	}
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
@@ -0,0 +1,145 @@
+// Test for Rest Auth of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	aim "github.com/cloud-barista/cb-spider/cloud-info-manager/auth-info-manager"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	dim "github.com/cloud-barista/cb-spider/cloud-info-manager/driver-info-manager"
+	rim "github.com/cloud-barista/cb-spider/cloud-info-manager/region-info-manager"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+
+	"github.com/labstack/echo/v4"
+)
+
+const authTestConnection = "auth-test-mock-config01"
+const authTestTokenName = "auth-test-token01"
+
+func setupAuthConnection(t *testing.T) {
+	// the Credentials are encrypted with the test key
+	if os.Getenv("SPIDER_KEY") == "" && os.Getenv("SPIDER_KEY_FILE") == "" {
+		os.Setenv("SPIDER_KEY", base64.StdEncoding.EncodeToString([]byte("auth-test-spider-key-01234567890")))
+		cim.SetSecretKeyProvider(nil)
+	}
+
+	dim.RegisterCloudDriver("auth-test-mock-driver01", "MOCK", "mock-driver-v1.0.so")
+	cim.RegisterCredential("auth-test-mock-credential01", "MOCK", []icbs.KeyValue{{"MockName", "auth-test-mock01"}})
+	rim.RegisterRegion("auth-test-mock-region01", "MOCK", []icbs.KeyValue{{"Region", "default"}})
+	ccim.CreateConnectionConfig(authTestConnection, "MOCK", "auth-test-mock-driver01", "auth-test-mock-credential01", "auth-test-mock-region01")
+	if _, err := ccim.GetConnectionConfig(authTestConnection); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		ccim.DeleteConnectionConfig(authTestConnection)
+		cim.UnRegisterCredential("auth-test-mock-credential01")
+		rim.UnRegisterRegion("auth-test-mock-region01")
+		dim.UnRegisterCloudDriver("auth-test-mock-driver01")
+	})
+}
+
+func newAuthTestServer() *echo.Echo {
+	e := echo.New()
+	e.Use(authMiddleware(envAdmin{}))
+	handler := func(c echo.Context) error {
+		var req struct {
+			ConnectionName string
+		}
+		if err := c.Bind(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return c.String(http.StatusOK, req.ConnectionName)
+	}
+	e.POST("/spider/vm", handler)
+	e.DELETE("/spider/vm/:Name", handler)
+	e.POST("/spider/auth/token", handler)
+	return e
+}
+
+func TestAuthConnectionName(t *testing.T) {
+	setupAuthConnection(t)
+
+	aim.RevokeToken(authTestTokenName)
+	_, token, err := aim.CreateToken(authTestTokenName, aim.OPERATOR, []string{authTestConnection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer aim.RevokeToken(authTestTokenName)
+
+	e := newAuthTestServer()
+	testList := []struct {
+		method, target, ctype, body string
+		expected                    int
+	}{
+		{"POST", "/spider/vm", echo.MIMEApplicationJSON, `{"ConnectionName": "` + authTestConnection + `"}`, http.StatusOK},
+		{"POST", "/spider/vm?ConnectionName=" + authTestConnection, echo.MIMEApplicationJSON, `{"ConnectionName": "` + authTestConnection + `"}`, http.StatusOK},
+		// the handler binds the body, not the query
+		{"POST", "/spider/vm?ConnectionName=" + authTestConnection, echo.MIMEApplicationJSON, `{"ConnectionName": "auth-test-other-config"}`, http.StatusForbidden},
+		{"POST", "/spider/vm", echo.MIMEApplicationJSON, `{"ConnectionName": "auth-test-other-config"}`, http.StatusForbidden},
+		{"POST", "/spider/vm?ConnectionName=" + authTestConnection, echo.MIMEApplicationForm,
+			url.Values{"ConnectionName": {"auth-test-other-config"}}.Encode(), http.StatusForbidden},
+		{"POST", "/spider/vm", echo.MIMEApplicationJSON, `{}`, http.StatusForbidden},
+		{"DELETE", "/spider/vm/vm-01", echo.MIMEApplicationJSON, `{"ConnectionName": "` + authTestConnection + `"}`, http.StatusOK},
+		{"DELETE", "/spider/vm/vm-01?ConnectionName=" + authTestConnection, echo.MIMEApplicationJSON, `{"ConnectionName": "auth-test-other-config"}`, http.StatusForbidden},
+		// the body over the limit is not read
+		{"POST", "/spider/vm", echo.MIMEApplicationJSON, `{"ConnectionName": "` + authTestConnection + `"}` + strings.Repeat(" ", maxBufferedBodySize), http.StatusRequestEntityTooLarge},
+	}
+	for _, test := range testList {
+		req := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+		if test.ctype != "" {
+			req.Header.Set(echo.HeaderContentType, test.ctype)
+		}
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != test.expected {
+			t.Errorf("%s %s %s: %d, expected %d: %s", test.method, test.target, test.body, rec.Code, test.expected, rec.Body.String())
+		}
+		if rec.Code == http.StatusOK && rec.Body.String() != authTestConnection {
+			t.Errorf("%s %s %s: the handler got %q", test.method, test.target, test.body, rec.Body.String())
+		}
+	}
+
+	// the body without the Content-Length is limited while it is read
+	body := `{"ConnectionName": "` + authTestConnection + `"}` + strings.Repeat(" ", maxBufferedBodySize)
+	req := httptest.NewRequest("POST", "/spider/vm", io.MultiReader(strings.NewReader(body)))
+	req.ContentLength = -1
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("the body without the Content-Length: %d, expected %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestAuthFirstToken(t *testing.T) {
+	if aim.HasToken() {
+		t.Skip("the tokens exist, the auth is enabled")
+	}
+
+	// the token APIs are not opened without the auth
+	e := newAuthTestServer()
+	req := httptest.NewRequest(http.MethodPost, "/spider/auth/token", strings.NewReader(`{"TokenName": "auth-test-token02", "Role": "admin"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("POST /spider/auth/token without the admin: %d, expected %d", rec.Code, http.StatusForbidden)
+	}
+}
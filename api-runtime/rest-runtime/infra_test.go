@@ -0,0 +1,72 @@
+// Test for Infra Rest of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"github.com/labstack/echo/v4"
+)
+
+const infraRestTestDoc = `
+ConnectionName: ` + authTestConnection + `
+KeyPairList:
+  - Name: infra-rest-keypair-01
+`
+
+func TestInfraApplyAsync(t *testing.T) {
+	setupAuthConnection(t)
+
+	doc, err := cmrt.ParseInfraDoc([]byte(infraRestTestDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cmrt.DestroyInfra(context.Background(), doc)
+
+	e := echo.New()
+	e.POST("/spider/infra/apply", ApplyInfra)
+	req := httptest.NewRequest(http.MethodPost, "/spider/infra/apply?async=true", strings.NewReader(infraRestTestDoc))
+	req.Header.Set(echo.HeaderContentType, "application/yaml")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST /spider/infra/apply?async=true: %d: %s", rec.Code, rec.Body.String())
+	}
+	jobInfo := cmrt.JobInfo{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &jobInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	// the private keys are not kept in the Job result
+	for idx := 0; idx < 100; idx++ {
+		job, err := cmrt.GetJob(jobInfo.JobId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status == cmrt.JobSucceeded || job.Status == cmrt.JobFailed {
+			if job.Status != cmrt.JobSucceeded {
+				t.Fatalf("the Job of the async apply: %s", job.Error)
+			}
+			if strings.Contains(string(job.Result), "PrivateKeyList") || strings.Contains(string(job.Result), "PRIVATE KEY") {
+				t.Errorf("the Job result has the private keys: %s", job.Result)
+			}
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("the Job of the async apply is not finished")
+}
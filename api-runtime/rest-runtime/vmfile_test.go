@@ -0,0 +1,99 @@
+// Test for VM File Transfer of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package restruntime
+
+import (
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"github.com/labstack/echo/v4"
+)
+
+// zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestVMFileBodyLimit(t *testing.T) {
+	e := echo.New()
+	e.Use(vmFileBodyLimitMiddleware)
+	e.POST("/spider/vm/:Name/file", UploadVMFile)
+
+	// rejected by the Content-Length before the body is read
+	req := httptest.NewRequest(http.MethodPost, "/spider/vm/vm-01/file", strings.NewReader(""))
+	req.ContentLength = cmrt.MaxVMFileSize + vmFileFormOverhead + 1
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Content-Length over the limit: %d, expected %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	// the body without the Content-Length is limited while it is parsed
+	bodyReader, bodyWriter := io.Pipe()
+	form := multipart.NewWriter(bodyWriter)
+	go func() {
+		part, _ := form.CreateFormFile("file", "large.bin")
+		io.Copy(part, io.LimitReader(zeroReader{}, cmrt.MaxVMFileSize+vmFileFormOverhead+1))
+		form.Close()
+		bodyWriter.Close()
+	}()
+	req = httptest.NewRequest(http.MethodPost, "/spider/vm/vm-01/file", bodyReader)
+	req.ContentLength = -1
+	req.Header.Set(echo.HeaderContentType, form.FormDataContentType())
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	bodyReader.Close()
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("the body over the limit: %d, expected %d: %s", rec.Code, http.StatusRequestEntityTooLarge, rec.Body.String())
+	}
+}
+
+func TestVMFileResponseWriter(t *testing.T) {
+	e := echo.New()
+	e.GET("/file", func(c echo.Context) error {
+		w := &vmFileResponseWriter{c: c, remotePath: "/tmp/hello.txt"}
+		if err := w.WriteFileHeader("0644", 6); err != nil {
+			return err
+		}
+		w.Write([]byte("hello\n"))
+		c.Response().Header().Set(vmFileSHA256Header, "checksum")
+		return nil
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "hello\n" || resp.Header.Get("X-Spider-File-Mode") != "0644" || resp.Header.Get("X-Spider-File-Size") != "6" {
+		t.Errorf("the response: %q, %v", body, resp.Header)
+	}
+	// the trailer is read after the body
+	if resp.Trailer.Get(vmFileSHA256Header) != "checksum" {
+		t.Errorf("the trailer: %v", resp.Trailer)
+	}
+}
@@ -0,0 +1,285 @@
+// Cloud Driver Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package clouddriverhandler
+
+import (
+	icon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/connect"
+	driverretry "github.com/cloud-barista/cb-spider/cloud-control-manager/driver-retry"
+	"github.com/cloud-barista/cb-spider/cloud-control-manager/metrics"
+	im "github.com/cloud-barista/cb-spider/cloud-info-manager"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Pool of CloudConnections to avoid the connect(auth handshake) cost on every API call.
+//
+// configuration by env:
+//   CONNECTION_CACHE_TTL: unit: sec, default: 600, 0: disable the connection cache.
+//   CONNECTION_CACHE_MAX: the max number of pooled connections, default: 100
+const (
+	defaultConnectionCacheTTL = 600
+	defaultConnectionCacheMax = 100
+)
+
+// CloudConnection of the driver shared by the callers of GetCloudConnection().
+// It is closed when it is removed from the cache and the last caller releases it.
+type sharedConnection struct {
+	cldConnection icon.CloudConnection
+	lock          sync.Mutex
+	refCount      int  // the number of the callers which have not released it
+	removed       bool // removed from the cache, or not cached
+}
+
+// the creator holds the new connection.
+func newSharedConnection(cldConnection icon.CloudConnection) *sharedConnection {
+	return &sharedConnection{cldConnection: cldConnection, refCount: 1}
+}
+
+func (conn *sharedConnection) acquire() {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+	conn.refCount++
+}
+
+func (conn *sharedConnection) release() {
+	conn.lock.Lock()
+	conn.refCount--
+	closing := conn.removed && conn.refCount == 0
+	conn.lock.Unlock()
+
+	if closing {
+		conn.close()
+	}
+}
+
+// The connection is closed now if no caller holds it, or by the last caller's release().
+// It is called with the lock of the cache, so the connection is closed by another goroutine.
+func (conn *sharedConnection) remove() {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+	if conn.removed {
+		return
+	}
+	conn.removed = true
+	if conn.refCount == 0 {
+		go conn.close()
+	}
+}
+
+func (conn *sharedConnection) close() {
+	if err := conn.cldConnection.Close(); err != nil {
+		cblog.Error(err)
+	}
+}
+
+type connectionCacheEntry struct {
+	cldConnection  *sharedConnection
+	providerName   string
+	driverName     string
+	credentialName string
+	regionName     string
+	createdTime    time.Time
+	lastUsedTime   time.Time
+}
+
+type connectionCache struct {
+	lock       sync.Mutex
+	entryMap   map[string]*connectionCacheEntry // key: ConnectionName
+	ttl        time.Duration
+	maxSize    int
+	generation uint64 // increased by every invalidation to prevent caching a stale connection
+}
+
+var connCache *connectionCache
+var connCacheOnce sync.Once
+
+func init() {
+	// invalidate the cached connections when the used meta info is changed or deleted.
+	im.AddInfoChangeHandler(invalidateCloudConnection)
+}
+
+// the cache is created at the first use, after the logger and env are ready.
+func getConnectionCache() *connectionCache {
+	connCacheOnce.Do(func() {
+		connCache = &connectionCache{
+			entryMap: make(map[string]*connectionCacheEntry),
+			ttl:      time.Duration(getEnvInt("CONNECTION_CACHE_TTL", defaultConnectionCacheTTL)) * time.Second,
+			maxSize:  getEnvInt("CONNECTION_CACHE_MAX", defaultConnectionCacheMax),
+		}
+	})
+	return connCache
+}
+
+func getEnvInt(envName string, defaultValue int) int {
+	strValue := os.Getenv(envName)
+	if strValue == "" {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(strValue)
+	if err != nil || intValue < 0 {
+		cblog.Errorf("$%s(%s) is not a valid number, so %d is used.", envName, strValue, defaultValue)
+		return defaultValue
+	}
+	return intValue
+}
+
+func (cache *connectionCache) enabled() bool {
+	return cache.ttl > 0 && cache.maxSize > 0
+}
+
+// return nil if there is no valid connection, the returned connection is held by the caller.
+func (cache *connectionCache) get(connectionName string) (*sharedConnection, string) {
+	if !cache.enabled() {
+		return nil, ""
+	}
+
+	cache.lock.Lock()
+	entry, ok := cache.entryMap[connectionName]
+	if !ok {
+		cache.lock.Unlock()
+		metrics.IncConnectionCacheMiss()
+		return nil, ""
+	}
+	if time.Since(entry.createdTime) > cache.ttl {
+		cache.removeWithoutLock(connectionName)
+		cache.lock.Unlock()
+		metrics.IncConnectionCacheMiss()
+		return nil, ""
+	}
+	entry.lastUsedTime = time.Now()
+	entry.cldConnection.acquire()
+	cache.lock.Unlock()
+
+	// health probing
+	connected, err := entry.cldConnection.cldConnection.IsConnected()
+	if err != nil || !connected {
+		if err != nil {
+			cblog.Error(err)
+		}
+		cblog.Info("CloudConnection Cache: " + connectionName + " is not connected, so it will be reconnected.")
+		cache.remove(connectionName, entry)
+		entry.cldConnection.release()
+		metrics.IncConnectionCacheMiss()
+		return nil, ""
+	}
+
+	metrics.IncConnectionCacheHit()
+	return entry.cldConnection, entry.providerName
+}
+
+// The connection which is not cached is closed by the last caller's release().
+func (cache *connectionCache) put(connectionName string, cccInfo *ccim.ConnectionConfigInfo,
+	cldConnection *sharedConnection, generation uint64) {
+	if !cache.enabled() {
+		cldConnection.remove()
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	// the meta info was changed while connecting
+	if generation != cache.generation {
+		cldConnection.remove()
+		return
+	}
+	if old, ok := cache.entryMap[connectionName]; ok {
+		old.cldConnection.remove()
+	}
+
+	if _, ok := cache.entryMap[connectionName]; !ok && len(cache.entryMap) >= cache.maxSize {
+		cache.evictLRUWithoutLock()
+	}
+
+	now := time.Now()
+	cache.entryMap[connectionName] = &connectionCacheEntry{
+		cldConnection:  cldConnection,
+		providerName:   cccInfo.ProviderName,
+		driverName:     cccInfo.DriverName,
+		credentialName: cccInfo.CredentialName,
+		regionName:     cccInfo.RegionName,
+		createdTime:    now,
+		lastUsedTime:   now,
+	}
+}
+
+func (cache *connectionCache) currentGeneration() uint64 {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.generation
+}
+
+// remove the entry only if it is still the same entry
+func (cache *connectionCache) remove(connectionName string, entry *connectionCacheEntry) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.entryMap[connectionName] == entry {
+		cache.removeWithoutLock(connectionName)
+	}
+}
+
+// The removed connection is closed when the callers which got it by GetCloudConnection() release it.
+func (cache *connectionCache) removeWithoutLock(connectionName string) {
+	if entry, ok := cache.entryMap[connectionName]; ok {
+		entry.cldConnection.remove()
+	}
+	delete(cache.entryMap, connectionName)
+}
+
+func (cache *connectionCache) evictLRUWithoutLock() {
+	var lruName string
+	var lruTime time.Time
+	for connectionName, entry := range cache.entryMap {
+		if lruName == "" || entry.lastUsedTime.Before(lruTime) {
+			lruName = connectionName
+			lruTime = entry.lastUsedTime
+		}
+	}
+	if lruName != "" {
+		cblog.Info("CloudConnection Cache: " + lruName + " is evicted by the max size.")
+		cache.removeWithoutLock(lruName)
+	}
+}
+
+// im.InfoChangeHandler
+func invalidateCloudConnection(kind im.InfoKind, infoName string) {
+	cache := getConnectionCache()
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.generation++
+
+	if kind == im.CONNECTIONCONFIG {
+		driverretry.RemoveConnection(infoName)
+	}
+
+	for connectionName, entry := range cache.entryMap {
+		var used bool
+		switch kind {
+		case im.CONNECTIONCONFIG:
+			used = (connectionName == infoName)
+		case im.DRIVER:
+			used = (entry.driverName == infoName)
+		case im.CREDENTIAL:
+			used = (entry.credentialName == infoName)
+		case im.REGION:
+			used = (entry.regionName == infoName)
+		}
+		if used {
+			cblog.Info("CloudConnection Cache: " + connectionName + " is invalidated by " + string(kind) + ":" + infoName)
+			cache.removeWithoutLock(connectionName)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Cloud Driver Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package clouddriverhandler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	icon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/connect"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+)
+
+// counts the Close() of the driver's connection
+type closeCountConnection struct {
+	icon.CloudConnection
+	closeCount int32
+}
+
+func (conn *closeCountConnection) IsConnected() (bool, error) {
+	return true, nil
+}
+
+func (conn *closeCountConnection) Close() error {
+	atomic.AddInt32(&conn.closeCount, 1)
+	return nil
+}
+
+func (conn *closeCountConnection) closed() int32 {
+	return atomic.LoadInt32(&conn.closeCount)
+}
+
+func newTestConnectionCache(ttl time.Duration) *connectionCache {
+	return &connectionCache{entryMap: make(map[string]*connectionCacheEntry), ttl: ttl, maxSize: 10}
+}
+
+var testConnectionConfig = &ccim.ConnectionConfigInfo{ProviderName: "MOCK", DriverName: "mock-driver01",
+	CredentialName: "mock-credential01", RegionName: "mock-region01"}
+
+func TestConnectionCacheRelease(t *testing.T) {
+	cache := newTestConnectionCache(time.Minute)
+
+	driverConn := &closeCountConnection{}
+	shared := newSharedConnection(driverConn)
+	cache.put("mock-config01", testConnectionConfig, shared, cache.currentGeneration())
+	first := newRetryCloudConnection(context.Background(), "mock-config01", "MOCK", shared)
+
+	cached, _ := cache.get("mock-config01")
+	if cached != shared {
+		t.Fatal("The pooled connection is not returned.")
+	}
+	second := newRetryCloudConnection(context.Background(), "mock-config01", "MOCK", cached)
+
+	// the caller's Close() releases the pooled connection only once
+	first.Close()
+	first.Close()
+	if driverConn.closed() != 0 {
+		t.Errorf("The pooled connection is closed by the caller.")
+	}
+
+	// the removed connection is closed by the last caller
+	removeTestConnection(cache, "mock-config01")
+	if driverConn.closed() != 0 {
+		t.Errorf("The removed connection is closed while the caller holds it.")
+	}
+	second.Close()
+	if driverConn.closed() != 1 {
+		t.Errorf("The removed connection is not closed by the last caller: %d", driverConn.closed())
+	}
+
+	// the released connection is closed when it is removed
+	driverConn = &closeCountConnection{}
+	shared = newSharedConnection(driverConn)
+	cache.put("mock-config01", testConnectionConfig, shared, cache.currentGeneration())
+	newRetryCloudConnection(context.Background(), "mock-config01", "MOCK", shared).Close()
+	removeTestConnection(cache, "mock-config01")
+	waitClosed(t, driverConn)
+
+	// the connection which is not cached is closed by the caller
+	cache = newTestConnectionCache(0)
+	driverConn = &closeCountConnection{}
+	shared = newSharedConnection(driverConn)
+	cache.put("mock-config01", testConnectionConfig, shared, cache.currentGeneration())
+	newRetryCloudConnection(context.Background(), "mock-config01", "MOCK", shared).Close()
+	if driverConn.closed() != 1 {
+		t.Errorf("The connection which is not cached is not closed: %d", driverConn.closed())
+	}
+}
+
+func removeTestConnection(cache *connectionCache, connectionName string) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.removeWithoutLock(connectionName)
+}
+
+// the connection removed without the callers is closed by another goroutine.
+func waitClosed(t *testing.T, driverConn *closeCountConnection) {
+	for idx := 0; idx < 100 && driverConn.closed() == 0; idx++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if driverConn.closed() != 1 {
+		t.Errorf("The removed connection is not closed: %d", driverConn.closed())
+	}
+}
@@ -0,0 +1,858 @@
+// Cloud Driver Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package clouddriverhandler
+
+import (
+	"context"
+	"sync"
+
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+	icon "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/connect"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	driverretry "github.com/cloud-barista/cb-spider/cloud-control-manager/driver-retry"
+)
+
+//================ Retry and Rate Limit of the Driver Calls
+
+// CloudConnection with the handlers, whose calls are rate-limited per connection.
+// Only the read-only calls(List, Get, ...Status) are retried on the throttling errors of the CSP,
+// the mutating calls(ex. StartVM, CreateVPC) may have been run by the CSP before the error.
+// cf) driverretry.Call(), $CBSPIDER_ROOT/conf/retry_conf.yaml
+// It is made for each caller with the context of the request, the CloudConnection of the driver is pooled.
+type retryCloudConnection struct {
+	icon.CloudConnection
+	shared         *sharedConnection
+	releaseOnce    sync.Once
+	ctx            context.Context
+	connectionName string
+	providerName   string
+}
+
+// the shared connection is held by the caller until Close().
+func newRetryCloudConnection(ctx context.Context, connectionName string, providerName string, shared *sharedConnection) icon.CloudConnection {
+	return &retryCloudConnection{CloudConnection: shared.cldConnection, shared: shared, ctx: ctx,
+		connectionName: connectionName, providerName: providerName}
+}
+
+// Close releases the pooled connection of the driver, it is closed by the last caller after it is removed from the pool.
+func (conn *retryCloudConnection) Close() error {
+	conn.releaseOnce.Do(conn.shared.release)
+	return nil
+}
+
+// run the read-only call with retries, and leave the retried call in the call-log with the retry count.
+func (conn *retryCloudConnection) call(rsType call.RES_TYPE, rsName string, apiName string, fn func() error) error {
+	start := call.Start()
+	retryCount, err := driverretry.Call(conn.ctx, conn.connectionName, conn.providerName, fn)
+	if retryCount == 0 {
+		return err
+	}
+
+	regionName, zoneName, _ := GetRegionNameByConnectionName(conn.connectionName)
+	callInfo := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.CLOUD_OS(conn.providerName),
+		RegionZone:   regionName + "/" + zoneName,
+		ResourceType: rsType,
+		ResourceName: rsName,
+		CloudOSAPI:   "CB-Spider:Retry:" + apiName,
+		ElapsedTime:  call.Elapsed(start),
+		ErrorMSG:     "",
+	}
+	if err != nil {
+		callInfo.ErrorMSG = err.Error()
+	}
+	// the RequestID of the caller's context, ex) the REST request
+	call.GetLogger("HISCALL").WithFields(call.RetryFields(conn.connectionName, call.RequestID(conn.ctx), retryCount)).Info(call.String(callInfo))
+	return err
+}
+
+// run the mutating call once within the rate limit, it is not retried.
+func (conn *retryCloudConnection) callOnce(fn func() error) error {
+	return driverretry.CallOnce(conn.ctx, conn.connectionName, conn.providerName, fn)
+}
+
+func (conn *retryCloudConnection) CreateImageHandler() (irs.ImageHandler, error) {
+	handler, err := conn.CloudConnection.CreateImageHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryImageHandler{ImageHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateVMSpecHandler() (irs.VMSpecHandler, error) {
+	handler, err := conn.CloudConnection.CreateVMSpecHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryVMSpecHandler{VMSpecHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateVPCHandler() (irs.VPCHandler, error) {
+	handler, err := conn.CloudConnection.CreateVPCHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryVPCHandler{VPCHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateSecurityHandler() (irs.SecurityHandler, error) {
+	handler, err := conn.CloudConnection.CreateSecurityHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retrySecurityHandler{SecurityHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateKeyPairHandler() (irs.KeyPairHandler, error) {
+	handler, err := conn.CloudConnection.CreateKeyPairHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryKeyPairHandler{KeyPairHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateVMHandler() (irs.VMHandler, error) {
+	handler, err := conn.CloudConnection.CreateVMHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryVMHandler{VMHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateNLBHandler() (irs.NLBHandler, error) {
+	handler, err := conn.CloudConnection.CreateNLBHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryNLBHandler{NLBHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateDiskHandler() (irs.DiskHandler, error) {
+	handler, err := conn.CloudConnection.CreateDiskHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryDiskHandler{DiskHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateMyImageHandler() (irs.MyImageHandler, error) {
+	handler, err := conn.CloudConnection.CreateMyImageHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryMyImageHandler{MyImageHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateClusterHandler() (irs.ClusterHandler, error) {
+	handler, err := conn.CloudConnection.CreateClusterHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryClusterHandler{ClusterHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateAnyCallHandler() (irs.AnyCallHandler, error) {
+	handler, err := conn.CloudConnection.CreateAnyCallHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryAnyCallHandler{AnyCallHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	handler, err := conn.CloudConnection.CreateTagHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryTagHandler{TagHandler: handler, conn: conn}, nil
+}
+
+func (conn *retryCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	handler, err := conn.CloudConnection.CreatePriceInfoHandler()
+	if err != nil || handler == nil {
+		return handler, err
+	}
+	return &retryPriceInfoHandler{PriceInfoHandler: handler, conn: conn}, nil
+}
+
+//================ Image Handler
+
+type retryImageHandler struct {
+	irs.ImageHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryImageHandler) CreateImage(imageReqInfo irs.ImageReqInfo) (info irs.ImageInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.ImageHandler.CreateImage(imageReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryImageHandler) ListImage() (infoList []*irs.ImageInfo, err error) {
+	err = h.conn.call(call.VMIMAGE, "", "ListImage()", func() error {
+		infoList, err = h.ImageHandler.ListImage()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryImageHandler) GetImage(imageIID irs.IID) (info irs.ImageInfo, err error) {
+	err = h.conn.call(call.VMIMAGE, imageIID.NameId, "GetImage()", func() error {
+		info, err = h.ImageHandler.GetImage(imageIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryImageHandler) CheckWindowsImage(imageIID irs.IID) (result bool, err error) {
+	err = h.conn.call(call.VMIMAGE, imageIID.NameId, "CheckWindowsImage()", func() error {
+		result, err = h.ImageHandler.CheckWindowsImage(imageIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryImageHandler) DeleteImage(imageIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.ImageHandler.DeleteImage(imageIID)
+		return err
+	})
+	return result, err
+}
+
+//================ VMSpec Handler
+
+type retryVMSpecHandler struct {
+	irs.VMSpecHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryVMSpecHandler) ListVMSpec() (infoList []*irs.VMSpecInfo, err error) {
+	err = h.conn.call(call.VMSPEC, "", "ListVMSpec()", func() error {
+		infoList, err = h.VMSpecHandler.ListVMSpec()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryVMSpecHandler) GetVMSpec(Name string) (info irs.VMSpecInfo, err error) {
+	err = h.conn.call(call.VMSPEC, Name, "GetVMSpec()", func() error {
+		info, err = h.VMSpecHandler.GetVMSpec(Name)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryVMSpecHandler) ListOrgVMSpec() (result string, err error) {
+	err = h.conn.call(call.VMSPEC, "", "ListOrgVMSpec()", func() error {
+		result, err = h.VMSpecHandler.ListOrgVMSpec()
+		return err
+	})
+	return result, err
+}
+
+func (h *retryVMSpecHandler) GetOrgVMSpec(Name string) (result string, err error) {
+	err = h.conn.call(call.VMSPEC, Name, "GetOrgVMSpec()", func() error {
+		result, err = h.VMSpecHandler.GetOrgVMSpec(Name)
+		return err
+	})
+	return result, err
+}
+
+//================ VPC Handler
+
+type retryVPCHandler struct {
+	irs.VPCHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryVPCHandler) CreateVPC(vpcReqInfo irs.VPCReqInfo) (info irs.VPCInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.VPCHandler.CreateVPC(vpcReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryVPCHandler) ListVPC() (infoList []*irs.VPCInfo, err error) {
+	err = h.conn.call(call.VPCSUBNET, "", "ListVPC()", func() error {
+		infoList, err = h.VPCHandler.ListVPC()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryVPCHandler) GetVPC(vpcIID irs.IID) (info irs.VPCInfo, err error) {
+	err = h.conn.call(call.VPCSUBNET, vpcIID.NameId, "GetVPC()", func() error {
+		info, err = h.VPCHandler.GetVPC(vpcIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryVPCHandler) DeleteVPC(vpcIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.VPCHandler.DeleteVPC(vpcIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryVPCHandler) AddSubnet(vpcIID irs.IID, subnetInfo irs.SubnetInfo) (info irs.VPCInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.VPCHandler.AddSubnet(vpcIID, subnetInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryVPCHandler) RemoveSubnet(vpcIID irs.IID, subnetIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.VPCHandler.RemoveSubnet(vpcIID, subnetIID)
+		return err
+	})
+	return result, err
+}
+
+//================ Security Handler
+
+type retrySecurityHandler struct {
+	irs.SecurityHandler
+	conn *retryCloudConnection
+}
+
+func (h *retrySecurityHandler) CreateSecurity(securityReqInfo irs.SecurityReqInfo) (info irs.SecurityInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.SecurityHandler.CreateSecurity(securityReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retrySecurityHandler) ListSecurity() (infoList []*irs.SecurityInfo, err error) {
+	err = h.conn.call(call.SECURITYGROUP, "", "ListSecurity()", func() error {
+		infoList, err = h.SecurityHandler.ListSecurity()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retrySecurityHandler) GetSecurity(securityIID irs.IID) (info irs.SecurityInfo, err error) {
+	err = h.conn.call(call.SECURITYGROUP, securityIID.NameId, "GetSecurity()", func() error {
+		info, err = h.SecurityHandler.GetSecurity(securityIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retrySecurityHandler) DeleteSecurity(securityIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.SecurityHandler.DeleteSecurity(securityIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retrySecurityHandler) AddRules(sgIID irs.IID, securityRules *[]irs.SecurityRuleInfo) (info irs.SecurityInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.SecurityHandler.AddRules(sgIID, securityRules)
+		return err
+	})
+	return info, err
+}
+
+func (h *retrySecurityHandler) RemoveRules(sgIID irs.IID, securityRules *[]irs.SecurityRuleInfo) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.SecurityHandler.RemoveRules(sgIID, securityRules)
+		return err
+	})
+	return result, err
+}
+
+//================ KeyPair Handler
+
+type retryKeyPairHandler struct {
+	irs.KeyPairHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryKeyPairHandler) CreateKey(keyPairReqInfo irs.KeyPairReqInfo) (info irs.KeyPairInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.KeyPairHandler.CreateKey(keyPairReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryKeyPairHandler) ListKey() (infoList []*irs.KeyPairInfo, err error) {
+	err = h.conn.call(call.VMKEYPAIR, "", "ListKey()", func() error {
+		infoList, err = h.KeyPairHandler.ListKey()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryKeyPairHandler) GetKey(keyIID irs.IID) (info irs.KeyPairInfo, err error) {
+	err = h.conn.call(call.VMKEYPAIR, keyIID.NameId, "GetKey()", func() error {
+		info, err = h.KeyPairHandler.GetKey(keyIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryKeyPairHandler) DeleteKey(keyIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.KeyPairHandler.DeleteKey(keyIID)
+		return err
+	})
+	return result, err
+}
+
+//================ VM Handler
+
+type retryVMHandler struct {
+	irs.VMHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (info irs.VMInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.VMHandler.StartVM(vmReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryVMHandler) SuspendVM(vmIID irs.IID) (status irs.VMStatus, err error) {
+	err = h.conn.callOnce(func() error {
+		status, err = h.VMHandler.SuspendVM(vmIID)
+		return err
+	})
+	return status, err
+}
+
+func (h *retryVMHandler) ResumeVM(vmIID irs.IID) (status irs.VMStatus, err error) {
+	err = h.conn.callOnce(func() error {
+		status, err = h.VMHandler.ResumeVM(vmIID)
+		return err
+	})
+	return status, err
+}
+
+func (h *retryVMHandler) RebootVM(vmIID irs.IID) (status irs.VMStatus, err error) {
+	err = h.conn.callOnce(func() error {
+		status, err = h.VMHandler.RebootVM(vmIID)
+		return err
+	})
+	return status, err
+}
+
+func (h *retryVMHandler) TerminateVM(vmIID irs.IID) (status irs.VMStatus, err error) {
+	err = h.conn.callOnce(func() error {
+		status, err = h.VMHandler.TerminateVM(vmIID)
+		return err
+	})
+	return status, err
+}
+
+func (h *retryVMHandler) ListVMStatus() (infoList []*irs.VMStatusInfo, err error) {
+	err = h.conn.call(call.VM, "", "ListVMStatus()", func() error {
+		infoList, err = h.VMHandler.ListVMStatus()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryVMHandler) GetVMStatus(vmIID irs.IID) (status irs.VMStatus, err error) {
+	err = h.conn.call(call.VM, vmIID.NameId, "GetVMStatus()", func() error {
+		status, err = h.VMHandler.GetVMStatus(vmIID)
+		return err
+	})
+	return status, err
+}
+
+func (h *retryVMHandler) ListVM() (infoList []*irs.VMInfo, err error) {
+	err = h.conn.call(call.VM, "", "ListVM()", func() error {
+		infoList, err = h.VMHandler.ListVM()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryVMHandler) GetVM(vmIID irs.IID) (info irs.VMInfo, err error) {
+	err = h.conn.call(call.VM, vmIID.NameId, "GetVM()", func() error {
+		info, err = h.VMHandler.GetVM(vmIID)
+		return err
+	})
+	return info, err
+}
+
+//================ NLB Handler
+
+type retryNLBHandler struct {
+	irs.NLBHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryNLBHandler) CreateNLB(nlbReqInfo irs.NLBInfo) (info irs.NLBInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.NLBHandler.CreateNLB(nlbReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryNLBHandler) ListNLB() (infoList []*irs.NLBInfo, err error) {
+	err = h.conn.call(call.NLB, "", "ListNLB()", func() error {
+		infoList, err = h.NLBHandler.ListNLB()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryNLBHandler) GetNLB(nlbIID irs.IID) (info irs.NLBInfo, err error) {
+	err = h.conn.call(call.NLB, nlbIID.NameId, "GetNLB()", func() error {
+		info, err = h.NLBHandler.GetNLB(nlbIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryNLBHandler) DeleteNLB(nlbIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.NLBHandler.DeleteNLB(nlbIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryNLBHandler) GetVMGroupHealthInfo(nlbIID irs.IID) (info irs.HealthInfo, err error) {
+	err = h.conn.call(call.NLB, nlbIID.NameId, "GetVMGroupHealthInfo()", func() error {
+		info, err = h.NLBHandler.GetVMGroupHealthInfo(nlbIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryNLBHandler) AddVMs(nlbIID irs.IID, vmIIDs *[]irs.IID) (info irs.VMGroupInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.NLBHandler.AddVMs(nlbIID, vmIIDs)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryNLBHandler) RemoveVMs(nlbIID irs.IID, vmIIDs *[]irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.NLBHandler.RemoveVMs(nlbIID, vmIIDs)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryNLBHandler) ChangeListener(nlbIID irs.IID, listener irs.ListenerInfo) (info irs.ListenerInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.NLBHandler.ChangeListener(nlbIID, listener)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryNLBHandler) ChangeVMGroupInfo(nlbIID irs.IID, vmGroup irs.VMGroupInfo) (info irs.VMGroupInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.NLBHandler.ChangeVMGroupInfo(nlbIID, vmGroup)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryNLBHandler) ChangeHealthCheckerInfo(nlbIID irs.IID, healthChecker irs.HealthCheckerInfo) (info irs.HealthCheckerInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.NLBHandler.ChangeHealthCheckerInfo(nlbIID, healthChecker)
+		return err
+	})
+	return info, err
+}
+
+//================ Disk Handler
+
+type retryDiskHandler struct {
+	irs.DiskHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryDiskHandler) CreateDisk(DiskReqInfo irs.DiskInfo) (info irs.DiskInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.DiskHandler.CreateDisk(DiskReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryDiskHandler) ListDisk() (infoList []*irs.DiskInfo, err error) {
+	err = h.conn.call(call.DISK, "", "ListDisk()", func() error {
+		infoList, err = h.DiskHandler.ListDisk()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryDiskHandler) GetDisk(diskIID irs.IID) (info irs.DiskInfo, err error) {
+	err = h.conn.call(call.DISK, diskIID.NameId, "GetDisk()", func() error {
+		info, err = h.DiskHandler.GetDisk(diskIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryDiskHandler) ChangeDiskSize(diskIID irs.IID, size string) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.DiskHandler.ChangeDiskSize(diskIID, size)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryDiskHandler) DeleteDisk(diskIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.DiskHandler.DeleteDisk(diskIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryDiskHandler) AttachDisk(diskIID irs.IID, ownerVM irs.IID) (info irs.DiskInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.DiskHandler.AttachDisk(diskIID, ownerVM)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryDiskHandler) DetachDisk(diskIID irs.IID, ownerVM irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.DiskHandler.DetachDisk(diskIID, ownerVM)
+		return err
+	})
+	return result, err
+}
+
+//================ MyImage Handler
+
+type retryMyImageHandler struct {
+	irs.MyImageHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryMyImageHandler) SnapshotVM(snapshotReqInfo irs.MyImageInfo) (info irs.MyImageInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.MyImageHandler.SnapshotVM(snapshotReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryMyImageHandler) ListMyImage() (infoList []*irs.MyImageInfo, err error) {
+	err = h.conn.call(call.MYIMAGE, "", "ListMyImage()", func() error {
+		infoList, err = h.MyImageHandler.ListMyImage()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryMyImageHandler) GetMyImage(myImageIID irs.IID) (info irs.MyImageInfo, err error) {
+	err = h.conn.call(call.MYIMAGE, myImageIID.NameId, "GetMyImage()", func() error {
+		info, err = h.MyImageHandler.GetMyImage(myImageIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryMyImageHandler) CheckWindowsImage(myImageIID irs.IID) (result bool, err error) {
+	err = h.conn.call(call.MYIMAGE, myImageIID.NameId, "CheckWindowsImage()", func() error {
+		result, err = h.MyImageHandler.CheckWindowsImage(myImageIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryMyImageHandler) DeleteMyImage(myImageIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.MyImageHandler.DeleteMyImage(myImageIID)
+		return err
+	})
+	return result, err
+}
+
+//================ Cluster Handler
+
+type retryClusterHandler struct {
+	irs.ClusterHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryClusterHandler) CreateCluster(clusterReqInfo irs.ClusterInfo) (info irs.ClusterInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.ClusterHandler.CreateCluster(clusterReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryClusterHandler) ListCluster() (infoList []*irs.ClusterInfo, err error) {
+	err = h.conn.call(call.CLUSTER, "", "ListCluster()", func() error {
+		infoList, err = h.ClusterHandler.ListCluster()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryClusterHandler) GetCluster(clusterIID irs.IID) (info irs.ClusterInfo, err error) {
+	err = h.conn.call(call.CLUSTER, clusterIID.NameId, "GetCluster()", func() error {
+		info, err = h.ClusterHandler.GetCluster(clusterIID)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryClusterHandler) DeleteCluster(clusterIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.ClusterHandler.DeleteCluster(clusterIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryClusterHandler) AddNodeGroup(clusterIID irs.IID, nodeGroupReqInfo irs.NodeGroupInfo) (info irs.NodeGroupInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.ClusterHandler.AddNodeGroup(clusterIID, nodeGroupReqInfo)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryClusterHandler) SetNodeGroupAutoScaling(clusterIID irs.IID, nodeGroupIID irs.IID, on bool) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.ClusterHandler.SetNodeGroupAutoScaling(clusterIID, nodeGroupIID, on)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryClusterHandler) ChangeNodeGroupScaling(clusterIID irs.IID, nodeGroupIID irs.IID,
+	DesiredNodeSize int, MinNodeSize int, MaxNodeSize int) (info irs.NodeGroupInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.ClusterHandler.ChangeNodeGroupScaling(clusterIID, nodeGroupIID, DesiredNodeSize, MinNodeSize, MaxNodeSize)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryClusterHandler) RemoveNodeGroup(clusterIID irs.IID, nodeGroupIID irs.IID) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.ClusterHandler.RemoveNodeGroup(clusterIID, nodeGroupIID)
+		return err
+	})
+	return result, err
+}
+
+func (h *retryClusterHandler) UpgradeCluster(clusterIID irs.IID, newVersion string) (info irs.ClusterInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.ClusterHandler.UpgradeCluster(clusterIID, newVersion)
+		return err
+	})
+	return info, err
+}
+
+//================ AnyCall Handler
+
+type retryAnyCallHandler struct {
+	irs.AnyCallHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryAnyCallHandler) AnyCall(callInfo irs.AnyCallInfo) (info irs.AnyCallInfo, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.AnyCallHandler.AnyCall(callInfo)
+		return err
+	})
+	return info, err
+}
+
+//================ Tag Handler
+
+type retryTagHandler struct {
+	irs.TagHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryTagHandler) AddTag(resType irs.RSType, resIID irs.IID, tag irs.KeyValue) (info irs.KeyValue, err error) {
+	err = h.conn.callOnce(func() error {
+		info, err = h.TagHandler.AddTag(resType, resIID, tag)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryTagHandler) ListTag(resType irs.RSType, resIID irs.IID) (tagList []irs.KeyValue, err error) {
+	err = h.conn.call(call.RES_TYPE(resType), resIID.NameId, "ListTag()", func() error {
+		tagList, err = h.TagHandler.ListTag(resType, resIID)
+		return err
+	})
+	return tagList, err
+}
+
+func (h *retryTagHandler) RemoveTag(resType irs.RSType, resIID irs.IID, key string) (result bool, err error) {
+	err = h.conn.callOnce(func() error {
+		result, err = h.TagHandler.RemoveTag(resType, resIID, key)
+		return err
+	})
+	return result, err
+}
+
+//================ PriceInfo Handler
+
+type retryPriceInfoHandler struct {
+	irs.PriceInfoHandler
+	conn *retryCloudConnection
+}
+
+func (h *retryPriceInfoHandler) ListVMSpecPrice() (infoList []*irs.PriceInfo, err error) {
+	err = h.conn.call(call.VMSPEC, "", "ListVMSpecPrice()", func() error {
+		infoList, err = h.PriceInfoHandler.ListVMSpecPrice()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryPriceInfoHandler) GetVMSpecPrice(Name string) (info irs.PriceInfo, err error) {
+	err = h.conn.call(call.VMSPEC, Name, "GetVMSpecPrice()", func() error {
+		info, err = h.PriceInfoHandler.GetVMSpecPrice(Name)
+		return err
+	})
+	return info, err
+}
+
+func (h *retryPriceInfoHandler) ListDiskPrice() (infoList []*irs.PriceInfo, err error) {
+	err = h.conn.call(call.DISK, "", "ListDiskPrice()", func() error {
+		infoList, err = h.PriceInfoHandler.ListDiskPrice()
+		return err
+	})
+	return infoList, err
+}
+
+func (h *retryPriceInfoHandler) GetDiskPrice(DiskType string) (info irs.PriceInfo, err error) {
+	err = h.conn.call(call.DISK, DiskType, "GetDiskPrice()", func() error {
+		info, err = h.PriceInfoHandler.GetDiskPrice(DiskType)
+		return err
+	})
+	return info, err
+}
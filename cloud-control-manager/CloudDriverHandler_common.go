@@ -17,6 +17,7 @@ import (
 	rim "github.com/cloud-barista/cb-spider/cloud-info-manager/region-info-manager"
 	icbs "github.com/cloud-barista/cb-store/interfaces"
 
+	"context"
 	"fmt"
 	"strings"
 )
@@ -46,33 +47,61 @@ func GetCloudDriver(cloudConnectName string) (idrv.CloudDriver, error) {
 	return getCloudDriver(*cldDrvInfo)
 }
 
+// GetCloudConnection is GetCloudConnectionWithContext() without the context of a request.
+func GetCloudConnection(cloudConnectName string) (icon.CloudConnection, error) {
+	return GetCloudConnectionWithContext(context.Background(), cloudConnectName)
+}
+
+// 1. get the pooled CloudConnection
+// 2. get a new CloudConnection and pool it
+// The records of the retried calls get the RequestID of ctx.
+// The caller should Close() the returned connection after the calls to release it,
+// the pooled connection of the driver is closed after it is removed from the pool and released by all callers.
+func GetCloudConnectionWithContext(ctx context.Context, cloudConnectName string) (icon.CloudConnection, error) {
+	cache := getConnectionCache()
+	if cldConnection, providerName := cache.get(cloudConnectName); cldConnection != nil {
+		return newRetryCloudConnection(ctx, cloudConnectName, providerName, cldConnection), nil
+	}
+
+	generation := cache.currentGeneration()
+	cccInfo, driverConnection, err := newCloudConnection(cloudConnectName)
+	if err != nil {
+		return nil, err
+	}
+	cldConnection := newSharedConnection(driverConnection)
+	cache.put(cloudConnectName, cccInfo, cldConnection, generation)
+
+	// the calls of the handlers are rate-limited, and the read-only calls are retried on the throttling errors.
+	return newRetryCloudConnection(ctx, cloudConnectName, cccInfo.ProviderName, cldConnection), nil
+}
+
 // 1. get credential info
 // 2. get region info
 // 3. get CloudConneciton
-func GetCloudConnection(cloudConnectName string) (icon.CloudConnection, error) {
+func newCloudConnection(cloudConnectName string) (*ccim.ConnectionConfigInfo, icon.CloudConnection, error) {
 	cccInfo, err := ccim.GetConnectionConfig(cloudConnectName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	cldDriver, err := GetCloudDriver(cloudConnectName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	crdInfo, err := cim.GetCredentialDecrypt(cccInfo.CredentialName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	rgnInfo, err := rim.GetRegion(cccInfo.RegionName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	regionName, zoneName, err := getRegionNameByRegionInfo(rgnInfo)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	connectionInfo := idrv.ConnectionInfo{ // @todo powerkim
@@ -105,10 +134,10 @@ func GetCloudConnection(cloudConnectName string) (icon.CloudConnection, error) {
 
 	cldConnection, err := cldDriver.ConnectCloud(connectionInfo)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return cldConnection, nil
+	return cccInfo, cldConnection, nil
 }
 
 func GetProviderNameByConnectionName(cloudConnectName string) (string, error) {
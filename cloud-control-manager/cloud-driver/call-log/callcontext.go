@@ -0,0 +1,36 @@
+// Call-Log: calling logger of Cloud & VM in CB-Spider
+//           Referred to cb-log
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//      * CB-Spider: https://github.com/cloud-barista/cb-spider
+//      * cb-log: https://github.com/cloud-barista/cb-log
+//
+// context of the calls(RequestID) for the records of the calls
+//
+// by CB-Spider Team, 2022.10.
+
+package calllog
+
+import (
+	"context"
+)
+
+// The RequestID of a request is carried by the context.Context of the request,
+// and the callers with the context leave it in their records by Fields(connectionName, RequestID(ctx)).
+// The drivers log their calls without the context, so their records do not have it.
+
+type requestIDKey struct{}
+
+// The request ID is left in the call-logs of the request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID of the context, "" if it is not set.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -15,16 +15,19 @@
 package calllog
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/chyeh/pubip"
 	calllogformatter "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log/formatter"
+	configwatcher "github.com/cloud-barista/cb-spider/cloud-control-manager/config-watcher"
 	"github.com/sirupsen/logrus"
-	"github.com/snowzach/rotatefilehook"
+	"github.com/natefinch/lumberjack"
 )
 
 type CLOUD_OS string
@@ -72,8 +75,9 @@ type CALLLogger struct {
 var (
 	HostIPorName  string
 	callLogger    *CALLLogger
-	callFormatter *calllogformatter.Formatter
+	callFormatter logrus.Formatter
 	calllogConfig CALLLOGCONFIG
+	configLock    sync.RWMutex // for calllogConfig and callFormatter
 )
 
 func init() {
@@ -101,10 +105,9 @@ func GetLogger(loggerName string) *logrus.Logger {
 	callLogger = new(CALLLogger)
 	callLogger.loggerName = loggerName
 	callLogger.logrus = &logrus.Logger{
-		Level:     logrus.InfoLevel,
-		Out:       os.Stderr,
-		Hooks:     make(logrus.LevelHooks),
-		Formatter: getFormatter(loggerName),
+		Level: logrus.InfoLevel,
+		Out:   os.Stderr,
+		Hooks: make(logrus.LevelHooks),
 	}
 
 	// set config.
@@ -113,57 +116,106 @@ func GetLogger(loggerName string) *logrus.Logger {
 }
 
 func setup(loggerName string) {
-	calllogConfig = GetConfigInfos()
 	callLogger.logrus.SetReportCaller(true)
+	applyConfig(loggerName, GetConfigInfos())
 
-	if calllogConfig.CALLLOG.LOOPCHECK {
-		SetLevel(calllogConfig.CALLLOG.LOGLEVEL)
-		go levelSetupLoop(loggerName)
-	} else {
-		SetLevel(calllogConfig.CALLLOG.LOGLEVEL)
+	// hot reload: the changes of the config file are applied without restart.
+	_, err := configwatcher.Watch(GetConfigFileName(), func() {
+		reloadConfig(loggerName)
+	})
+	if err != nil {
+		logrus.Errorf("Failed to watch the calllog config file: %v", err)
 	}
+}
 
-	if calllogConfig.CALLLOG.LOGFILE {
-		setRotateFileHook(loggerName, &calllogConfig)
+func reloadConfig(loggerName string) {
+	logConfig, err := readConfigInfos(GetConfigFileName())
+	if err != nil {
+		logrus.Errorf("Failed to reload the calllog config file, the current config is kept: %v", err)
+		return
 	}
+	logrus.Infof("The calllog config file is reloaded: level=%s, logfile=%v, format=%s",
+		logConfig.CALLLOG.LOGLEVEL, logConfig.CALLLOG.LOGFILE, logConfig.CALLLOG.LOGFORMAT)
+	applyConfig(loggerName, logConfig)
 }
 
-// Now, this method is busy wait.
-// @TODO must change this  with file watch&event.
-// ref) https://github.com/fsnotify/fsnotify/blob/master/example_test.go
-func levelSetupLoop(loggerName string) {
-	for {
-		calllogConfig = GetConfigInfos()
-		SetLevel(calllogConfig.CALLLOG.LOGLEVEL)
-		time.Sleep(time.Second * 2)
+// set the level, the format and the log file of the config.
+func applyConfig(loggerName string, logConfig CALLLOGCONFIG) {
+	configLock.Lock()
+	oldConfig := calllogConfig
+	calllogConfig = logConfig
+	formatChanged := callFormatter == nil || !strings.EqualFold(oldConfig.CALLLOG.LOGFORMAT, logConfig.CALLLOG.LOGFORMAT)
+	if formatChanged {
+		callFormatter = nil
 	}
+	configLock.Unlock()
+
+	err := SetLevel(logConfig.CALLLOG.LOGLEVEL)
+	if err != nil {
+		logrus.Errorf("Failed to set log level: %v", err)
+	}
+
+	if !formatChanged && oldConfig.CALLLOG.LOGFILE == logConfig.CALLLOG.LOGFILE &&
+		oldConfig.LOGFILEINFO == logConfig.LOGFILEINFO {
+		return
+	}
+
+	formatter := getFormatter(loggerName)
+	callLogger.logrus.SetFormatter(formatter)
+
+	hooks := make(logrus.LevelHooks)
+	if logConfig.CALLLOG.LOGFILE {
+		hooks.Add(newRotateFileHook(&logConfig, formatter))
+	}
+	oldHooks := callLogger.logrus.ReplaceHooks(hooks)
+	for _, hook := range oldHooks[logrus.InfoLevel] {
+		if fileHook, ok := hook.(*rotateFileHook); ok {
+			fileHook.logWriter.Close()
+		}
+	}
+}
+
+// rotating log file of the call-log, it can be closed to change the file at runtime.
+type rotateFileHook struct {
+	logWriter *lumberjack.Logger
+	formatter logrus.Formatter
 }
 
-func setRotateFileHook(loggerName string, logConfig *CALLLOGCONFIG) {
-	level, _ := logrus.ParseLevel(logConfig.CALLLOG.LOGLEVEL)
+func newRotateFileHook(logConfig *CALLLOGCONFIG, formatter logrus.Formatter) *rotateFileHook {
+	return &rotateFileHook{
+		logWriter: &lumberjack.Logger{
+			Filename:   logConfig.LOGFILEINFO.FILENAME,
+			MaxSize:    logConfig.LOGFILEINFO.MAXSIZE, // megabytes
+			MaxBackups: logConfig.LOGFILEINFO.MAXBACKUPS,
+			MaxAge:     logConfig.LOGFILEINFO.MAXAGE, //days
+		},
+		formatter: formatter,
+	}
+}
 
-	rotateFileHook, err := rotatefilehook.NewRotateFileHook(rotatefilehook.RotateFileConfig{
-		Filename:   logConfig.LOGFILEINFO.FILENAME,
-		MaxSize:    logConfig.LOGFILEINFO.MAXSIZE, // megabytes
-		MaxBackups: logConfig.LOGFILEINFO.MAXBACKUPS,
-		MaxAge:     logConfig.LOGFILEINFO.MAXAGE, //days
-		Level:      level,
-		Formatter:  getFormatter(loggerName),
-	})
+// The level is filtered by the logger, so all levels are written.
+func (hook *rotateFileHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
 
+func (hook *rotateFileHook) Fire(entry *logrus.Entry) error {
+	data, err := hook.formatter.Format(entry)
 	if err != nil {
-		logrus.Fatalf("Failed to initialize file rotate hook: %v", err)
+		return err
 	}
-	callLogger.logrus.AddHook(rotateFileHook)
+	_, err = hook.logWriter.Write(data)
+	return err
 }
 
-func SetLevel(strLevel string) {
+// The level set at runtime is kept until the config file is changed.
+func SetLevel(strLevel string) error {
 	err := checkLevel(strLevel)
 	if err != nil {
-		logrus.Errorf("Failed to set log level: %v", err)
+		return err
 	}
 	level, _ := logrus.ParseLevel(strLevel)
 	callLogger.logrus.SetLevel(level)
+	return nil
 }
 
 func checkLevel(lvl string) error {
@@ -180,11 +232,17 @@ func GetLevel() string {
 	return callLogger.logrus.GetLevel().String()
 }
 
-func getFormatter(loggerName string) *calllogformatter.Formatter {
+func getFormatter(loggerName string) logrus.Formatter {
+	configLock.Lock()
+	defer configLock.Unlock()
 
 	if callFormatter != nil {
 		return callFormatter
 	}
+	if strings.EqualFold(calllogConfig.CALLLOG.LOGFORMAT, JSONFORMAT) {
+		callFormatter = &jsonFormatter{loggerName: loggerName}
+		return callFormatter
+	}
 	callFormatter = &calllogformatter.Formatter{
 		TimestampFormat: "2006-01-02 15:04:05",
 		LogFormat:       "[" + loggerName + "].[" + HostIPorName + "] %time% (%weekday%) %func% - %msg%\n",
@@ -192,6 +250,22 @@ func getFormatter(loggerName string) *calllogformatter.Formatter {
 	return callFormatter
 }
 
+// text | json
+func isJSONFormat() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	return strings.EqualFold(calllogConfig.CALLLOG.LOGFORMAT, JSONFORMAT)
+}
+
+// the current config, it can be changed at runtime.
+func getConfig() CALLLOGCONFIG {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	return calllogConfig
+}
+
 //=========================
 type CLOUDLOGSCHEMA struct {
 	CloudOS      CLOUD_OS // ex) AWS | AZURE | ALIBABA | GCP | OPENSTACK | CLOUDTWIN | CLOUDIT | DOCKER | NCP | MOCK | IBM
@@ -208,6 +282,24 @@ type VMLOGSCHEMA struct {
 }
 */
 
+// The observer of the calls, ex) metrics exporter.
+// It is set by the upper layer, so the drivers do not depend on it.
+// The calls are observed by String() before the log level filter, so all calls are observed at any log level.
+var cloudLogObserver func(CLOUDLOGSCHEMA)
+var observerLock sync.RWMutex
+
+func SetCloudLogObserver(observer func(CLOUDLOGSCHEMA)) {
+	observerLock.Lock()
+	defer observerLock.Unlock()
+	cloudLogObserver = observer
+}
+
+func getCloudLogObserver() func(CLOUDLOGSCHEMA) {
+	observerLock.RLock()
+	defer observerLock.RUnlock()
+	return cloudLogObserver
+}
+
 func Start() time.Time {
 	return time.Now()
 }
@@ -217,6 +309,21 @@ func Elapsed(start time.Time) string {
 }
 
 func String(logInfo interface{}) string {
+	if callInfo, ok := logInfo.(CLOUDLOGSCHEMA); ok {
+		if observer := getCloudLogObserver(); observer != nil {
+			observer(callInfo)
+		}
+	}
+
+	// JSON Lines format: the message is a real JSON object.
+	if isJSONFormat() {
+		data, err := json.Marshal(logInfo)
+		if err == nil {
+			return string(data)
+		}
+		logrus.Error(err)
+	}
+
 	t := reflect.TypeOf(logInfo)
 	v := reflect.ValueOf(logInfo)
 
@@ -0,0 +1,307 @@
+// Call-Log: calling logger of Cloud & VM in CB-Spider
+//           Referred to cb-log
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//      * CB-Spider: https://github.com/cloud-barista/cb-spider
+//      * cb-log: https://github.com/cloud-barista/cb-log
+//
+// JSON Lines format and reader of the call-log files
+//
+// by CB-Spider Team, 2022.10.
+
+package calllog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TEXTFORMAT = "text"
+	JSONFORMAT = "json"
+
+	// keys of the logrus fields with the context of a call
+	// ex) callogger.WithFields(call.Fields(connectionName, requestID)).Info(call.String(callInfo))
+	CONNECTIONNAME = "ConnectionName"
+	REQUESTID      = "RequestID"
+	RETRYCOUNT     = "RetryCount" // only for the retried calls
+
+	textTimestampFormat = "2006-01-02 15:04:05"
+)
+
+// A call record of the JSON Lines format, and the result of ListCallLog().
+// ConnectionName and RequestID are "" if the caller(ex. a driver) does not know them.
+type CALLLOGRECORD struct {
+	Timestamp      time.Time
+	Host           string
+	RequestID      string
+	ConnectionName string
+	RetryCount     int `json:",omitempty"` // the number of retries of a throttled call
+	CLOUDLOGSCHEMA
+	Message string `json:",omitempty"` // only for a message of another schema
+}
+
+// the context of a call, ConnectionName and RequestID
+func Fields(connectionName string, requestID string) logrus.Fields {
+	return logrus.Fields{CONNECTIONNAME: connectionName, REQUESTID: requestID}
+}
+
+// the context of a retried call, with the number of retries
+func RetryFields(connectionName string, requestID string, retryCount int) logrus.Fields {
+	fields := Fields(connectionName, requestID)
+	fields[RETRYCOUNT] = retryCount
+	return fields
+}
+
+//=========================
+// JSON Lines formatter, one call record per line.
+// ex) {"Timestamp":"2022-10-17T10:00:00.1+09:00","Host":"127.0.0.1","RequestID":"cd8...","ConnectionName":"aws-ohio-config",
+//      "CloudOS":"AWS","RegionZone":"us-east-2/us-east-2a","ResourceType":"VM","ResourceName":"vm-01",
+//      "CloudOSAPI":"RunInstances()","ElapsedTime":"1.2345","ErrorMSG":""}
+type jsonFormatter struct {
+	loggerName string
+}
+
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	record := CALLLOGRECORD{Timestamp: entry.Time, Host: HostIPorName}
+	record.ConnectionName, _ = entry.Data[CONNECTIONNAME].(string)
+	record.RequestID, _ = entry.Data[REQUESTID].(string)
+	record.RetryCount, _ = entry.Data[RETRYCOUNT].(int)
+
+	if !parseMessage(entry.Message, &record) {
+		record.Message = entry.Message
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// parse a message of String(), JSON or text.
+func parseMessage(msg string, record *CALLLOGRECORD) bool {
+	if strings.HasPrefix(msg, "{") {
+		return json.Unmarshal([]byte(msg), &record.CLOUDLOGSCHEMA) == nil
+	}
+	return parseTextMessage(msg, record)
+}
+
+// field names of CLOUDLOGSCHEMA in the order of String()
+var cloudLogFieldNames = func() []string {
+	names := []string{}
+	t := reflect.TypeOf(CLOUDLOGSCHEMA{})
+	for idx := 0; idx < t.NumField(); idx++ {
+		names = append(names, t.Field(idx).Name)
+	}
+	return names
+}()
+
+// ex) "CloudOS" : "AWS", "RegionZone" : "us-east1/us-east1-c", ..., "ErrorMSG" : ""
+// A value can have quotes(ex. ErrorMSG), so each value ends at the next field name.
+func parseTextMessage(msg string, record *CALLLOGRECORD) bool {
+	schema := reflect.ValueOf(&record.CLOUDLOGSCHEMA).Elem()
+	rest := msg
+	for idx, name := range cloudLogFieldNames {
+		prefix := "\"" + name + "\" : \""
+		if !strings.HasPrefix(rest, prefix) {
+			return false
+		}
+		rest = rest[len(prefix):]
+
+		value := strings.TrimSuffix(rest, "\"")
+		if idx < len(cloudLogFieldNames)-1 {
+			end := strings.Index(rest, "\", \""+cloudLogFieldNames[idx+1]+"\" : \"")
+			if end < 0 {
+				return false
+			}
+			value = rest[:end]
+			rest = rest[end+len("\", "):]
+		}
+		schema.FieldByName(name).SetString(value)
+	}
+	return true
+}
+
+// parse a line of the call-log file, JSON or text.
+// text ex) [HISCALL].[127.0.0.1] 2022-10-17 10:00:00 (Monday) pkg.ListVPC():123 - "CloudOS" : "AWS", ...
+func parseLine(line string) (*CALLLOGRECORD, bool) {
+	record := CALLLOGRECORD{}
+	if strings.HasPrefix(line, "{") {
+		if json.Unmarshal([]byte(line), &record) != nil {
+			return nil, false
+		}
+		return &record, true
+	}
+
+	hostStart := strings.Index(line, "].[")
+	hostEnd := strings.Index(line, "] ")
+	msgStart := strings.Index(line, " - ")
+	if hostStart < 0 || hostEnd < hostStart || msgStart < 0 || len(line) < hostEnd+2+len(textTimestampFormat) {
+		return nil, false
+	}
+	record.Host = line[hostStart+len("].[") : hostEnd]
+	timestamp, err := time.ParseInLocation(textTimestampFormat, line[hostEnd+2:hostEnd+2+len(textTimestampFormat)], time.Local)
+	if err != nil {
+		return nil, false
+	}
+	record.Timestamp = timestamp
+	if !parseTextMessage(line[msgStart+len(" - "):], &record) {
+		return nil, false
+	}
+	return &record, true
+}
+
+//=========================
+// Reader of the call-log files
+
+type CALLLOGFILTER struct {
+	// The records of the connection.
+	// The driver records without ConnectionName are matched by CloudOS and RegionName of the connection.
+	ConnectionName string
+	CloudOS        CLOUD_OS
+	RegionName     string
+
+	ResourceType RES_TYPE  // "" for all
+	Since        time.Time // zero for all
+	ErrorOnly    bool
+}
+
+func (filter *CALLLOGFILTER) match(record *CALLLOGRECORD) bool {
+	if filter.ConnectionName != "" {
+		if record.ConnectionName != "" {
+			if record.ConnectionName != filter.ConnectionName {
+				return false
+			}
+		} else {
+			if filter.CloudOS == "" || !strings.EqualFold(string(record.CloudOS), string(filter.CloudOS)) {
+				return false
+			}
+			// ex) "us-east1" or "us-east1/us-east1-c"
+			if filter.RegionName != "" && record.RegionZone != filter.RegionName &&
+				!strings.HasPrefix(record.RegionZone, filter.RegionName+"/") {
+				return false
+			}
+		}
+	}
+	if filter.ResourceType != "" && !strings.EqualFold(string(record.ResourceType), string(filter.ResourceType)) {
+		return false
+	}
+	if !filter.Since.IsZero() && record.Timestamp.Before(filter.Since) {
+		return false
+	}
+	if filter.ErrorOnly && record.ErrorMSG == "" {
+		return false
+	}
+	return true
+}
+
+// the current file and the rotated files, the newest first.
+// ex) calllogs.log, calllogs-2022-10-17T10-00-00.000.log, calllogs-2022-10-16T09-00-00.000.log
+func getLogFileList() ([]string, error) {
+	fileName := getConfig().LOGFILEINFO.FILENAME
+	if fileName == "" {
+		fileName = GetConfigInfos().LOGFILEINFO.FILENAME
+	}
+	ext := filepath.Ext(fileName)
+	backupList, err := filepath.Glob(strings.TrimSuffix(fileName, ext) + "-*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	// the timestamp of the rotated files is sortable.
+	sort.Sort(sort.Reverse(sort.StringSlice(backupList)))
+
+	fileList := []string{}
+	if _, err := os.Stat(fileName); err == nil {
+		fileList = append(fileList, fileName)
+	}
+	return append(fileList, backupList...), nil
+}
+
+// List the call records matched with the filter, the newest first.
+// It returns the records from offset to offset+limit(limit <= 0: all), and the total count of the matched records.
+// The files are read line by line, and only the records in the range are kept.
+func ListCallLog(filter CALLLOGFILTER, offset int, limit int) ([]*CALLLOGRECORD, int, error) {
+	fileList, err := getLogFileList()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	recordList := []*CALLLOGRECORD{}
+	total := 0
+	for _, fileName := range fileList {
+		// all records of this file and of the older files are written before Since.
+		if fileInfo, err := os.Stat(fileName); err == nil && !filter.Since.IsZero() && fileInfo.ModTime().Before(filter.Since) {
+			break
+		}
+
+		// the number of the newest records of this file in the range, -1: all
+		keepCount := -1
+		if limit > 0 {
+			keepCount = offset + limit - total
+			if keepCount < 0 {
+				keepCount = 0
+			}
+		}
+		fileRecordList, count, err := readLogFile(fileName, &filter, keepCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		// fileRecordList: the newest records of this file, the oldest first
+		for idx := len(fileRecordList) - 1; idx >= 0; idx-- {
+			position := total + (len(fileRecordList) - 1 - idx)
+			if position >= offset && (limit <= 0 || position < offset+limit) {
+				recordList = append(recordList, fileRecordList[idx])
+			}
+		}
+		total += count
+	}
+	return recordList, total, nil
+}
+
+// the newest keepCount(-1: all) matched records of a file, the oldest first, and the count of all matched records.
+func readLogFile(fileName string, filter *CALLLOGFILTER, keepCount int) ([]*CALLLOGRECORD, int, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		if os.IsNotExist(err) { // rotated while reading
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	// a ring buffer of the last keepCount records, head is the index of the oldest record.
+	recordList := []*CALLLOGRECORD{}
+	head := 0
+	count := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		record, ok := parseLine(scanner.Text())
+		if !ok || !filter.match(record) {
+			continue
+		}
+		count++
+		if keepCount == 0 {
+			continue
+		}
+		if keepCount > 0 && len(recordList) == keepCount {
+			recordList[head] = record
+			head = (head + 1) % keepCount
+			continue
+		}
+		recordList = append(recordList, record)
+	}
+	if head > 0 {
+		recordList = append(append([]*CALLLOGRECORD{}, recordList[head:]...), recordList[:head]...)
+	}
+	return recordList, count, scanner.Err()
+}
@@ -26,9 +26,9 @@ import (
 
 type CALLLOGCONFIG struct {
         CALLLOG struct {
-                LOOPCHECK bool
                 LOGLEVEL string
                 LOGFILE bool
+                LOGFORMAT string // text | json, default: text
         }
 
         LOGFILEINFO struct {
@@ -45,25 +45,38 @@ func load(filePath string) ([]byte, error) {
 }
 
 func GetConfigInfos() CALLLOGCONFIG {
+        configInfos, err := readConfigInfos(GetConfigFileName())
+        if err != nil {
+                log.Fatalf("error: %v", err)
+        }
+	return configInfos
+}
+
+// $CBSPIDER_ROOT/conf/calllog_conf.yaml
+func GetConfigFileName() string {
         calllogRootPath := os.Getenv("CBSPIDER_ROOT")
         if calllogRootPath == "" {
                 log.Fatalf("$CBSPIDER_ROOT is not set!!")
                 os.Exit(1)
         }
-        data, err := load(calllogRootPath + "/conf/calllog_conf.yaml")
+        return calllogRootPath + "/conf/calllog_conf.yaml"
+}
 
+// It does not stop the server for the wrong config file modified at runtime.
+func readConfigInfos(fileName string) (CALLLOGCONFIG, error) {
+        configInfos := CALLLOGCONFIG{}
+        data, err := load(fileName)
         if err != nil {
-                log.Fatalf("error: %v", err)
+                return configInfos, err
         }
 
-        configInfos := CALLLOGCONFIG{}
         err = yaml.Unmarshal([]byte(data), &configInfos)
         if err != nil {
-                log.Fatalf("error: %v", err)
+                return configInfos, err
         }
 
 	configInfos.LOGFILEINFO.FILENAME = ReplaceEnvPath(configInfos.LOGFILEINFO.FILENAME)
-	return configInfos
+	return configInfos, nil
 }
 
 // $ABC/def ==> /abc/def
@@ -0,0 +1,188 @@
+// Call-Log: calling logger of Cloud & VM in CB-Spider
+//           Referred to cb-log
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//      * CB-Spider: https://github.com/cloud-barista/cb-spider
+//      * cb-log: https://github.com/cloud-barista/cb-log
+//
+// test of the call-log reader
+//
+// by CB-Spider Team, 2022.10.
+
+package main
+
+import (
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestListCallLog(t *testing.T) {
+	callogger := call.GetLogger("HISCALL")
+
+	start := time.Now().Add(-time.Second)
+	info := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.MOCK,
+		RegionZone:   "mock-region01/mock-zone01",
+		ResourceType: call.VM,
+		ResourceName: "calllog-test-vm01",
+		CloudOSAPI:   "StartVM()",
+		ElapsedTime:  "0.0100",
+		ErrorMSG:     `"calllog-test-vm01" : "already exists", "code" : "409"`,
+	}
+	callogger.WithFields(call.Fields("mock-config01", "calllog-test-request01")).Info(call.String(info))
+
+	filter := call.CALLLOGFILTER{
+		ConnectionName: "mock-config01",
+		CloudOS:        call.MOCK,
+		RegionName:     "mock-region01",
+		ResourceType:   call.VM,
+		Since:          start,
+		ErrorOnly:      true,
+	}
+	recordList, total, err := call.ListCallLog(filter, 0, 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if total < 1 || len(recordList) != 1 {
+		t.Fatalf("The logged call does not exist. total: %d, len: %d", total, len(recordList))
+	}
+	if recordList[0].CLOUDLOGSCHEMA != info {
+		t.Errorf("The logged call is not same. logged: %#v, read: %#v", info, recordList[0].CLOUDLOGSCHEMA)
+	}
+
+	// the other connection of the other region
+	filter.ConnectionName = "mock-config02"
+	filter.RegionName = "mock-region02"
+	recordList, _, err = call.ListCallLog(filter, 0, 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, record := range recordList {
+		if record.ResourceName == info.ResourceName {
+			t.Errorf("The call of mock-config01 is listed with mock-config02.")
+		}
+	}
+}
+
+func TestCloudLogObserver(t *testing.T) {
+	callogger := call.GetLogger("HISCALL")
+
+	observedList := []call.CLOUDLOGSCHEMA{}
+	call.SetCloudLogObserver(func(callInfo call.CLOUDLOGSCHEMA) {
+		observedList = append(observedList, callInfo)
+	})
+	defer call.SetCloudLogObserver(nil)
+
+	info := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.MOCK,
+		RegionZone:   "mock-region01/mock-zone01",
+		ResourceType: call.VM,
+		ResourceName: "calllog-test-vm02",
+		CloudOSAPI:   "GetVM()",
+		ElapsedTime:  "0.0100",
+		ErrorMSG:     "",
+	}
+
+	callogger.Info(call.String(info))
+	if len(observedList) != 1 || observedList[0] != info {
+		t.Errorf("The logged call is not observed: %#v", observedList)
+	}
+
+	// the calls filtered out by the log level are observed too
+	level := call.GetLevel()
+	if err := call.SetLevel("error"); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer call.SetLevel(level)
+	callogger.Info(call.String(info))
+	if len(observedList) != 2 || observedList[1] != info {
+		t.Errorf("The call filtered out by the log level is not observed: %#v", observedList)
+	}
+}
+
+// keeps the fields of the logged records
+type captureHook struct {
+	dataList []logrus.Fields
+}
+
+func (hook *captureHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (hook *captureHook) Fire(entry *logrus.Entry) error {
+	hook.dataList = append(hook.dataList, entry.Data)
+	return nil
+}
+
+func TestCallContext(t *testing.T) {
+	callogger := call.GetLogger("HISCALL")
+	hook := &captureHook{}
+	callogger.AddHook(hook)
+
+	info := call.CLOUDLOGSCHEMA{
+		CloudOS:      call.MOCK,
+		RegionZone:   "mock-region03/mock-zone01",
+		ResourceType: call.VPCSUBNET,
+		ResourceName: "calllog-test-vpc01",
+		CloudOSAPI:   "CreateVPC()",
+		ElapsedTime:  "0.0100",
+		ErrorMSG:     "",
+	}
+
+	if requestID := call.RequestID(context.Background()); requestID != "" {
+		t.Errorf("The context without the request ID has %s", requestID)
+	}
+
+	// the context is passed to another goroutine of the request
+	ctx := call.WithRequestID(context.Background(), "calllog-test-request02")
+	done := make(chan bool)
+	go func() {
+		callogger.WithFields(call.Fields("mock-config03", call.RequestID(ctx))).Info(call.String(info))
+		close(done)
+	}()
+	<-done
+
+	if len(hook.dataList) != 1 {
+		t.Fatalf("The number of the logged records is %d, not 1.", len(hook.dataList))
+	}
+	if hook.dataList[0][call.CONNECTIONNAME] != "mock-config03" || hook.dataList[0][call.REQUESTID] != "calllog-test-request02" {
+		t.Errorf("The record does not get the context: %v", hook.dataList[0])
+	}
+}
+
+func TestListCallLogRange(t *testing.T) {
+	callogger := call.GetLogger("HISCALL")
+
+	start := time.Now().Add(-time.Second)
+	for idx := 0; idx < 5; idx++ {
+		info := call.CLOUDLOGSCHEMA{
+			CloudOS:      call.MOCK,
+			RegionZone:   "mock-region04/mock-zone01",
+			ResourceType: call.DISK,
+			ResourceName: fmt.Sprintf("calllog-test-disk%02d", idx),
+			CloudOSAPI:   "CreateDisk()",
+			ElapsedTime:  "0.0100",
+			ErrorMSG:     "",
+		}
+		callogger.WithFields(call.Fields("mock-config04", "")).Info(call.String(info))
+	}
+
+	// the newest first: disk04, disk03, ..., disk00
+	filter := call.CALLLOGFILTER{ConnectionName: "mock-config04", CloudOS: call.MOCK, RegionName: "mock-region04", ResourceType: call.DISK, Since: start}
+	recordList, total, err := call.ListCallLog(filter, 1, 2)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if total != 5 || len(recordList) != 2 {
+		t.Fatalf("total: %d, len: %d, want 5, 2", total, len(recordList))
+	}
+	if recordList[0].ResourceName != "calllog-test-disk03" || recordList[1].ResourceName != "calllog-test-disk02" {
+		t.Errorf("The records are not in the range: %s, %s", recordList[0].ResourceName, recordList[1].ResourceName)
+	}
+}
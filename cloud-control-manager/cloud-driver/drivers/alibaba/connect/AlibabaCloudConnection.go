@@ -151,3 +151,10 @@ func (cloudConn *AlibabaCloudConnection) CreateAnyCallHandler() (irs.AnyCallHand
         return nil, errors.New("GCP Driver: not implemented")
 }
 
+func (cloudConn *AlibabaCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
+
+func (cloudConn *AlibabaCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
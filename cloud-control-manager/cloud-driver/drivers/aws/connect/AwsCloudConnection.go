@@ -142,3 +142,10 @@ func (cloudConn *AwsCloudConnection) CreateAnyCallHandler() (irs.AnyCallHandler,
         return &handler, nil
 }
 
+func (cloudConn *AwsCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
+
+func (cloudConn *AwsCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
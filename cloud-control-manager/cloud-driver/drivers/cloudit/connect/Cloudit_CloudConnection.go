@@ -114,3 +114,10 @@ func (cloudConn *ClouditCloudConnection) CreateAnyCallHandler() (irs.AnyCallHand
 	return nil, errors.New("Cloudit Driver: not implemented")
 }
 
+func (cloudConn *ClouditCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
+
+func (cloudConn *ClouditCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
@@ -110,3 +110,10 @@ func (cloudConn *DockerCloudConnection) CreateAnyCallHandler() (irs.AnyCallHandl
         return nil, errors.New("Docker Driver: not implemented")
 }
 
+func (cloudConn *DockerCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
+
+func (cloudConn *DockerCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
@@ -134,3 +134,10 @@ func (cloudConn *GCPCloudConnection) CreateAnyCallHandler() (irs.AnyCallHandler,
 	return nil, errors.New("GCP Driver: not implemented")
 }
 
+func (cloudConn *GCPCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("GCP Driver: not implemented")
+}
+
+func (cloudConn *GCPCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("GCP Driver: not implemented")
+}
@@ -141,3 +141,10 @@ func (cloudConn *IbmCloudConnection) CreateAnyCallHandler() (irs.AnyCallHandler,
 	return nil, errors.New("Ibm Driver: not implemented")
 }
 
+func (cloudConn *IbmCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
+
+func (cloudConn *IbmCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
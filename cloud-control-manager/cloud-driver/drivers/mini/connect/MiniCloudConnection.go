@@ -111,3 +111,10 @@ func (cloudConn *MiniConnection) CreateAnyCallHandler() (irs.AnyCallHandler, err
         return nil, errors.New("Mini Driver: not implemented")
 }
 
+func (cloudConn *MiniConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("Mini Driver: not implemented")
+}
+
+func (cloudConn *MiniConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("Mini Driver: not implemented")
+}
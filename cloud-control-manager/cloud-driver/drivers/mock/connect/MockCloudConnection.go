@@ -16,7 +16,6 @@ import (
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
 	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 	"github.com/sirupsen/logrus"
-	"errors"
 )
 
 var cblogger *logrus.Logger
@@ -94,7 +93,9 @@ func (cloudConn *MockConnection) CreateDiskHandler() (irs.DiskHandler, error) {
 }
 
 func (cloudConn *MockConnection) CreateClusterHandler() (irs.ClusterHandler, error) {
-        return nil, errors.New("Mock Driver: not implemented")
+	cblogger.Info("Mock Driver: called CreateClusterHandler()!")
+	handler := mkrs.MockClusterHandler{cloudConn.MockName}
+	return &handler, nil
 }
 
 func (cloudConn *MockConnection) CreateMyImageHandler() (irs.MyImageHandler, error) {
@@ -109,3 +110,14 @@ func (cloudConn *MockConnection) CreateAnyCallHandler() (irs.AnyCallHandler, err
         return &handler, nil
 }
 
+func (cloudConn *MockConnection) CreateTagHandler() (irs.TagHandler, error) {
+	cblogger.Info("Mock Driver: called CreateTagHandler()!")
+	handler := mkrs.MockTagHandler{cloudConn.MockName}
+	return &handler, nil
+}
+
+func (cloudConn *MockConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	cblogger.Info("Mock Driver: called CreatePriceInfoHandler()!")
+	handler := mkrs.MockPriceInfoHandler{cloudConn.Region, cloudConn.MockName}
+	return &handler, nil
+}
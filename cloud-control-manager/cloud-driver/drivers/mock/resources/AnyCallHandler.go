@@ -86,6 +86,15 @@ func countAll(anyCallHandler *MockAnyCallHandler, callInfo irs.AnyCallInfo) (irs
 		} else {
 			strCount = strconv.Itoa(len(infoList))
 		}
+	case "cluster":
+clusterMapLock.RLock()
+		infoList, ok := clusterInfoMap[mockName]
+		if !ok {
+			strCount = "0"
+		} else {
+			strCount = strconv.Itoa(len(infoList))
+		}
+clusterMapLock.RUnlock()
 	}
 
 	// make results
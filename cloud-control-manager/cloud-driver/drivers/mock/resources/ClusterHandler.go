@@ -0,0 +1,563 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2022.10.
+
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+var clusterInfoMap map[string][]*irs.ClusterInfo
+
+type MockClusterHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	clusterInfoMap = make(map[string][]*irs.ClusterInfo)
+}
+
+var clusterMapLock = new(sync.RWMutex)
+
+const defaultMockClusterVersion = "1.23.3"
+
+// (1) validate the used resources(VPC, Subnet, SG, KeyPair)
+// (2) create clusterInfo object with the Creating status
+// (3) insert clusterInfo into global Map
+func (clusterHandler *MockClusterHandler) CreateCluster(clusterReqInfo irs.ClusterInfo) (irs.ClusterInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateCluster()!")
+
+	mockName := clusterHandler.MockName
+
+	// (1) validate the used resources
+	validatedNetworkInfo, err := validateClusterNetwork(mockName, clusterReqInfo.Network)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
+	for idx, ngInfo := range clusterReqInfo.NodeGroupList {
+		validatedNGInfo, err := newNodeGroupInfo(mockName, ngInfo)
+		if err != nil {
+			cblogger.Error(err)
+			return irs.ClusterInfo{}, err
+		}
+		clusterReqInfo.NodeGroupList[idx] = validatedNGInfo
+	}
+
+	// (2) create clusterInfo object
+	clusterReqInfo.IId.SystemId = clusterReqInfo.IId.NameId
+	if clusterReqInfo.Version == "" || clusterReqInfo.Version == "default" {
+		clusterReqInfo.Version = defaultMockClusterVersion
+	}
+	clusterReqInfo.Network = validatedNetworkInfo
+	clusterReqInfo.AccessInfo = newAccessInfo(clusterReqInfo.IId.SystemId)
+	clusterReqInfo.Status = irs.ClusterCreating
+	clusterReqInfo.CreatedTime = time.Now()
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	infoList, _ := clusterInfoMap[mockName]
+	for _, info := range infoList {
+		if info.IId.NameId == clusterReqInfo.IId.NameId {
+			return irs.ClusterInfo{}, fmt.Errorf("%s Cluster already exists!!", clusterReqInfo.IId.NameId)
+		}
+	}
+
+	// (3) insert ClusterInfo into global Map
+	clonedInfo := CloneClusterInfo(clusterReqInfo)
+	infoList = append(infoList, &clonedInfo)
+	clusterInfoMap[mockName] = infoList
+
+	return CloneClusterInfo(clusterReqInfo), nil
+}
+
+func validateClusterNetwork(mockName string, networkInfo irs.NetworkInfo) (irs.NetworkInfo, error) {
+	// vpc validation
+	vpcHandler := MockVPCHandler{mockName}
+	validatedVPCInfo, err := vpcHandler.GetVPC(networkInfo.VpcIID)
+	if err != nil {
+		return irs.NetworkInfo{}, err
+	}
+
+	// subnet validation
+	validatedSubnetIIDs := []irs.IID{}
+	for _, subnetIID := range networkInfo.SubnetIIDs {
+		flg := false
+		for _, info := range validatedVPCInfo.SubnetInfoList {
+			if info.IId.NameId == subnetIID.NameId {
+				validatedSubnetIIDs = append(validatedSubnetIIDs, info.IId)
+				flg = true
+				break
+			}
+		}
+		if !flg {
+			return irs.NetworkInfo{}, fmt.Errorf(subnetIID.NameId + " subnet iid does not exist!!")
+		}
+	}
+
+	// sg validation
+	securityHandler := MockSecurityHandler{mockName}
+	sgInfoList, err := securityHandler.ListSecurity()
+	if err != nil {
+		return irs.NetworkInfo{}, err
+	}
+	validatedSgIIDs := []irs.IID{}
+	for _, sgIID := range networkInfo.SecurityGroupIIDs {
+		flg := false
+		for _, info := range sgInfoList {
+			if (*info).IId.NameId == sgIID.NameId {
+				validatedSgIIDs = append(validatedSgIIDs, info.IId)
+				flg = true
+				break
+			}
+		}
+		if !flg {
+			return irs.NetworkInfo{}, fmt.Errorf(sgIID.NameId + " security group iid does not exist!!")
+		}
+	}
+
+	return irs.NetworkInfo{
+		VpcIID:            validatedVPCInfo.IId,
+		SubnetIIDs:        validatedSubnetIIDs,
+		SecurityGroupIIDs: validatedSgIIDs,
+		KeyValueList:      networkInfo.KeyValueList,
+	}, nil
+}
+
+// validate KeyPair and scaling sizes, and make synthetic nodes
+func newNodeGroupInfo(mockName string, ngReqInfo irs.NodeGroupInfo) (irs.NodeGroupInfo, error) {
+	// keypair validation
+	keyPairHandler := MockKeyPairHandler{mockName}
+	validatedKeyPairInfo, err := keyPairHandler.GetKey(ngReqInfo.KeyPairIID)
+	if err != nil {
+		return irs.NodeGroupInfo{}, err
+	}
+
+	err = validateScalingSize(ngReqInfo.IId, ngReqInfo.DesiredNodeSize, ngReqInfo.MinNodeSize, ngReqInfo.MaxNodeSize)
+	if err != nil {
+		return irs.NodeGroupInfo{}, err
+	}
+
+	ngReqInfo.IId.SystemId = ngReqInfo.IId.NameId
+	ngReqInfo.ImageIID.SystemId = ngReqInfo.ImageIID.NameId
+	ngReqInfo.KeyPairIID = validatedKeyPairInfo.IId
+	if ngReqInfo.RootDiskType == "" || ngReqInfo.RootDiskType == "default" {
+		ngReqInfo.RootDiskType = "SSD"
+	}
+	if ngReqInfo.RootDiskSize == "" || ngReqInfo.RootDiskSize == "default" {
+		ngReqInfo.RootDiskSize = "32"
+	}
+	ngReqInfo.Status = irs.NodeGroupCreating
+	ngReqInfo.Nodes = makeNodes(ngReqInfo.IId.SystemId, []irs.IID{}, ngReqInfo.DesiredNodeSize)
+
+	return ngReqInfo, nil
+}
+
+func validateScalingSize(ngIID irs.IID, desiredNodeSize int, minNodeSize int, maxNodeSize int) error {
+	if minNodeSize < 0 || desiredNodeSize < minNodeSize || desiredNodeSize > maxNodeSize {
+		return fmt.Errorf("%s NodeGroup has invalid scaling size: Min(%d) <= Desired(%d) <= Max(%d) is required!!",
+			ngIID.NameId, minNodeSize, desiredNodeSize, maxNodeSize)
+	}
+	return nil
+}
+
+// Keep the existing nodes and add or drop the tail nodes to fit the desired size.
+// ex) node IID: {"ng-01-node-1", "ng-01-node-1"}
+func makeNodes(ngSystemId string, nodes []irs.IID, desiredNodeSize int) []irs.IID {
+	newNodes := []irs.IID{}
+	for idx := 0; idx < desiredNodeSize; idx++ {
+		if idx < len(nodes) {
+			newNodes = append(newNodes, nodes[idx])
+			continue
+		}
+		nodeId := ngSystemId + "-node-" + strconv.Itoa(idx+1)
+		newNodes = append(newNodes, irs.IID{nodeId, nodeId})
+	}
+	return newNodes
+}
+
+func newAccessInfo(clusterSystemId string) irs.AccessInfo {
+	endpoint := "https://" + clusterSystemId + ".k8s.spider.barista.com:6443"
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    certificate-authority-data: bW9jay1jZXJ0aWZpY2F0ZS1hdXRob3JpdHktZGF0YQ==
+    server: ` + endpoint + `
+  name: ` + clusterSystemId + `
+contexts:
+- context:
+    cluster: ` + clusterSystemId + `
+    user: ` + clusterSystemId + `-admin
+  name: ` + clusterSystemId + `
+current-context: ` + clusterSystemId + `
+users:
+- name: ` + clusterSystemId + `-admin
+  user:
+    token: mock-token-` + clusterSystemId + `
+`
+	return irs.AccessInfo{Endpoint: endpoint, Kubeconfig: kubeconfig}
+}
+
+// Simulate the status transition of CSP.
+// A Cluster(NodeGroup) in progress(Creating, Updating) becomes Active at the next inquiry.
+// Caller should hold the write lock.
+func activateCluster(info *irs.ClusterInfo) {
+	if info.Status == irs.ClusterCreating || info.Status == irs.ClusterUpdating {
+		info.Status = irs.ClusterActive
+	}
+	for idx, ngInfo := range info.NodeGroupList {
+		if ngInfo.Status == irs.NodeGroupCreating || ngInfo.Status == irs.NodeGroupUpdating {
+			info.NodeGroupList[idx].Status = irs.NodeGroupActive
+		}
+	}
+}
+
+func CloneClusterInfoList(srcInfoList []*irs.ClusterInfo) []*irs.ClusterInfo {
+	clonedInfoList := []*irs.ClusterInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneClusterInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneClusterInfo(srcInfo irs.ClusterInfo) irs.ClusterInfo {
+	/*
+		type ClusterInfo struct {
+			IId IID // {NameId, SystemId}
+
+			Version string // Kubernetes Version, ex) 1.23.3
+			Network       NetworkInfo
+
+			NodeGroupList []NodeGroupInfo
+			AccessInfo    AccessInfo
+			Addons        AddonsInfo
+
+			Status        ClusterStatus
+
+			CreatedTime  time.Time
+			KeyValueList []KeyValue
+		}
+	*/
+
+	// clone ClusterInfo
+	clonedInfo := irs.ClusterInfo{
+		IId:     irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		Version: srcInfo.Version,
+		Network: irs.NetworkInfo{
+			VpcIID:            irs.IID{srcInfo.Network.VpcIID.NameId, srcInfo.Network.VpcIID.SystemId},
+			SubnetIIDs:        cloneIIDList(srcInfo.Network.SubnetIIDs),
+			SecurityGroupIIDs: cloneIIDList(srcInfo.Network.SecurityGroupIIDs),
+			KeyValueList:      srcInfo.Network.KeyValueList,
+		},
+
+		NodeGroupList: CloneNodeGroupInfoList(srcInfo.NodeGroupList),
+		AccessInfo:    srcInfo.AccessInfo,
+
+		// Need not clone
+		Addons: srcInfo.Addons,
+
+		Status:       srcInfo.Status,
+		CreatedTime:  srcInfo.CreatedTime,
+		TagList:      CloneTagList(srcInfo.TagList),
+		KeyValueList: srcInfo.KeyValueList,
+	}
+
+	return clonedInfo
+}
+
+func CloneNodeGroupInfoList(srcInfoList []irs.NodeGroupInfo) []irs.NodeGroupInfo {
+	clonedInfoList := []irs.NodeGroupInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfoList = append(clonedInfoList, CloneNodeGroupInfo(srcInfo))
+	}
+	return clonedInfoList
+}
+
+func CloneNodeGroupInfo(srcInfo irs.NodeGroupInfo) irs.NodeGroupInfo {
+	clonedInfo := irs.NodeGroupInfo{
+		IId:          irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
+		ImageIID:     irs.IID{srcInfo.ImageIID.NameId, srcInfo.ImageIID.SystemId},
+		VMSpecName:   srcInfo.VMSpecName,
+		RootDiskType: srcInfo.RootDiskType,
+		RootDiskSize: srcInfo.RootDiskSize,
+		KeyPairIID:   irs.IID{srcInfo.KeyPairIID.NameId, srcInfo.KeyPairIID.SystemId},
+
+		OnAutoScaling:   srcInfo.OnAutoScaling,
+		DesiredNodeSize: srcInfo.DesiredNodeSize,
+		MinNodeSize:     srcInfo.MinNodeSize,
+		MaxNodeSize:     srcInfo.MaxNodeSize,
+
+		Status: srcInfo.Status,
+		Nodes:  cloneIIDList(srcInfo.Nodes),
+
+		// Need not clone
+		KeyValueList: srcInfo.KeyValueList,
+	}
+
+	return clonedInfo
+}
+
+func cloneIIDList(srcList []irs.IID) []irs.IID {
+	clonedList := []irs.IID{}
+	for _, one := range srcList {
+		clonedList = append(clonedList, irs.IID{one.NameId, one.SystemId})
+	}
+	return clonedList
+}
+
+func (clusterHandler *MockClusterHandler) ListCluster() ([]*irs.ClusterInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListCluster()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	infoList, ok := clusterInfoMap[mockName]
+	if !ok {
+		return []*irs.ClusterInfo{}, nil
+	}
+
+	for _, info := range infoList {
+		activateCluster(info)
+	}
+
+	// cloning list of Cluster
+	return CloneClusterInfoList(infoList), nil
+}
+
+func (clusterHandler *MockClusterHandler) GetCluster(clusterIID irs.IID) (irs.ClusterInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetCluster()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	info, err := findCluster(mockName, clusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
+	activateCluster(info)
+	return CloneClusterInfo(*info), nil
+}
+
+// Caller should hold the lock.
+func findCluster(mockName string, clusterIID irs.IID) (*irs.ClusterInfo, error) {
+	infoList, ok := clusterInfoMap[mockName]
+	if !ok {
+		return nil, fmt.Errorf("%s Cluster does not exist!!", clusterIID.NameId)
+	}
+
+	for _, info := range infoList {
+		if info.IId.NameId == clusterIID.NameId {
+			return info, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s Cluster does not exist!!", clusterIID.NameId)
+}
+
+// Caller should hold the lock.
+func findNodeGroup(info *irs.ClusterInfo, nodeGroupIID irs.IID) (*irs.NodeGroupInfo, error) {
+	for idx, ngInfo := range info.NodeGroupList {
+		if ngInfo.IId.NameId == nodeGroupIID.NameId {
+			return &info.NodeGroupList[idx], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s NodeGroup does not exist in %s Cluster!!", nodeGroupIID.NameId, info.IId.NameId)
+}
+
+func (clusterHandler *MockClusterHandler) DeleteCluster(clusterIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteCluster()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	infoList, ok := clusterInfoMap[mockName]
+	if !ok {
+		return false, fmt.Errorf("%s Cluster does not exist!!", clusterIID.NameId)
+	}
+
+	for idx, info := range infoList {
+		if info.IId.NameId == clusterIID.NameId {
+			infoList = append(infoList[:idx], infoList[idx+1:]...)
+			clusterInfoMap[mockName] = infoList
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (clusterHandler *MockClusterHandler) AddNodeGroup(clusterIID irs.IID, nodeGroupReqInfo irs.NodeGroupInfo) (irs.NodeGroupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AddNodeGroup()!")
+
+	mockName := clusterHandler.MockName
+
+	validatedNGInfo, err := newNodeGroupInfo(mockName, nodeGroupReqInfo)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	info, err := findCluster(mockName, clusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
+	if _, err := findNodeGroup(info, validatedNGInfo.IId); err == nil {
+		errMSG := fmt.Sprintf("%s NodeGroup already exists in %s Cluster!!", validatedNGInfo.IId.NameId, clusterIID.NameId)
+		cblogger.Error(errMSG)
+		return irs.NodeGroupInfo{}, fmt.Errorf(errMSG)
+	}
+
+	info.NodeGroupList = append(info.NodeGroupList, CloneNodeGroupInfo(validatedNGInfo))
+
+	return validatedNGInfo, nil
+}
+
+func (clusterHandler *MockClusterHandler) SetNodeGroupAutoScaling(clusterIID irs.IID, nodeGroupIID irs.IID, on bool) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called SetNodeGroupAutoScaling()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	info, err := findCluster(mockName, clusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	ngInfo, err := findNodeGroup(info, nodeGroupIID)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	ngInfo.OnAutoScaling = on
+	return true, nil
+}
+
+func (clusterHandler *MockClusterHandler) ChangeNodeGroupScaling(clusterIID irs.IID, nodeGroupIID irs.IID,
+	DesiredNodeSize int, MinNodeSize int, MaxNodeSize int) (irs.NodeGroupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ChangeNodeGroupScaling()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	info, err := findCluster(mockName, clusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
+	ngInfo, err := findNodeGroup(info, nodeGroupIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
+	err = validateScalingSize(nodeGroupIID, DesiredNodeSize, MinNodeSize, MaxNodeSize)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.NodeGroupInfo{}, err
+	}
+
+	ngInfo.DesiredNodeSize = DesiredNodeSize
+	ngInfo.MinNodeSize = MinNodeSize
+	ngInfo.MaxNodeSize = MaxNodeSize
+	ngInfo.Nodes = makeNodes(ngInfo.IId.SystemId, ngInfo.Nodes, DesiredNodeSize)
+	ngInfo.Status = irs.NodeGroupUpdating
+
+	return CloneNodeGroupInfo(*ngInfo), nil
+}
+
+func (clusterHandler *MockClusterHandler) RemoveNodeGroup(clusterIID irs.IID, nodeGroupIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RemoveNodeGroup()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	info, err := findCluster(mockName, clusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+
+	for idx, ngInfo := range info.NodeGroupList {
+		if ngInfo.IId.NameId == nodeGroupIID.NameId {
+			info.NodeGroupList = append(info.NodeGroupList[:idx], info.NodeGroupList[idx+1:]...)
+			return true, nil
+		}
+	}
+
+	return false, fmt.Errorf("%s NodeGroup does not exist in %s Cluster!!", nodeGroupIID.NameId, clusterIID.NameId)
+}
+
+func (clusterHandler *MockClusterHandler) UpgradeCluster(clusterIID irs.IID, newVersion string) (irs.ClusterInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called UpgradeCluster()!")
+
+	mockName := clusterHandler.MockName
+
+clusterMapLock.Lock()
+defer clusterMapLock.Unlock()
+
+	info, err := findCluster(mockName, clusterIID)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.ClusterInfo{}, err
+	}
+
+	if info.Version == newVersion {
+		errMSG := fmt.Sprintf("%s Cluster is already version %s!!", clusterIID.NameId, newVersion)
+		cblogger.Error(errMSG)
+		return irs.ClusterInfo{}, fmt.Errorf(errMSG)
+	}
+
+	info.Version = newVersion
+	info.Status = irs.ClusterUpdating
+
+	return CloneClusterInfo(*info), nil
+}
@@ -102,6 +102,7 @@ func CloneNLBInfo(srcInfo irs.NLBInfo) irs.NLBInfo {
 		HealthChecker: srcInfo.HealthChecker,
 
 		CreatedTime: srcInfo.CreatedTime,
+		TagList: CloneTagList(srcInfo.TagList),
 		KeyValueList:  srcInfo.KeyValueList,
 	}
 
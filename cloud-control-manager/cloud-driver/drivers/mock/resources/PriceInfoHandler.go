@@ -0,0 +1,143 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2022.10.
+
+package resources
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	cblog "github.com/cloud-barista/cb-log"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	"gopkg.in/yaml.v3"
+)
+
+// the prices of the regions not listed in the price file
+const commonPriceRegion = "common-region"
+
+// The prices are read from $CBSPIDER_ROOT/cloud-driver-libs/mock_price.yaml at each call.
+type MockPriceInfoHandler struct {
+	Region   idrv.RegionInfo
+	MockName string
+}
+
+type mockRegionPrice struct {
+	Currency string            `yaml:"currency"`
+	VMSpec   map[string]string `yaml:"vmspec"`
+	Disk     map[string]string `yaml:"disk"`
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) ListVMSpecPrice() ([]*irs.PriceInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListVMSpecPrice()!")
+
+	regionPrice, err := priceInfoHandler.getRegionPrice()
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+	return priceInfoHandler.toPriceInfoList(regionPrice.Currency, irs.PRICE_UNIT_HOUR, regionPrice.VMSpec), nil
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) GetVMSpecPrice(Name string) (irs.PriceInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetVMSpecPrice()!")
+
+	regionPrice, err := priceInfoHandler.getRegionPrice()
+	if err != nil {
+		cblogger.Error(err)
+		return irs.PriceInfo{}, err
+	}
+	price, ok := regionPrice.VMSpec[Name]
+	if !ok {
+		return irs.PriceInfo{}, fmt.Errorf("%s VMSpec Price does not exist!!", Name)
+	}
+	return priceInfoHandler.toPriceInfo(Name, regionPrice.Currency, irs.PRICE_UNIT_HOUR, price), nil
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) ListDiskPrice() ([]*irs.PriceInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListDiskPrice()!")
+
+	regionPrice, err := priceInfoHandler.getRegionPrice()
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+	return priceInfoHandler.toPriceInfoList(regionPrice.Currency, irs.PRICE_UNIT_GB_MONTH, regionPrice.Disk), nil
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) GetDiskPrice(DiskType string) (irs.PriceInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetDiskPrice()!")
+
+	regionPrice, err := priceInfoHandler.getRegionPrice()
+	if err != nil {
+		cblogger.Error(err)
+		return irs.PriceInfo{}, err
+	}
+	price, ok := regionPrice.Disk[DiskType]
+	if !ok {
+		return irs.PriceInfo{}, fmt.Errorf("%s Disk Price does not exist!!", DiskType)
+	}
+	return priceInfoHandler.toPriceInfo(DiskType, regionPrice.Currency, irs.PRICE_UNIT_GB_MONTH, price), nil
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) getRegionPrice() (mockRegionPrice, error) {
+	rootPath := os.Getenv("CBSPIDER_ROOT")
+	if rootPath == "" {
+		return mockRegionPrice{}, fmt.Errorf("$CBSPIDER_ROOT is not set!!")
+	}
+
+	data, err := ioutil.ReadFile(rootPath + "/cloud-driver-libs/mock_price.yaml")
+	if err != nil {
+		return mockRegionPrice{}, err
+	}
+	priceMap := map[string]mockRegionPrice{}
+	if err := yaml.Unmarshal(data, &priceMap); err != nil {
+		return mockRegionPrice{}, err
+	}
+
+	if regionPrice, ok := priceMap[priceInfoHandler.Region.Region]; ok {
+		return regionPrice, nil
+	}
+	if regionPrice, ok := priceMap[commonPriceRegion]; ok {
+		return regionPrice, nil
+	}
+	return mockRegionPrice{}, fmt.Errorf("%s Region Price does not exist!!", priceInfoHandler.Region.Region)
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) toPriceInfoList(currency string, unit string, priceMap map[string]string) []*irs.PriceInfo {
+	nameList := []string{}
+	for name := range priceMap {
+		nameList = append(nameList, name)
+	}
+	sort.Strings(nameList)
+
+	infoList := []*irs.PriceInfo{}
+	for _, name := range nameList {
+		info := priceInfoHandler.toPriceInfo(name, currency, unit, priceMap[name])
+		infoList = append(infoList, &info)
+	}
+	return infoList
+}
+
+func (priceInfoHandler *MockPriceInfoHandler) toPriceInfo(name string, currency string, unit string, price string) irs.PriceInfo {
+	return irs.PriceInfo{
+		Region:        priceInfoHandler.Region.Region,
+		Name:          name,
+		Currency:      currency,
+		Unit:          unit,
+		OnDemandPrice: price,
+	}
+}
@@ -45,6 +45,7 @@ func (securityHandler *MockSecurityHandler) CreateSecurity(securityReqInfo irs.S
 		securityReqInfo.VpcIID,
 		// deprecated; securityReqInfo.Direction,
 		securityReqInfo.SecurityRules,
+		CloneTagList(securityReqInfo.TagList),
 		nil}
 
 	// (2) insert SecurityInfo into global Map
@@ -82,6 +83,7 @@ func CloneSecurityInfo(srcInfo irs.SecurityInfo) irs.SecurityInfo {
 		IId:       irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
 		VpcIID:    irs.IID{srcInfo.VpcIID.NameId, srcInfo.VpcIID.SystemId},
 		// deprecated; Direction: srcInfo.Direction,
+		TagList:   CloneTagList(srcInfo.TagList),
 
 		// Need not clone
 		SecurityRules: srcInfo.SecurityRules,
@@ -0,0 +1,169 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2022.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// The tags are kept in the TagList of each resource info in the global Maps.
+type MockTagHandler struct {
+	MockName string
+}
+
+func (tagHandler *MockTagHandler) AddTag(resType irs.RSType, resIID irs.IID, tag irs.KeyValue) (irs.KeyValue, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AddTag()!")
+
+	if tag.Key == "" {
+		return irs.KeyValue{}, fmt.Errorf("The key of the tag is empty!!")
+	}
+
+	_, err := updateTagList(tagHandler.MockName, resType, resIID, func(tagList *[]irs.KeyValue) bool {
+		newTagList := []irs.KeyValue{}
+		for _, one := range *tagList {
+			if one.Key != tag.Key {
+				newTagList = append(newTagList, one)
+			}
+		}
+		*tagList = append(newTagList, tag)
+		return true
+	})
+	if err != nil {
+		cblogger.Error(err)
+		return irs.KeyValue{}, err
+	}
+	return tag, nil
+}
+
+func (tagHandler *MockTagHandler) ListTag(resType irs.RSType, resIID irs.IID) ([]irs.KeyValue, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListTag()!")
+
+	var tagList []irs.KeyValue
+	_, err := updateTagList(tagHandler.MockName, resType, resIID, func(storedTagList *[]irs.KeyValue) bool {
+		tagList = CloneTagList(*storedTagList)
+		return false
+	})
+	if err != nil {
+		cblogger.Error(err)
+		return nil, err
+	}
+	return tagList, nil
+}
+
+func (tagHandler *MockTagHandler) RemoveTag(resType irs.RSType, resIID irs.IID, key string) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RemoveTag()!")
+
+	removed, err := updateTagList(tagHandler.MockName, resType, resIID, func(tagList *[]irs.KeyValue) bool {
+		newTagList := []irs.KeyValue{}
+		for _, one := range *tagList {
+			if one.Key != key {
+				newTagList = append(newTagList, one)
+			}
+		}
+		if len(newTagList) == len(*tagList) {
+			return false
+		}
+		*tagList = newTagList
+		return true
+	})
+	if err != nil {
+		cblogger.Error(err)
+		return false, err
+	}
+	if !removed {
+		return false, fmt.Errorf("%s tag of %s does not exist!!", key, resIID.NameId)
+	}
+	return true, nil
+}
+
+// find the TagList of the resource, and call the handler with the lock of the global Map.
+// handler: returns true if it changed the TagList.
+func updateTagList(mockName string, resType irs.RSType, resIID irs.IID, handler func(tagList *[]irs.KeyValue) bool) (bool, error) {
+	var lock *sync.RWMutex
+	var tagList *[]irs.KeyValue
+
+	switch resType {
+	case irs.VPC:
+		lock = vpcMapLock
+		lock.Lock()
+		for _, info := range vpcInfoMap[mockName] {
+			if info.IId.NameId == resIID.NameId {
+				tagList = &info.TagList
+			}
+		}
+	case irs.SG:
+		lock = sgMapLock
+		lock.Lock()
+		for _, info := range securityInfoMap[mockName] {
+			if info.IId.NameId == resIID.NameId {
+				tagList = &info.TagList
+			}
+		}
+	case irs.VM:
+		lock = vmMapLock
+		lock.Lock()
+		for _, info := range vmInfoMap[mockName] {
+			if info.IId.NameId == resIID.NameId {
+				tagList = &info.TagList
+			}
+		}
+	case irs.NLB:
+		lock = nlbMapLock
+		lock.Lock()
+		for _, info := range nlbInfoMap[mockName] {
+			if info.IId.NameId == resIID.NameId {
+				tagList = &info.TagList
+			}
+		}
+	case irs.DISK:
+		lock = diskMapLock
+		lock.Lock()
+		for _, info := range diskInfoMap[mockName] {
+			if info.IId.NameId == resIID.NameId {
+				tagList = &info.TagList
+			}
+		}
+	case irs.CLUSTER:
+		lock = clusterMapLock
+		lock.Lock()
+		for _, info := range clusterInfoMap[mockName] {
+			if info.IId.NameId == resIID.NameId {
+				tagList = &info.TagList
+			}
+		}
+	default:
+		return false, fmt.Errorf("%s is not a taggable resource type!!", resType)
+	}
+	defer lock.Unlock()
+
+	if tagList == nil {
+		return false, fmt.Errorf("%s %s does not exist!!", resIID.NameId, resType)
+	}
+	return handler(tagList), nil
+}
+
+func CloneTagList(srcTagList []irs.KeyValue) []irs.KeyValue {
+	if srcTagList == nil {
+		return nil
+	}
+	clonedTagList := []irs.KeyValue{}
+	for _, tag := range srcTagList {
+		clonedTagList = append(clonedTagList, irs.KeyValue{tag.Key, tag.Value})
+	}
+	return clonedTagList
+}
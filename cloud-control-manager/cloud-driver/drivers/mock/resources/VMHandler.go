@@ -182,6 +182,7 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 
 		DataDiskIIDs:  validatedDiskIIDs,
 
+		TagList: CloneTagList(vmReqInfo.TagList),
 		KeyValueList: nil,
 	}
 
@@ -527,6 +528,7 @@ func CloneVMInfo(srcInfo irs.VMInfo) irs.VMInfo {
 
 		SSHAccessPoint: srcInfo.SSHAccessPoint,
 
+		TagList:        CloneTagList(srcInfo.TagList),
                 KeyValueList:   srcInfo.KeyValueList, // now, do not need cloning
         }
 
@@ -52,6 +52,7 @@ func (vpcHandler *MockVPCHandler) CreateVPC(vpcReqInfo irs.VPCReqInfo) (irs.VPCI
 		vpcReqInfo.IId,
 		vpcReqInfo.IPv4_CIDR,
 		vpcReqInfo.SubnetInfoList,
+		CloneTagList(vpcReqInfo.TagList),
 		nil}
 
 	// (2) insert VPCInfo into global Map
@@ -105,6 +106,7 @@ func CloneVPCInfo(srcInfo irs.VPCInfo) (irs.VPCInfo) {
         IId: irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
         IPv4_CIDR: srcInfo.IPv4_CIDR,
         SubnetInfoList: CloneSubnetInfoList(srcInfo.SubnetInfoList),
+        TagList: CloneTagList(srcInfo.TagList),
 
         // Need not clone
         KeyValueList: srcInfo.KeyValueList,
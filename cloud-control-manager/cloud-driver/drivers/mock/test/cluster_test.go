@@ -0,0 +1,265 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package mocktest
+
+import (
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var clusterHandler irs.ClusterHandler
+var clusterAnyCallHandler irs.AnyCallHandler
+
+func init() {
+        // make the log level lower to print clearly
+        cblog.SetLevel("error")
+
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-88", // to avoid conflicts with the data of other tests
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{"default", "", ""},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	clusterHandler, _ = cloudConn.CreateClusterHandler()
+	clusterAnyCallHandler, _ = cloudConn.CreateAnyCallHandler()
+
+	vpcHandler, _ := cloudConn.CreateVPCHandler()
+	securityHandler, _ := cloudConn.CreateSecurityHandler()
+	keyPairHandler, _ := cloudConn.CreateKeyPairHandler()
+
+	// vpc creation
+	vpcReqInfo := irs.VPCReqInfo{
+		IId:            irs.IID{"mock-cluster-vpc-01", ""},
+		IPv4_CIDR:      "10.0.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{"mock-cluster-subnet-01", ""}, IPv4_CIDR: "10.0.1.0/24"}},
+	}
+	vpcHandler.CreateVPC(vpcReqInfo)
+
+	// sg creation
+	sgReqInfo := irs.SecurityReqInfo{
+		IId:           irs.IID{"mock-cluster-sg-01", ""},
+		VpcIID:        irs.IID{"mock-cluster-vpc-01", ""},
+		SecurityRules: &[]irs.SecurityRuleInfo{{FromPort: "1", ToPort: "65535", IPProtocol: "tcp", Direction: "inbound"}},
+	}
+	securityHandler.CreateSecurity(sgReqInfo)
+
+	// keypair creation
+	keyPairHandler.CreateKey(irs.KeyPairReqInfo{IId: irs.IID{"mock-cluster-key-01", ""}})
+}
+
+type ClusterTestInfo struct {
+	ClusterIID   string
+	NodeGroupIID string
+	NodeSize     int
+}
+
+var clusterTestInfoList = []ClusterTestInfo{
+	{"mock-cluster-01", "mock-ng-01", 2},
+	{"mock-cluster-02", "mock-ng-01", 3},
+}
+
+func newClusterReqInfo(info ClusterTestInfo) irs.ClusterInfo {
+	return irs.ClusterInfo{
+		IId: irs.IID{info.ClusterIID, ""},
+		Network: irs.NetworkInfo{
+			VpcIID:            irs.IID{"mock-cluster-vpc-01", ""},
+			SubnetIIDs:        []irs.IID{{"mock-cluster-subnet-01", ""}},
+			SecurityGroupIIDs: []irs.IID{{"mock-cluster-sg-01", ""}},
+		},
+		NodeGroupList: []irs.NodeGroupInfo{newNodeGroupReqInfo(info.NodeGroupIID, info.NodeSize)},
+	}
+}
+
+func newNodeGroupReqInfo(ngName string, nodeSize int) irs.NodeGroupInfo {
+	return irs.NodeGroupInfo{
+		IId:             irs.IID{ngName, ""},
+		VMSpecName:      "mock-vmspec-01",
+		KeyPairIID:      irs.IID{"mock-cluster-key-01", ""},
+		OnAutoScaling:   true,
+		DesiredNodeSize: nodeSize,
+		MinNodeSize:     1,
+		MaxNodeSize:     5,
+	}
+}
+
+func TestClusterCreateList(t *testing.T) {
+	// create
+	for _, info := range clusterTestInfoList {
+		clusterInfo, err := clusterHandler.CreateCluster(newClusterReqInfo(info))
+		if err != nil {
+			t.Error(err.Error())
+			continue
+		}
+		if clusterInfo.Status != irs.ClusterCreating {
+			t.Errorf("Cluster Status is not %s. It is %s.", irs.ClusterCreating, clusterInfo.Status)
+		}
+		if clusterInfo.AccessInfo.Kubeconfig == "" {
+			t.Errorf("%s Cluster does not have a Kubeconfig.", info.ClusterIID)
+		}
+		if len(clusterInfo.NodeGroupList[0].Nodes) != info.NodeSize {
+			t.Errorf("The number of Nodes is not %d. It is %d.", info.NodeSize, len(clusterInfo.NodeGroupList[0].Nodes))
+		}
+	}
+
+	// check the list size and status
+	infoList, err := clusterHandler.ListCluster()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) != len(clusterTestInfoList) {
+		t.Errorf("The number of Infos is not %d. It is %d.", len(clusterTestInfoList), len(infoList))
+	}
+	for i, info := range infoList {
+		if info.IId.SystemId != clusterTestInfoList[i].ClusterIID {
+			t.Errorf("System ID %s is not same %s", info.IId.SystemId, clusterTestInfoList[i].ClusterIID)
+		}
+		if info.Status != irs.ClusterActive {
+			t.Errorf("Cluster Status is not %s. It is %s.", irs.ClusterActive, info.Status)
+		}
+	}
+
+	// check the count of AnyCall
+	callInfo, err := clusterAnyCallHandler.AnyCall(irs.AnyCallInfo{
+		FID:           "countAll",
+		IKeyValueList: []irs.KeyValue{{"rsType", "cluster"}},
+	})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if callInfo.OKeyValueList[0].Value != "2" {
+		t.Errorf("The count of Clusters is not %d. It is %s.", 2, callInfo.OKeyValueList[0].Value)
+	}
+}
+
+func TestClusterCreateInvalid(t *testing.T) {
+	reqInfo := newClusterReqInfo(ClusterTestInfo{"mock-cluster-invalid", "mock-ng-01", 1})
+	reqInfo.Network.SubnetIIDs = []irs.IID{{"mock-cluster-subnet-99", ""}}
+	_, err := clusterHandler.CreateCluster(reqInfo)
+	if err == nil {
+		t.Errorf("CreateCluster() with an invalid Subnet must be failed.")
+	}
+
+	reqInfo = newClusterReqInfo(ClusterTestInfo{"mock-cluster-invalid", "mock-ng-01", 1})
+	reqInfo.NodeGroupList[0].KeyPairIID = irs.IID{"mock-cluster-key-99", ""}
+	_, err = clusterHandler.CreateCluster(reqInfo)
+	if err == nil {
+		t.Errorf("CreateCluster() with an invalid KeyPair must be failed.")
+	}
+}
+
+func TestClusterNodeGroup(t *testing.T) {
+	clusterIID := irs.IID{clusterTestInfoList[0].ClusterIID, clusterTestInfoList[0].ClusterIID}
+
+	// add
+	ngInfo, err := clusterHandler.AddNodeGroup(clusterIID, newNodeGroupReqInfo("mock-ng-02", 1))
+	if err != nil {
+		t.Error(err.Error())
+	}
+	_, err = clusterHandler.AddNodeGroup(clusterIID, newNodeGroupReqInfo("mock-ng-02", 1))
+	if err == nil {
+		t.Errorf("AddNodeGroup() with a duplicated name must be failed.")
+	}
+
+	// scale
+	ngInfo, err = clusterHandler.ChangeNodeGroupScaling(clusterIID, ngInfo.IId, 4, 2, 6)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if ngInfo.Status != irs.NodeGroupUpdating {
+		t.Errorf("NodeGroup Status is not %s. It is %s.", irs.NodeGroupUpdating, ngInfo.Status)
+	}
+	if len(ngInfo.Nodes) != 4 {
+		t.Errorf("The number of Nodes is not %d. It is %d.", 4, len(ngInfo.Nodes))
+	}
+	_, err = clusterHandler.ChangeNodeGroupScaling(clusterIID, ngInfo.IId, 9, 2, 6)
+	if err == nil {
+		t.Errorf("ChangeNodeGroupScaling() with Desired > Max must be failed.")
+	}
+
+	// check the result
+	clusterInfo, err := clusterHandler.GetCluster(clusterIID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(clusterInfo.NodeGroupList) != 2 {
+		t.Errorf("The number of NodeGroups is not %d. It is %d.", 2, len(clusterInfo.NodeGroupList))
+	}
+	if clusterInfo.NodeGroupList[1].Status != irs.NodeGroupActive {
+		t.Errorf("NodeGroup Status is not %s. It is %s.", irs.NodeGroupActive, clusterInfo.NodeGroupList[1].Status)
+	}
+
+	// remove by the NameId as the other operations
+	ret, err := clusterHandler.RemoveNodeGroup(clusterIID, irs.IID{ngInfo.IId.NameId, ""})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !ret {
+		t.Errorf("Return is not True!! %s", ngInfo.IId.NameId)
+	}
+	clusterInfo, _ = clusterHandler.GetCluster(clusterIID)
+	if len(clusterInfo.NodeGroupList) != 1 {
+		t.Errorf("The number of NodeGroups is not %d. It is %d.", 1, len(clusterInfo.NodeGroupList))
+	}
+}
+
+func TestClusterUpgrade(t *testing.T) {
+	clusterIID := irs.IID{clusterTestInfoList[0].ClusterIID, clusterTestInfoList[0].ClusterIID}
+
+	clusterInfo, err := clusterHandler.UpgradeCluster(clusterIID, "1.24.0")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if clusterInfo.Status != irs.ClusterUpdating {
+		t.Errorf("Cluster Status is not %s. It is %s.", irs.ClusterUpdating, clusterInfo.Status)
+	}
+
+	clusterInfo, err = clusterHandler.GetCluster(clusterIID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if clusterInfo.Version != "1.24.0" || clusterInfo.Status != irs.ClusterActive {
+		t.Errorf("Cluster is not (1.24.0, %s). It is (%s, %s).", irs.ClusterActive, clusterInfo.Version, clusterInfo.Status)
+	}
+}
+
+func TestClusterDeleteGet(t *testing.T) {
+	// delete all
+	infoList, err := clusterHandler.ListCluster()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	for _, info := range infoList {
+		ret, err := clusterHandler.DeleteCluster(irs.IID{info.IId.NameId, ""})
+		if err != nil {
+			t.Error(err.Error())
+		}
+		if !ret {
+			t.Errorf("Return is not True!! %s", info.IId.NameId)
+		}
+	}
+
+	// check the result of Delete Op
+	_, err = clusterHandler.GetCluster(irs.IID{clusterTestInfoList[0].ClusterIID, ""})
+	if err == nil {
+		t.Errorf("%s Cluster must not exist.", clusterTestInfoList[0].ClusterIID)
+	}
+	infoList, err = clusterHandler.ListCluster()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) > 0 {
+		t.Errorf("The number of Infos is not %d. It is %d.", 0, len(infoList))
+	}
+}
@@ -0,0 +1,62 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+var priceInfoHandler irs.PriceInfoHandler
+
+func init() {
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-Price-01",
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{Region: "mock-region-01"},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	priceInfoHandler, _ = cloudConn.CreatePriceInfoHandler()
+}
+
+func TestPriceInfo(t *testing.T) {
+	// the prices of the common-region for the region not listed
+	specPriceList, err := priceInfoHandler.ListVMSpecPrice()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(specPriceList) != 4 || specPriceList[0].Name != "mock-vmspec-01" {
+		t.Errorf("VMSpec Price List: %v", specPriceList)
+	}
+
+	specPrice, err := priceInfoHandler.GetVMSpecPrice("mock-vmspec-03")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if specPrice.Region != "mock-region-01" || specPrice.Unit != irs.PRICE_UNIT_HOUR || specPrice.Currency != "USD" || specPrice.OnDemandPrice != "0.3840" {
+		t.Errorf("VMSpec Price: %v", specPrice)
+	}
+
+	diskPrice, err := priceInfoHandler.GetDiskPrice("SSD")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if diskPrice.Unit != irs.PRICE_UNIT_GB_MONTH || diskPrice.OnDemandPrice != "0.1000" {
+		t.Errorf("Disk Price: %v", diskPrice)
+	}
+
+	if _, err := priceInfoHandler.GetVMSpecPrice("mock-vmspec-none"); err == nil {
+		t.Error("GetVMSpecPrice() with a not existing VMSpec should return an error!")
+	}
+}
@@ -0,0 +1,99 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+var tagVPCHandler irs.VPCHandler
+var tagHandler irs.TagHandler
+
+func init() {
+	cred := idrv.CredentialInfo{
+		MockName: "MockDriver-Tag-01",
+	}
+	connInfo := idrv.ConnectionInfo{
+		CredentialInfo: cred,
+		RegionInfo:     idrv.RegionInfo{},
+	}
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(connInfo)
+	tagVPCHandler, _ = cloudConn.CreateVPCHandler()
+	tagHandler, _ = cloudConn.CreateTagHandler()
+}
+
+func TestTagAddListRemove(t *testing.T) {
+	vpcIID := irs.IID{"mock-tag-vpc-01", ""}
+	reqInfo := irs.VPCReqInfo{
+		IId:            vpcIID,
+		IPv4_CIDR:      "10.0.1.0/24",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{"mock-subnet-01", ""}, IPv4_CIDR: "10.0.1.0/24"}},
+		TagList:        []irs.KeyValue{{"owner", "team-a"}},
+	}
+	vpcInfo, err := tagVPCHandler.CreateVPC(reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vpcInfo.TagList) != 1 || vpcInfo.TagList[0].Value != "team-a" {
+		t.Errorf("TagList of the created VPC: %v", vpcInfo.TagList)
+	}
+
+	// add a new tag and change the value of an existing tag
+	if _, err := tagHandler.AddTag(irs.VPC, vpcIID, irs.KeyValue{"env", "dev"}); err != nil {
+		t.Error(err.Error())
+	}
+	if _, err := tagHandler.AddTag(irs.VPC, vpcIID, irs.KeyValue{"owner", "team-b"}); err != nil {
+		t.Error(err.Error())
+	}
+
+	tagList, err := tagHandler.ListTag(irs.VPC, vpcIID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	tagMap := map[string]string{}
+	for _, tag := range tagList {
+		tagMap[tag.Key] = tag.Value
+	}
+	if len(tagMap) != 2 || tagMap["owner"] != "team-b" || tagMap["env"] != "dev" {
+		t.Errorf("TagList after AddTag: %v", tagList)
+	}
+
+	// the tags are also shown in the resource info
+	vpcInfo, err = tagVPCHandler.GetVPC(vpcIID)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(vpcInfo.TagList) != 2 {
+		t.Errorf("TagList of GetVPC: %v", vpcInfo.TagList)
+	}
+
+	// remove
+	result, err := tagHandler.RemoveTag(irs.VPC, vpcIID, "owner")
+	if err != nil || !result {
+		t.Errorf("RemoveTag: %v, %v", result, err)
+	}
+	if _, err := tagHandler.RemoveTag(irs.VPC, vpcIID, "owner"); err == nil {
+		t.Error("RemoveTag of a removed tag should return an error!")
+	}
+	tagList, _ = tagHandler.ListTag(irs.VPC, vpcIID)
+	if len(tagList) != 1 || tagList[0].Key != "env" {
+		t.Errorf("TagList after RemoveTag: %v", tagList)
+	}
+
+	// not existing resource
+	if _, err := tagHandler.ListTag(irs.VM, irs.IID{"mock-tag-vm-none", ""}); err == nil {
+		t.Error("ListTag of a not existing resource should return an error!")
+	}
+
+	tagVPCHandler.DeleteVPC(vpcIID)
+}
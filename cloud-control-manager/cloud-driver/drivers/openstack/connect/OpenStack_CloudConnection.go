@@ -137,3 +137,11 @@ func (cloudConn *OpenStackCloudConnection) CreateAnyCallHandler() (irs.AnyCallHa
         }
         return &anyCallHandler, nil
 }
+
+func (cloudConn *OpenStackCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
+
+func (cloudConn *OpenStackCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
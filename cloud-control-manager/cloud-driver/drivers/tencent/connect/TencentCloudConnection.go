@@ -153,3 +153,10 @@ func (cloudConn *TencentCloudConnection) CreateAnyCallHandler() (irs.AnyCallHand
 	return nil, errors.New("Tencent Driver: not implemented")
 }
 
+func (cloudConn *TencentCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
+	return nil, errors.New("Tencent Driver: not implemented")
+}
+
+func (cloudConn *TencentCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, error) {
+	return nil, errors.New("Tencent Driver: not implemented")
+}
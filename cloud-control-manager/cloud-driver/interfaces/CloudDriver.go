@@ -19,15 +19,17 @@ type DriverCapabilityInfo struct {
 	ImageHandler bool // support: true, do not support: false
 	VPCHandler   bool // support: true, do not support: false
 	//VNetworkHandler bool // support: true, do not support: false
-	SecurityHandler bool // support: true, do not support: false
-	KeyPairHandler  bool // support: true, do not support: false
-	VNicHandler     bool // support: true, do not support: false
-	PublicIPHandler bool // support: true, do not support: false
-	VMHandler       bool // support: true, do not support: false
-	VMSpecHandler   bool // support: true, do not support: false
-	DiskHandler     bool // support: true, do not support: false
-	MyImageHandler  bool // support: true, do not support: false
-	ClusterHandler  bool // support: true, do not support: false
+	SecurityHandler  bool // support: true, do not support: false
+	KeyPairHandler   bool // support: true, do not support: false
+	VNicHandler      bool // support: true, do not support: false
+	PublicIPHandler  bool // support: true, do not support: false
+	VMHandler        bool // support: true, do not support: false
+	VMSpecHandler    bool // support: true, do not support: false
+	DiskHandler      bool // support: true, do not support: false
+	MyImageHandler   bool // support: true, do not support: false
+	ClusterHandler   bool // support: true, do not support: false
+	TagHandler       bool // support: true, do not support: false
+	PriceInfoHandler bool // support: true, do not support: false
 
 	FIXED_SUBNET_CIDR bool // support: true, do not support: false
 	VPC_CIDR          bool // support: true, do not support: false
@@ -32,6 +32,10 @@ type CloudConnection interface {
 
 	CreateAnyCallHandler() (irs.AnyCallHandler, error)
 
+	CreateTagHandler() (irs.TagHandler, error)
+
+	CreatePriceInfoHandler() (irs.PriceInfoHandler, error)
+
 	IsConnected() (bool, error)
 	Close() error
 }
@@ -49,6 +49,7 @@ type ClusterInfo struct {
 	Status        ClusterStatus
 
 	CreatedTime  time.Time
+	TagList      []KeyValue
 	KeyValueList []KeyValue
 }
 
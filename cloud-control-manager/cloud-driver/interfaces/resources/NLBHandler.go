@@ -28,6 +28,7 @@ type NLBInfo struct {
 	HealthChecker	HealthCheckerInfo
 
 	CreatedTime	time.Time
+	TagList      []KeyValue
 	KeyValueList []KeyValue
 }
 
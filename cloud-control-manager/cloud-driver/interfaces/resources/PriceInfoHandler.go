@@ -0,0 +1,39 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2022.10.
+
+package resources
+
+//-------- Const
+// the normalized units of the on-demand prices
+const (
+	PRICE_UNIT_HOUR     = "Hour"     // the price of a VMSpec per hour
+	PRICE_UNIT_GB_MONTH = "GB-Month" // the price of a disk type per GB per month
+)
+
+//-------- Info Structure
+type PriceInfo struct {
+	Region        string // the region of the connection
+	Name          string // the VMSpec name or the disk type, ex) "t3.micro", "gp3"
+	Currency      string // ex) "USD"
+	Unit          string // PRICE_UNIT_HOUR or PRICE_UNIT_GB_MONTH
+	OnDemandPrice string // the price per the Unit, ex) "0.0104"
+
+	KeyValueList []KeyValue
+}
+
+//-------- PriceInfo API
+// The on-demand prices in the region of the connection.
+type PriceInfoHandler interface {
+	ListVMSpecPrice() ([]*PriceInfo, error)
+	GetVMSpecPrice(Name string) (PriceInfo, error)
+
+	ListDiskPrice() ([]*PriceInfo, error)
+	GetDiskPrice(DiskType string) (PriceInfo, error)
+}
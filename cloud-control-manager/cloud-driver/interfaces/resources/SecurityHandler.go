@@ -17,6 +17,8 @@ type SecurityReqInfo struct {
 	VpcIID        IID    // {NameId, SystemId}
 	//Direction     string // To be deprecated
 	SecurityRules *[]SecurityRuleInfo
+
+	TagList []KeyValue
 }
 
 // @definitionAlias cres.SecurityRuleInfo
@@ -35,6 +37,7 @@ type SecurityInfo struct {
 	//Direction     string // @todo userd??
 	SecurityRules *[]SecurityRuleInfo
 
+	TagList      []KeyValue
 	KeyValueList []KeyValue
 }
 
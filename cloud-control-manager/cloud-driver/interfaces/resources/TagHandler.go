@@ -0,0 +1,34 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2022.10.
+
+package resources
+
+//-------- Const
+type RSType string
+
+// the taggable resources
+const (
+	VPC     RSType = "vpc"
+	SG      RSType = "sg"
+	VM      RSType = "vm"
+	NLB     RSType = "nlb"
+	DISK    RSType = "disk"
+	CLUSTER RSType = "cluster"
+)
+
+//-------- Tag API
+// The tags are user's labels on the resources, ex) {Key: "owner", Value: "team-a"}
+// The tags of a new resource are set with the TagList of its request.
+type TagHandler interface {
+	// add a tag, or change the value of the tag with the same key.
+	AddTag(resType RSType, resIID IID, tag KeyValue) (KeyValue, error)
+	ListTag(resType RSType, resIID IID) ([]KeyValue, error)
+	RemoveTag(resType RSType, resIID IID, key string) (bool, error)
+}
@@ -42,6 +42,8 @@ type VMReqInfo struct {
 	VMUserId     string
 	VMUserPasswd string
 	WindowsType  bool
+
+	TagList []KeyValue
 }
 
 type VMStatusInfo struct {
@@ -108,6 +110,7 @@ type VMInfo struct {
 
 	SSHAccessPoint string // ex) 10.2.3.2:22, 123.456.789.123:4321
 
+	TagList      []KeyValue
 	KeyValueList []KeyValue
 }
 
@@ -16,6 +16,8 @@ type VPCReqInfo struct {
 	IId   IID       // {NameId, SystemId}
 	IPv4_CIDR string 
 	SubnetInfoList []SubnetInfo 
+
+	TagList []KeyValue
 }
 
 type VPCInfo struct {
@@ -23,6 +25,7 @@ type VPCInfo struct {
 	IPv4_CIDR string 
 	SubnetInfoList []SubnetInfo 
 
+	TagList      []KeyValue
 	KeyValueList []KeyValue 
 }
 
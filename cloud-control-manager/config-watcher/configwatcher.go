@@ -0,0 +1,97 @@
+// Config File Watcher of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// ref) https://github.com/fsnotify/fsnotify
+//
+// by CB-Spider Team, 2022.10.
+
+package configwatcher
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// An editor writes a file with several events(ex. truncate and write, or rename and create),
+// so the changes are notified once after this delay.
+const notifyDelay = 200 * time.Millisecond
+
+// Watcher calls the handler when the config file is changed.
+type Watcher struct {
+	fileName string
+	onChange func()
+	watcher  *fsnotify.Watcher
+
+	lock  sync.Mutex
+	timer *time.Timer
+}
+
+// Watch the config file, and call onChange after it is written, created or replaced.
+// The directory of the file is watched, because a replaced file(ex. by vi or ConfigMap) is not watched any more.
+func Watch(fileName string, onChange func()) (*Watcher, error) {
+	fileName = filepath.Clean(fileName)
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	err = fsWatcher.Add(filepath.Dir(fileName))
+	if err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	watcher := &Watcher{fileName: fileName, onChange: onChange, watcher: fsWatcher}
+	go watcher.run()
+	return watcher, nil
+}
+
+func (watcher *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-watcher.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != watcher.fileName {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				watcher.notify()
+			}
+		case err, ok := <-watcher.watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.Error(err)
+		}
+	}
+}
+
+func (watcher *Watcher) notify() {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	if watcher.timer != nil {
+		watcher.timer.Stop()
+	}
+	watcher.timer = time.AfterFunc(notifyDelay, watcher.onChange)
+}
+
+// Stop watching.
+func (watcher *Watcher) Close() error {
+	watcher.lock.Lock()
+	if watcher.timer != nil {
+		watcher.timer.Stop()
+	}
+	watcher.lock.Unlock()
+
+	return watcher.watcher.Close()
+}
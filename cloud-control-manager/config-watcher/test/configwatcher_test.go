@@ -0,0 +1,79 @@
+// Config File Watcher Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package configwatchertest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configwatcher "github.com/cloud-barista/cb-spider/cloud-control-manager/config-watcher"
+)
+
+func waitNotified(t *testing.T, notified chan string, want string) {
+	select {
+	case got := <-notified:
+		if got != want {
+			t.Errorf("The reloaded config is not %q. It is %q.", want, got)
+		}
+	case <-time.After(3 * time.Second):
+		t.Errorf("The change(%q) is not notified.", want)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configwatcher")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "test_conf.yaml")
+	if err := ioutil.WriteFile(fileName, []byte("loglevel: info\n"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	notified := make(chan string, 10)
+	watcher, err := configwatcher.Watch(fileName, func() {
+		data, _ := ioutil.ReadFile(fileName)
+		notified <- string(data)
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer watcher.Close()
+
+	// write
+	if err := ioutil.WriteFile(fileName, []byte("loglevel: error\n"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	waitNotified(t, notified, "loglevel: error\n")
+
+	// replace, ex) vi or ConfigMap
+	tmpFileName := filepath.Join(dir, "test_conf.yaml.tmp")
+	if err := ioutil.WriteFile(tmpFileName, []byte("loglevel: info\n"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := os.Rename(tmpFileName, fileName); err != nil {
+		t.Fatal(err.Error())
+	}
+	waitNotified(t, notified, "loglevel: info\n")
+
+	// the other file in the same directory
+	if err := ioutil.WriteFile(filepath.Join(dir, "other_conf.yaml"), []byte("other\n"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+	select {
+	case got := <-notified:
+		t.Errorf("The change of the other file is notified: %q", got)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
@@ -0,0 +1,205 @@
+// Retry and Rate Limit of Driver Calls in CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// load and set config file
+//
+// ref) https://github.com/go-yaml/yaml/tree/v3
+//
+// by CB-Spider Team, 2022.10.
+
+package driverretry
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	configwatcher "github.com/cloud-barista/cb-spider/cloud-control-manager/config-watcher"
+)
+
+type RETRYCONFIG struct {
+	RETRY struct {
+		MAXRETRIES     int
+		INITIALBACKOFF int // milliseconds
+		MAXBACKOFF     int // milliseconds
+		MULTIPLIER     float64
+		JITTER         float64 // 0 ~ 1
+	}
+
+	RATELIMIT struct {
+		ENABLED bool
+		RPS     float64 // calls per second, 0: no limit
+		BURST   int
+	}
+
+	PROVIDERS map[string]PROVIDERCONFIG // key: CloudOS, ex) AWS
+}
+
+// The values of a CSP, nil: the common value is used.
+type PROVIDERCONFIG struct {
+	MAXRETRIES      *int
+	RPS             *float64
+	BURST           *int
+	RETRYABLEERRORS []string // added to the built-in errors of the CSP
+}
+
+// the policy of a CSP, resolved from the config.
+type Policy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+
+	RateLimit bool
+	RPS       float64
+	Burst     int
+
+	RetryableErrors []string
+}
+
+var (
+	retryConfig RETRYCONFIG
+	configLock  sync.RWMutex
+	configOnce  sync.Once
+)
+
+// the config without the config file.
+func defaultConfig() RETRYCONFIG {
+	config := RETRYCONFIG{}
+	config.RETRY.MAXRETRIES = 3
+	config.RETRY.INITIALBACKOFF = 1000
+	config.RETRY.MAXBACKOFF = 20000
+	config.RETRY.MULTIPLIER = 2
+	config.RETRY.JITTER = 0.5
+	config.RATELIMIT.ENABLED = true
+	config.RATELIMIT.RPS = 10
+	config.RATELIMIT.BURST = 20
+	return config
+}
+
+// $CBSPIDER_ROOT/conf/retry_conf.yaml
+func GetConfigFileName() string {
+	return os.Getenv("CBSPIDER_ROOT") + "/conf/retry_conf.yaml"
+}
+
+// load the config file at the first call, and watch it.
+// The default config is used if there is no config file.
+func setup() {
+	configOnce.Do(func() {
+		fileName := GetConfigFileName()
+		config, err := readConfigInfos(fileName)
+		if err != nil {
+			cblog.Infof("The default retry config is used: %v", err)
+			config = defaultConfig()
+		}
+		setConfig(config)
+
+		// hot reload: the changes of the config file are applied without restart.
+		_, err = configwatcher.Watch(fileName, func() {
+			config, err := readConfigInfos(fileName)
+			if err != nil {
+				cblog.Errorf("Failed to reload the retry config file, the current config is kept: %v", err)
+				return
+			}
+			cblog.Info("The retry config file is reloaded.")
+			setConfig(config)
+		})
+		if err != nil {
+			cblog.Errorf("Failed to watch the retry config file: %v", err)
+		}
+	})
+}
+
+func readConfigInfos(fileName string) (RETRYCONFIG, error) {
+	config := defaultConfig()
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, err
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// SetConfig changes the config until the config file is changed, ex) for tests.
+func SetConfig(config RETRYCONFIG) {
+	configOnce.Do(func() {})
+	setConfig(config)
+}
+
+func setConfig(config RETRYCONFIG) {
+	configLock.Lock()
+	defer configLock.Unlock()
+	retryConfig = config
+}
+
+// GetConfig returns the current config.
+func GetConfig() RETRYCONFIG {
+	setup()
+
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return retryConfig
+}
+
+// GetPolicy returns the policy of a CSP, the values of the CSP override the common values.
+func GetPolicy(providerName string) Policy {
+	config := GetConfig()
+
+	policy := Policy{
+		MaxRetries:     config.RETRY.MAXRETRIES,
+		InitialBackoff: time.Duration(config.RETRY.INITIALBACKOFF) * time.Millisecond,
+		MaxBackoff:     time.Duration(config.RETRY.MAXBACKOFF) * time.Millisecond,
+		Multiplier:     config.RETRY.MULTIPLIER,
+		Jitter:         config.RETRY.JITTER,
+		RateLimit:      config.RATELIMIT.ENABLED,
+		RPS:            config.RATELIMIT.RPS,
+		Burst:          config.RATELIMIT.BURST,
+	}
+	policy.RetryableErrors = append(policy.RetryableErrors, commonRetryableErrors...)
+	policy.RetryableErrors = append(policy.RetryableErrors, builtinRetryableErrors[strings.ToUpper(providerName)]...)
+
+	for name, providerConfig := range config.PROVIDERS {
+		if !strings.EqualFold(name, providerName) {
+			continue
+		}
+		if providerConfig.MAXRETRIES != nil {
+			policy.MaxRetries = *providerConfig.MAXRETRIES
+		}
+		if providerConfig.RPS != nil {
+			policy.RPS = *providerConfig.RPS
+		}
+		if providerConfig.BURST != nil {
+			policy.Burst = *providerConfig.BURST
+		}
+		policy.RetryableErrors = append(policy.RetryableErrors, providerConfig.RETRYABLEERRORS...)
+	}
+
+	if policy.MaxRetries < 0 {
+		policy.MaxRetries = 0
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 1
+	}
+	if policy.Jitter < 0 {
+		policy.Jitter = 0
+	} else if policy.Jitter > 1 {
+		policy.Jitter = 1
+	}
+	if policy.RPS <= 0 {
+		policy.RateLimit = false
+	}
+	if policy.Burst < 1 {
+		policy.Burst = 1
+	}
+	return policy
+}
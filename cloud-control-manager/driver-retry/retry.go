@@ -0,0 +1,169 @@
+// Retry and Rate Limit of Driver Calls in CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package driverretry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cb-store/config"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+var cblog *logrus.Logger
+
+func init() {
+	cblog = config.Cblogger
+}
+
+//================ Retryable Errors
+
+// The throttling errors of the CSPs.
+// A throttled call is not always rejected before it is run, ex) the error after the request is accepted,
+// so only the read-only calls are retried, cf) CallOnce() for the mutating calls.
+// ex) AWS: "RequestLimitExceeded: Request limit exceeded.\n\tstatus code: 503, request id: ..."
+// ex) AZURE: "compute.VirtualMachinesClient#CreateOrUpdate: ... StatusCode=429 -- Original Error: ... Code=\"TooManyRequests\""
+// ex) GCP: "googleapi: Error 403: Rate Limit Exceeded, rateLimitExceeded"
+var builtinRetryableErrors = map[string][]string{
+	"AWS":     {"RequestLimitExceeded", "ThrottlingException", "Throttling:", "TooManyRequestsException", "RequestThrottled", "SlowDown"},
+	"AZURE":   {"StatusCode=429", "TooManyRequests", "SubscriptionRequestsThrottled"},
+	"GCP":     {"rateLimitExceeded", "userRateLimitExceeded", "RATE_LIMIT_EXCEEDED", "Error 429"},
+	"ALIBABA": {"Throttling"},
+	"TENCENT": {"RequestLimitExceeded"},
+}
+
+// HTTP 429 of all CSPs
+var commonRetryableErrors = []string{"429 Too Many Requests"}
+
+// IsRetryable returns true if the error is a throttling error of the CSP.
+func IsRetryable(providerName string, err error) bool {
+	return isRetryable(GetPolicy(providerName), err)
+}
+
+func isRetryable(policy Policy, err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, retryableError := range policy.RetryableErrors {
+		if retryableError != "" && strings.Contains(msg, retryableError) {
+			return true
+		}
+	}
+	return false
+}
+
+//================ Exponential Backoff with Jitter
+
+var randLock sync.Mutex
+var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// Backoff returns the waiting time before the retry(1, 2, ...).
+// ex) initial: 1s, multiplier: 2, jitter: 0.5 => retry 1: 0.5s ~ 1s, retry 2: 1s ~ 2s, retry 3: 2s ~ 4s
+func Backoff(policy Policy, retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	backoff := float64(policy.InitialBackoff) * math.Pow(policy.Multiplier, float64(retry-1))
+	if maxBackoff := float64(policy.MaxBackoff); maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	randLock.Lock()
+	reduction := random.Float64() * policy.Jitter
+	randLock.Unlock()
+	return time.Duration(backoff * (1 - reduction))
+}
+
+//================ Rate Limit per Connection
+
+var limiterLock sync.Mutex
+var limiterMap = map[string]*rate.Limiter{} // key: ConnectionName
+
+// the limiter of the connection, which follows the changes of the policy.
+func getLimiter(connectionName string, policy Policy) *rate.Limiter {
+	limiterLock.Lock()
+	defer limiterLock.Unlock()
+
+	limiter, ok := limiterMap[connectionName]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(policy.RPS), policy.Burst)
+		limiterMap[connectionName] = limiter
+		return limiter
+	}
+	if limiter.Limit() != rate.Limit(policy.RPS) {
+		limiter.SetLimit(rate.Limit(policy.RPS))
+	}
+	if limiter.Burst() != policy.Burst {
+		limiter.SetBurst(policy.Burst)
+	}
+	return limiter
+}
+
+// RemoveConnection removes the limiter of a deleted connection.
+func RemoveConnection(connectionName string) {
+	limiterLock.Lock()
+	defer limiterLock.Unlock()
+	delete(limiterMap, connectionName)
+}
+
+//================ Call with Retry
+
+// Call runs the read-only driver call of a connection within the rate limit of the connection,
+// and retries the call with backoff on the throttling errors of the CSP.
+// It returns the number of retries and the error of the last call,
+// or the error of ctx if the request is canceled while waiting for the rate limit or the backoff.
+func Call(ctx context.Context, connectionName string, providerName string, call func() error) (int, error) {
+	policy := GetPolicy(providerName)
+
+	retryCount := 0
+	for {
+		if err := waitRateLimit(ctx, connectionName, policy); err != nil {
+			return retryCount, err
+		}
+
+		err := call()
+		if err == nil || retryCount >= policy.MaxRetries || !isRetryable(policy, err) {
+			return retryCount, err
+		}
+
+		retryCount++
+		backoff := Backoff(policy, retryCount)
+		cblog.Infof("[%s] retry %d/%d after %v: %v", connectionName, retryCount, policy.MaxRetries, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return retryCount, ctx.Err()
+		}
+	}
+}
+
+// CallOnce runs the mutating driver call of a connection within the rate limit of the connection, without retries.
+// The call is not run if the request is canceled while waiting for the rate limit.
+func CallOnce(ctx context.Context, connectionName string, providerName string, call func() error) error {
+	if err := waitRateLimit(ctx, connectionName, GetPolicy(providerName)); err != nil {
+		return err
+	}
+	return call()
+}
+
+// The burst of the policy is 1 or more, so the error is of ctx, ex) canceled or the deadline is before the turn.
+func waitRateLimit(ctx context.Context, connectionName string, policy Policy) error {
+	if !policy.RateLimit {
+		return nil
+	}
+	return getLimiter(connectionName, policy).Wait(ctx)
+}
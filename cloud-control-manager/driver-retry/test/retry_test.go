@@ -0,0 +1,230 @@
+// Retry and Rate Limit Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package driverretrytest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	driverretry "github.com/cloud-barista/cb-spider/cloud-control-manager/driver-retry"
+)
+
+// the config of $CBSPIDER_ROOT/conf/retry_conf.yaml, it runs first before SetConfig().
+func TestConfigFile(t *testing.T) {
+	if os.Getenv("CBSPIDER_ROOT") == "" {
+		t.Skip("$CBSPIDER_ROOT is not set.")
+	}
+
+	policy := driverretry.GetPolicy("AWS")
+	if policy.MaxRetries != 3 || policy.InitialBackoff != time.Second || !policy.RateLimit || policy.RPS != 20 || policy.Burst != 40 {
+		t.Errorf("The AWS policy is not the config. %#v", policy)
+	}
+	if policy := driverretry.GetPolicy("MOCK"); policy.RateLimit {
+		t.Errorf("The MOCK driver calls should not be rate-limited. %#v", policy)
+	}
+}
+
+func testConfig() driverretry.RETRYCONFIG {
+	config := driverretry.RETRYCONFIG{}
+	config.RETRY.MAXRETRIES = 3
+	config.RETRY.INITIALBACKOFF = 10
+	config.RETRY.MAXBACKOFF = 40
+	config.RETRY.MULTIPLIER = 2
+	config.RETRY.JITTER = 0.5
+	return config
+}
+
+func TestIsRetryable(t *testing.T) {
+	driverretry.SetConfig(testConfig())
+
+	testCases := []struct {
+		providerName string
+		msg          string
+		retryable    bool
+	}{
+		{"AWS", "RequestLimitExceeded: Request limit exceeded.\n\tstatus code: 503, request id: 1234", true},
+		{"AWS", "Throttling: Rate exceeded\n\tstatus code: 400", true},
+		{"AWS", "InvalidKeyPair.Duplicate: The keypair 'key-01' already exists.", false},
+		{"AZURE", "compute.VirtualMachinesClient#CreateOrUpdate: Failure sending request: StatusCode=429 -- Original Error: Code=\"TooManyRequests\"", true},
+		{"AZURE", "StatusCode=409 -- Original Error: Code=\"Conflict\"", false},
+		{"GCP", "googleapi: Error 403: Rate Limit Exceeded, rateLimitExceeded", true},
+		{"GCP", "googleapi: Error 409: The resource 'vm-01' already exists, alreadyExists", false},
+		{"gcp", "googleapi: Error 429: Quota exceeded, userRateLimitExceeded", true},
+		{"OPENSTACK", "Request failed: 429 Too Many Requests", true},
+		{"OPENSTACK", "RequestLimitExceeded", false},
+	}
+	for _, testCase := range testCases {
+		if got := driverretry.IsRetryable(testCase.providerName, errors.New(testCase.msg)); got != testCase.retryable {
+			t.Errorf("IsRetryable(%s, %q) = %v, want %v", testCase.providerName, testCase.msg, got, testCase.retryable)
+		}
+	}
+
+	// the retryable errors of the config are added to the built-in ones.
+	config := testConfig()
+	config.PROVIDERS = map[string]driverretry.PROVIDERCONFIG{"AWS": {RETRYABLEERRORS: []string{"InsufficientInstanceCapacity"}}}
+	driverretry.SetConfig(config)
+	if !driverretry.IsRetryable("AWS", errors.New("InsufficientInstanceCapacity: ...")) || !driverretry.IsRetryable("AWS", errors.New("RequestLimitExceeded")) {
+		t.Errorf("The retryable errors of the config are not added.")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	driverretry.SetConfig(testConfig())
+	policy := driverretry.GetPolicy("AWS")
+
+	// initial: 10ms, multiplier: 2, max: 40ms, jitter: 0.5
+	wantMax := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}
+	for idx, max := range wantMax {
+		for n := 0; n < 20; n++ {
+			backoff := driverretry.Backoff(policy, idx+1)
+			if backoff > max || backoff < max/2 {
+				t.Errorf("The backoff of retry %d is %v, not in [%v, %v].", idx+1, backoff, max/2, max)
+			}
+		}
+	}
+}
+
+func TestCall(t *testing.T) {
+	driverretry.SetConfig(testConfig())
+
+	// throttled twice, and succeeded
+	callCount := 0
+	retryCount, err := driverretry.Call(context.Background(), "retry-test-config01", "AWS", func() error {
+		callCount++
+		if callCount <= 2 {
+			return errors.New("RequestLimitExceeded: Request limit exceeded.")
+		}
+		return nil
+	})
+	if err != nil || retryCount != 2 || callCount != 3 {
+		t.Errorf("Call() = (%d, %v), called %d times, want (2, nil), 3 times", retryCount, err, callCount)
+	}
+
+	// throttled until the max retries
+	callCount = 0
+	retryCount, err = driverretry.Call(context.Background(), "retry-test-config01", "AWS", func() error {
+		callCount++
+		return errors.New("RequestLimitExceeded: Request limit exceeded.")
+	})
+	if err == nil || retryCount != 3 || callCount != 4 {
+		t.Errorf("Call() = (%d, %v), called %d times, want (3, error), 4 times", retryCount, err, callCount)
+	}
+
+	// not retryable
+	callCount = 0
+	retryCount, err = driverretry.Call(context.Background(), "retry-test-config01", "AWS", func() error {
+		callCount++
+		return errors.New("InvalidParameterValue")
+	})
+	if err == nil || retryCount != 0 || callCount != 1 {
+		t.Errorf("Call() = (%d, %v), called %d times, want (0, error), 1 time", retryCount, err, callCount)
+	}
+
+	// no retry by the config of the CSP
+	config := testConfig()
+	noRetry := 0
+	config.PROVIDERS = map[string]driverretry.PROVIDERCONFIG{"AWS": {MAXRETRIES: &noRetry}}
+	driverretry.SetConfig(config)
+	callCount = 0
+	retryCount, _ = driverretry.Call(context.Background(), "retry-test-config01", "AWS", func() error {
+		callCount++
+		return errors.New("RequestLimitExceeded")
+	})
+	if retryCount != 0 || callCount != 1 {
+		t.Errorf("The call is retried with maxretries 0. retry: %d, called: %d", retryCount, callCount)
+	}
+}
+
+// the mutating call is run once, even with the throttling error.
+func TestCallOnce(t *testing.T) {
+	driverretry.SetConfig(testConfig())
+
+	callCount := 0
+	err := driverretry.CallOnce(context.Background(), "retry-test-config01", "AWS", func() error {
+		callCount++
+		return errors.New("RequestLimitExceeded: Request limit exceeded.")
+	})
+	if err == nil || callCount != 1 {
+		t.Errorf("CallOnce() = %v, called %d times, want error, 1 time", err, callCount)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	config := testConfig()
+	config.RATELIMIT.ENABLED = true
+	config.RATELIMIT.RPS = 20
+	config.RATELIMIT.BURST = 2
+	driverretry.SetConfig(config)
+	defer driverretry.RemoveConnection("retry-test-config02")
+
+	// 2 calls at once by the burst, and the next 4 calls every 50ms
+	start := time.Now()
+	for idx := 0; idx < 6; idx++ {
+		driverretry.Call(context.Background(), "retry-test-config02", "AZURE", func() error { return nil })
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("6 calls of 20 rps with burst 2 take %v, less than 150ms.", elapsed)
+	}
+
+	// the mutating calls are rate-limited too
+	start = time.Now()
+	for idx := 0; idx < 3; idx++ {
+		driverretry.CallOnce(context.Background(), "retry-test-config02", "AZURE", func() error { return nil })
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 calls after the burst of 20 rps take %v, less than 100ms.", elapsed)
+	}
+
+	// the other connection has its own limiter
+	start = time.Now()
+	driverretry.Call(context.Background(), "retry-test-config03", "AZURE", func() error { return nil })
+	driverretry.RemoveConnection("retry-test-config03")
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("The call of the other connection waits %v.", elapsed)
+	}
+}
+
+// the waiting for the backoff and the rate limit is stopped by the canceled request.
+func TestCallCanceled(t *testing.T) {
+	config := testConfig()
+	config.RETRY.INITIALBACKOFF = 10000
+	config.RETRY.MAXBACKOFF = 10000
+	config.RATELIMIT.ENABLED = true
+	config.RATELIMIT.RPS = 1
+	config.RATELIMIT.BURST = 1
+	driverretry.SetConfig(config)
+	defer driverretry.RemoveConnection("retry-test-config04")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the first call by the burst, and canceled while waiting for the backoff
+	callCount := 0
+	start := time.Now()
+	retryCount, err := driverretry.Call(ctx, "retry-test-config04", "AWS", func() error {
+		callCount++
+		return errors.New("RequestLimitExceeded: Request limit exceeded.")
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || retryCount != 1 || callCount != 1 || time.Since(start) > time.Second {
+		t.Errorf("Call() = (%d, %v), called %d times in %v, want (1, deadline exceeded), 1 time", retryCount, err, callCount, time.Since(start))
+	}
+
+	// the mutating call is not run after the canceled waiting for the rate limit
+	callCount = 0
+	err = driverretry.CallOnce(ctx, "retry-test-config04", "AWS", func() error {
+		callCount++
+		return nil
+	})
+	if err == nil || callCount != 0 {
+		t.Errorf("CallOnce() = %v, called %d times, want error, 0 time", err, callCount)
+	}
+}
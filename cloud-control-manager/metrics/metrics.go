@@ -0,0 +1,128 @@
+// Prometheus Metrics of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	call "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/call-log"
+)
+
+// All metrics are registered in the Spider's own registry,
+// and exported by the REST server(GET /spider/metrics).
+var registry = prometheus.NewRegistry()
+
+var (
+	//=========== REST Runtime
+	restRequestCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "spider_rest_requests_total",
+		Help: "The number of REST requests per route.",
+	}, []string{"method", "route", "code"})
+
+	restRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "spider_rest_request_duration_seconds",
+		Help:    "The latency of REST requests per route.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"method", "route"})
+
+	//=========== Driver Calls(CSP API Calls)
+	driverCallCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "spider_driver_calls_total",
+		Help: "The number of driver calls per CSP.",
+	}, []string{"cloudos", "resource_type", "api"})
+
+	driverCallErrorCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "spider_driver_call_errors_total",
+		Help: "The number of failed driver calls per CSP.",
+	}, []string{"cloudos", "resource_type", "api"})
+
+	driverCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "spider_driver_call_duration_seconds",
+		Help:    "The latency of driver calls per CSP.",
+		Buckets: []float64{0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+	}, []string{"cloudos", "resource_type", "api"})
+
+	//=========== SPLock
+	spLockWaitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "spider_splock_wait_seconds",
+		Help:    "The waiting time to acquire a SPLock.",
+		Buckets: []float64{0.001, 0.01, 0.1, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"lock", "mode"})
+
+	spLockMapSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "spider_splock_map_size",
+		Help: "The number of resource IDs in a SPLock map.",
+	}, []string{"lock"})
+
+	//=========== Connection Cache
+	connectionCacheCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "spider_connection_cache_requests_total",
+		Help: "The number of cloud connection requests by the result of the cache(hit|miss).",
+	}, []string{"result"})
+)
+
+func init() {
+	registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	registry.MustRegister(prometheus.NewGoCollector())
+
+	registry.MustRegister(restRequestCount, restRequestDuration)
+	registry.MustRegister(driverCallCount, driverCallErrorCount, driverCallDuration)
+	registry.MustRegister(spLockWaitDuration, spLockMapSize)
+	registry.MustRegister(connectionCacheCount)
+
+	// the driver calls are observed from the call-log
+	call.SetCloudLogObserver(func(callInfo call.CLOUDLOGSCHEMA) {
+		ObserveDriverCall(string(callInfo.CloudOS), string(callInfo.ResourceType),
+			callInfo.CloudOSAPI, callInfo.ElapsedTime, callInfo.ErrorMSG)
+	})
+}
+
+// http.Handler to export the metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// route: the route path, ex) "/spider/vm/:Name"
+func ObserveRESTRequest(method string, route string, code int, elapsed time.Duration) {
+	restRequestCount.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+	restRequestDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// elapsedTime: sec, "" if it was not measured, ex) "2.0201"
+func ObserveDriverCall(cloudOS string, resourceType string, api string, elapsedTime string, errorMSG string) {
+	driverCallCount.WithLabelValues(cloudOS, resourceType, api).Inc()
+	if errorMSG != "" {
+		driverCallErrorCount.WithLabelValues(cloudOS, resourceType, api).Inc()
+	}
+	if elapsed, err := strconv.ParseFloat(elapsedTime, 64); err == nil {
+		driverCallDuration.WithLabelValues(cloudOS, resourceType, api).Observe(elapsed)
+	}
+}
+
+// mode: "lock" | "rlock"
+func ObserveSPLockWait(lockName string, mode string, elapsed time.Duration) {
+	spLockWaitDuration.WithLabelValues(lockName, mode).Observe(elapsed.Seconds())
+}
+
+func SetSPLockMapSize(lockName string, size int) {
+	spLockMapSize.WithLabelValues(lockName).Set(float64(size))
+}
+
+func IncConnectionCacheHit() {
+	connectionCacheCount.WithLabelValues("hit").Inc()
+}
+
+func IncConnectionCacheMiss() {
+	connectionCacheCount.WithLabelValues("miss").Inc()
+}
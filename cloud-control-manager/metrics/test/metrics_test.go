@@ -0,0 +1,67 @@
+// Prometheus Metrics Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package metricstest
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	splock "github.com/cloud-barista/cb-spider/api-runtime/common-runtime/sp-lock"
+	"github.com/cloud-barista/cb-spider/cloud-control-manager/metrics"
+)
+
+func scrape(t *testing.T) string {
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/spider/metrics", nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	metrics.ObserveRESTRequest("GET", "/spider/vm/:Name", 200, 10*time.Millisecond)
+	metrics.ObserveDriverCall("MOCK", "VM", "StartVM()", "2.0201", "")
+	metrics.ObserveDriverCall("MOCK", "VM", "StartVM()", "", "VM does not exist!!")
+	metrics.IncConnectionCacheHit()
+	metrics.IncConnectionCacheMiss()
+
+	spLock := splock.New("test")
+	spLock.Lock("mock-config01", "vm-01")
+	spLock.RLock("mock-config01", "vm-02")
+
+	body := scrape(t)
+	expectedList := []string{
+		`spider_rest_requests_total{code="200",method="GET",route="/spider/vm/:Name"} 1`,
+		`spider_rest_request_duration_seconds_count{method="GET",route="/spider/vm/:Name"} 1`,
+		`spider_driver_calls_total{api="StartVM()",cloudos="MOCK",resource_type="VM"} 2`,
+		`spider_driver_call_errors_total{api="StartVM()",cloudos="MOCK",resource_type="VM"} 1`,
+		`spider_driver_call_duration_seconds_count{api="StartVM()",cloudos="MOCK",resource_type="VM"} 1`,
+		`spider_connection_cache_requests_total{result="hit"} 1`,
+		`spider_connection_cache_requests_total{result="miss"} 1`,
+		`spider_splock_map_size{lock="test"} 2`,
+		`spider_splock_wait_seconds_count{lock="test",mode="lock"} 1`,
+		`spider_splock_wait_seconds_count{lock="test",mode="rlock"} 1`,
+	}
+	for _, expected := range expectedList {
+		if !strings.Contains(body, expected) {
+			t.Errorf("The metrics do not have %s", expected)
+		}
+	}
+
+	spLock.Unlock("mock-config01", "vm-01")
+	spLock.RUnlock("mock-config01", "vm-02")
+	if body = scrape(t); !strings.Contains(body, `spider_splock_map_size{lock="test"} 0`) {
+		t.Errorf("The SPLock map size is not 0 after unlocking.")
+	}
+}
@@ -0,0 +1,130 @@
+// Package for VM's SSH and SCP of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package sshrun
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/bramvdbogaerde/go-scp"
+	"golang.org/x/crypto/ssh"
+)
+
+//================ Command with separated stdout, stderr and exit code
+
+// the max size of the stdout and the stderr each, the rest is discarded.
+const MaxCommandOutputSize = 4 * 1024 * 1024
+
+type CommandResult struct {
+	Stdout    string
+	Stderr    string
+	ExitCode  int  // -1: the command did not exit normally, ex) timed out
+	TimedOut  bool // true: the command was stopped by the timeout
+	Truncated bool // true: the stdout or the stderr exceeded MaxCommandOutputSize
+}
+
+// RunCommandContext runs the cmd on the connected client, the stdin is optional(nil).
+// The non-zero exit code is returned in the result, not as an error.
+// If the ctx is done before the command exits, the client is closed to stop the command.
+func RunCommandContext(ctx context.Context, client scp.Client, cmd string, stdin io.Reader) (CommandResult, error) {
+	cblog.Info("call RunCommandContext()")
+
+	stdout := &limitedBuffer{limit: MaxCommandOutputSize}
+	stderr := &limitedBuffer{limit: MaxCommandOutputSize}
+
+	session := client.Session
+	session.Stdout = stdout
+	session.Stderr = stderr
+	if stdin != nil {
+		session.Stdin = stdin
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(cmd)
+	}()
+
+	var err error
+	ctxDone := false
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		ctxDone = true
+		client.Close()
+		<-done // wait for the stdout and the stderr to be flushed
+	}
+
+	result := CommandResult{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+	if ctxDone {
+		result.ExitCode = -1
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			result.TimedOut = true
+			return result, nil
+		}
+		return result, ctx.Err()
+	}
+
+	var exitErr *ssh.ExitError
+	var exitMissingErr *ssh.ExitMissingError
+	switch {
+	case err == nil:
+		result.ExitCode = 0
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	case errors.As(err, &exitMissingErr):
+		result.ExitCode = -1
+	default:
+		return result, err
+	}
+	return result, nil
+}
+
+//=============== for One Call Service
+
+// SSHRunContext connects, runs the cmd with RunCommandContext() and closes the connection.
+func SSHRunContext(ctx context.Context, sshInfo SSHInfo, cmd string, stdin io.Reader) (CommandResult, error) {
+	cblog.Info("call SSHRunContext()")
+
+	sshCli, err := Connect(sshInfo)
+	if err != nil {
+		return CommandResult{ExitCode: -1}, err
+	}
+	defer Close(sshCli)
+
+	return RunCommandContext(ctx, sshCli, cmd, stdin)
+}
+
+// keeps the first limit bytes, and discards the rest without an error not to block the session.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remain := b.limit - b.buf.Len()
+	if remain < len(p) {
+		b.truncated = true
+		if remain > 0 {
+			b.buf.Write(p[:remain])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,230 @@
+// Package for VM's SSH and SCP of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package sshrun
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bramvdbogaerde/go-scp"
+)
+
+//================ File Transfer with the size limit and the checksum
+
+type FileTransferInfo struct {
+	Mode   string // ex) "0644"
+	Size   int64  // bytes
+	SHA256 string // hex string of the transferred bytes
+}
+
+// FileHeaderWriter is given the mode and the size of the remote file before the content is written,
+// ex) to set the headers of an HTTP response streaming the download.
+type FileHeaderWriter interface {
+	io.Writer
+	WriteFileHeader(mode string, size int64) error
+}
+
+// UploadContext uploads the size bytes of the r to the remotePath with the mode(ex: "0644").
+// If the ctx is done before the upload is completed, the client is closed to stop the upload.
+func UploadContext(ctx context.Context, client scp.Client, r io.Reader, size int64, remotePath string, mode string) (FileTransferInfo, error) {
+	cblog.Info("call UploadContext()")
+
+	// the go-scp client times out by its own timer, the ctx controls the timeout instead.
+	client.Timeout = time.Duration(math.MaxInt64)
+	stop := closeOnDone(ctx, client)
+	defer stop()
+
+	hash := sha256.New()
+	passThru := func(r io.Reader, total int64) io.Reader {
+		return io.TeeReader(r, hash)
+	}
+	if err := client.CopyPassThru(r, remotePath, mode, size, passThru); err != nil {
+		return FileTransferInfo{}, ctxErrOr(ctx, err)
+	}
+	return FileTransferInfo{Mode: mode, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// DownloadContext downloads the remotePath to the w by the scp source protocol.
+// (go-scp v1.0.0 CopyFromRemote() blocks until its timeout when the remote returns an error.)
+// It fails before writing anything if the remote file is larger than the maxSize.
+// If the w is a FileHeaderWriter, WriteFileHeader() is called before the content.
+// If the ctx is done before the download is completed, the client is closed to stop the download.
+func DownloadContext(ctx context.Context, client scp.Client, remotePath string, maxSize int64, w io.Writer) (FileTransferInfo, error) {
+	cblog.Info("call DownloadContext()")
+
+	stop := closeOnDone(ctx, client)
+	defer stop()
+
+	info, err := download(client, remotePath, maxSize, w)
+	if err != nil {
+		return FileTransferInfo{}, ctxErrOr(ctx, err)
+	}
+	return info, nil
+}
+
+func download(client scp.Client, remotePath string, maxSize int64, w io.Writer) (FileTransferInfo, error) {
+	session := client.Session
+	in, err := session.StdinPipe()
+	if err != nil {
+		return FileTransferInfo{}, err
+	}
+	defer in.Close()
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return FileTransferInfo{}, err
+	}
+	out := bufio.NewReader(stdout)
+
+	if err := session.Start("scp -f " + shellQuote(remotePath)); err != nil {
+		return FileTransferInfo{}, err
+	}
+	if err := ack(in); err != nil {
+		return FileTransferInfo{}, err
+	}
+
+	// ex) "C0644 1234 file.txt\n"
+	header, err := readSCPLine(out)
+	if err != nil {
+		return FileTransferInfo{}, err
+	}
+	if !strings.HasPrefix(header, "C") {
+		return FileTransferInfo{}, fmt.Errorf("scp: %s is not a regular file", remotePath)
+	}
+	fields := strings.SplitN(strings.TrimPrefix(header, "C"), " ", 3)
+	if len(fields) != 3 {
+		return FileTransferInfo{}, fmt.Errorf("scp: invalid file header: %q", header)
+	}
+	mode := fields[0]
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return FileTransferInfo{}, fmt.Errorf("scp: invalid file size: %q", header)
+	}
+	if maxSize > 0 && size > maxSize {
+		return FileTransferInfo{}, fmt.Errorf("The size(%d bytes) of %s exceeds the limit(%d bytes)!", size, remotePath, maxSize)
+	}
+	if headerWriter, ok := w.(FileHeaderWriter); ok {
+		if err := headerWriter.WriteFileHeader(mode, size); err != nil {
+			return FileTransferInfo{}, err
+		}
+	}
+	if err := ack(in); err != nil {
+		return FileTransferInfo{}, err
+	}
+
+	hash := sha256.New()
+	if _, err := io.CopyN(io.MultiWriter(w, hash), out, size); err != nil {
+		return FileTransferInfo{}, err
+	}
+	if err := readSCPStatus(out); err != nil {
+		return FileTransferInfo{}, err
+	}
+	if err := ack(in); err != nil {
+		return FileTransferInfo{}, err
+	}
+	in.Close()
+	if err := session.Wait(); err != nil {
+		return FileTransferInfo{}, err
+	}
+	return FileTransferInfo{Mode: mode, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+func ack(w io.Writer) error {
+	_, err := w.Write([]byte{0})
+	return err
+}
+
+// reads a protocol line, the warning(1) and the error(2) of the remote are returned as an error.
+func readSCPLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if line[0] == 1 || line[0] == 2 {
+		return "", fmt.Errorf("scp: %s", strings.TrimSpace(line[1:]))
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+func readSCPStatus(r *bufio.Reader) error {
+	status, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if status == 0 {
+		return nil
+	}
+	msg, _ := r.ReadString('\n')
+	return fmt.Errorf("scp: %s", strings.TrimSpace(msg))
+}
+
+// single-quotes the s for the remote shell, the single quotes in the s are escaped.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// closes the client if the ctx is done before the returned stop function is called.
+func closeOnDone(ctx context.Context, client scp.Client) func() {
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-stopCh:
+		}
+	}()
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
+
+func ctxErrOr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("%v: %v", ctx.Err(), err)
+	}
+	return err
+}
+
+//=============== for One Call Service
+
+// SSHUploadContext connects, uploads with UploadContext() and closes the connection.
+func SSHUploadContext(ctx context.Context, sshInfo SSHInfo, r io.Reader, size int64, remotePath string, mode string) (FileTransferInfo, error) {
+	cblog.Info("call SSHUploadContext()")
+
+	sshCli, err := Connect(sshInfo)
+	if err != nil {
+		return FileTransferInfo{}, err
+	}
+	defer Close(sshCli)
+
+	return UploadContext(ctx, sshCli, r, size, remotePath, mode)
+}
+
+// SSHDownloadContext connects, downloads with DownloadContext() and closes the connection.
+func SSHDownloadContext(ctx context.Context, sshInfo SSHInfo, remotePath string, maxSize int64, w io.Writer) (FileTransferInfo, error) {
+	cblog.Info("call SSHDownloadContext()")
+
+	sshCli, err := Connect(sshInfo)
+	if err != nil {
+		return FileTransferInfo{}, err
+	}
+	defer Close(sshCli)
+
+	return DownloadContext(ctx, sshCli, remotePath, maxSize, w)
+}
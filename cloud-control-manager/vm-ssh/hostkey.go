@@ -0,0 +1,98 @@
+// Package for VM's SSH and SCP of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package sshrun
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+//================ Host Key Verification (Trust On First Use)
+
+const hostKeyChangedMSG = "host key verification failed"
+
+// HostKeyChangedError is returned when the presented host key differs from the pinned key.
+// The ssh handshake wraps it as a string, so use IsHostKeyChanged() to check the returned error.
+type HostKeyChangedError struct {
+	Host                 string
+	PinnedFingerprint    string
+	PresentedFingerprint string
+}
+
+func (e *HostKeyChangedError) Error() string {
+	return fmt.Sprintf("%s: the host key of %s has changed (pinned: %s, presented: %s). "+
+		"Someone could be eavesdropping, or the VM was re-created with a new host key. "+
+		"Reset the pinned host key of the VM if the change is expected.",
+		hostKeyChangedMSG, e.Host, e.PinnedFingerprint, e.PresentedFingerprint)
+}
+
+func IsHostKeyChanged(err error) bool {
+	if err == nil {
+		return false
+	}
+	var changedErr *HostKeyChangedError
+	if errors.As(err, &changedErr) {
+		return true
+	}
+	return strings.Contains(err.Error(), hostKeyChangedMSG)
+}
+
+// MarshalHostKey returns the host key in the authorized_keys format without the new line.
+// ex) "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFe..."
+func MarshalHostKey(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+// PinnedHostKeyCallback verifies the presented host key with the pinned key(authorized_keys format).
+// If the pinnedKey is empty, the presented key is trusted on first use and passed to the pinFunc.
+func PinnedHostKeyCallback(pinnedKey string, pinFunc func(hostname string, key ssh.PublicKey) error) (ssh.HostKeyCallback, error) {
+	if pinnedKey == "" {
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			return pinFunc(hostname, key)
+		}, nil
+	}
+
+	pinned, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinnedKey))
+	if err != nil {
+		return nil, err
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if !bytes.Equal(pinned.Marshal(), key.Marshal()) {
+			return &HostKeyChangedError{
+				Host:                 hostname,
+				PinnedFingerprint:    ssh.FingerprintSHA256(pinned),
+				PresentedFingerprint: ssh.FingerprintSHA256(key),
+			}
+		}
+		return nil
+	}, nil
+}
+
+// PinnedHostKeyAlgorithms returns the host key algorithms of the pinned key,
+// so the server presents the same type of key which was pinned.
+// returns nil(the default algorithms) if the pinnedKey is empty.
+func PinnedHostKeyAlgorithms(pinnedKey string) ([]string, error) {
+	if pinnedKey == "" {
+		return nil, nil
+	}
+
+	pinned, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinnedKey))
+	if err != nil {
+		return nil, err
+	}
+	if pinned.Type() == ssh.KeyAlgoRSA {
+		return []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}, nil
+	}
+	return []string{pinned.Type()}, nil
+}
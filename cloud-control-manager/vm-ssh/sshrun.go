@@ -36,13 +36,23 @@ type SSHInfo struct {
                                 //              ...`)
         ServerPort      string  // ex) "node12:22"
 	Timeout		int	// ex) 3 (= 3sec)
+
+	HostKeyCallback		ssh.HostKeyCallback	// nil: not verified, ex) PinnedHostKeyCallback()
+	HostKeyAlgorithms	[]string		// nil: default algorithms, ex) PinnedHostKeyAlgorithms()
+
+	JumpHosts		[]JumpHostInfo		// nil: direct connection, ex) bastion hosts in order
 }
 //====================================================================
 
 func Connect(sshInfo SSHInfo) (scp.Client, error) {
 	cblog.Info("call Connect()")
 
-        clientConfig, _ := getClientConfig(sshInfo.UserName, sshInfo.PrivateKey, ssh.InsecureIgnoreHostKey(), sshInfo.Timeout)
+	if len(sshInfo.JumpHosts) > 0 {
+		return connectByJumpHosts(sshInfo)
+	}
+
+        clientConfig, _ := getClientConfig(sshInfo.UserName, sshInfo.PrivateKey, getHostKeyCallback(sshInfo.HostKeyCallback), sshInfo.Timeout)
+	clientConfig.HostKeyAlgorithms = sshInfo.HostKeyAlgorithms
         client := scp.NewClient(sshInfo.ServerPort, &clientConfig)
         err := client.Connect()
         return client, err
@@ -53,18 +63,30 @@ type SSHKeyPathInfo struct {
         UserName        string  // ex) "root"
         KeyPath     	string 	// ex) "/root/.ssh/id_rsa // You should use the full path.
         ServerPort      string  // ex) "node12:22"
+
+	HostKeyCallback		ssh.HostKeyCallback	// nil: not verified, ex) PinnedHostKeyCallback()
+	HostKeyAlgorithms	[]string		// nil: default algorithms, ex) PinnedHostKeyAlgorithms()
 }
 //====================================================================
 
 func ConnectKeyPath(sshKeyPathInfo SSHKeyPathInfo) (scp.Client, error) {
         cblog.Info("call ConnectKeyPath()")
 
-	clientConfig, _ := auth.PrivateKey(sshKeyPathInfo.UserName, sshKeyPathInfo.KeyPath, ssh.InsecureIgnoreHostKey())
+	clientConfig, _ := auth.PrivateKey(sshKeyPathInfo.UserName, sshKeyPathInfo.KeyPath, getHostKeyCallback(sshKeyPathInfo.HostKeyCallback))
+	clientConfig.HostKeyAlgorithms = sshKeyPathInfo.HostKeyAlgorithms
         client := scp.NewClient(sshKeyPathInfo.ServerPort, &clientConfig)
         err := client.Connect()
         return client, err
 }
 
+// for the backward compatibility, the host key is not verified without a callback.
+func getHostKeyCallback(hostKeyCallback ssh.HostKeyCallback) ssh.HostKeyCallback {
+	if hostKeyCallback == nil {
+		return ssh.InsecureIgnoreHostKey()
+	}
+	return hostKeyCallback
+}
+
 func getClientConfig(username string, privateKey []byte, keyCallBack ssh.HostKeyCallback, timeout int) (ssh.ClientConfig, error) {
 
 	signer, err := ssh.ParsePrivateKey(privateKey)
@@ -2,7 +2,7 @@ package cloudos
 
 import (
 	"fmt"
-	"github.com/fsnotify/fsnotify"
+	configwatcher "github.com/cloud-barista/cb-spider/cloud-control-manager/config-watcher"
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"os"
@@ -56,7 +56,17 @@ func GetCloudOSMetaInfo(cloudOS string) (CloudOSMetaInfo, error) {
 
 	readMetaYaml(confFileName)
 
-	go setFSNotify(confFileName)
+	// hot reload: the changes of the meta file are applied without restart.
+	_, err = configwatcher.Watch(confFileName, func() {
+		cblog.Info("modified file:" + confFileName)
+		err := readMetaYaml(confFileName)
+		if err != nil {
+			cblog.Error(err)
+		}
+	})
+	if err != nil {
+		cblog.Error(err)
+	}
 
 	rwMutex.Lock()
 	mInfo := metaInfo[cloudOS]
@@ -141,45 +151,3 @@ func splitAndTrim(in string) []string {
 	}
 	return ins
 }
-
-// ref) https://github.com/fsnotify/fsnotify
-// Thanks, fsnotify
-func setFSNotify(confFileName string) {
-
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		cblog.Fatal(err)
-	}
-	defer watcher.Close()
-
-	done := make(chan bool)
-	go func() {
-		for {
-			select {
-			case event, ok := <-watcher.Events:
-				if !ok {
-					return
-				}
-				if event.Op&fsnotify.Write == fsnotify.Write {
-					cblog.Info("modified file:" + event.Name)
-					err := readMetaYaml(confFileName)
-					if err != nil {
-						cblog.Fatal(err)
-					}
-				}
-			case err, ok := <-watcher.Errors:
-				if !ok {
-					return
-				}
-				cblog.Error(err)
-			}
-		}
-	}()
-
-	err = watcher.Add(confFileName)
-	if err != nil {
-		cblog.Fatal(err)
-	}
-
-	<-done
-}
@@ -0,0 +1,49 @@
+// Cloud Info Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cloudos
+
+import (
+	"sync"
+)
+
+// Kind of the meta info managed by cloud-info-manager
+type InfoKind string
+
+const (
+	DRIVER           InfoKind = "driver"
+	CREDENTIAL       InfoKind = "credential"
+	REGION           InfoKind = "region"
+	CONNECTIONCONFIG InfoKind = "connectionconfig"
+)
+
+// Called after a meta info is registered(created or updated) or deleted.
+// ex) handler(CREDENTIAL, "aws-credential01")
+type InfoChangeHandler func(kind InfoKind, infoName string)
+
+// The upper managers(ex. cloud-control-manager) can not be imported here because of import cycle,
+// so they register their handlers to be notified of changes.
+var infoChangeHandlerList []InfoChangeHandler
+
+var infoChangeHandlerLock sync.RWMutex
+
+func AddInfoChangeHandler(handler InfoChangeHandler) {
+	infoChangeHandlerLock.Lock()
+	defer infoChangeHandlerLock.Unlock()
+
+	infoChangeHandlerList = append(infoChangeHandlerList, handler)
+}
+
+func NotifyInfoChanged(kind InfoKind, infoName string) {
+	infoChangeHandlerLock.RLock()
+	defer infoChangeHandlerLock.RUnlock()
+
+	for _, handler := range infoChangeHandlerList {
+		handler(kind, infoName)
+	}
+}
@@ -0,0 +1,274 @@
+// API Token Info. Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package authinfomanager
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	"github.com/cloud-barista/cb-store/config"
+	"github.com/sirupsen/logrus"
+)
+
+var cblog *logrus.Logger
+
+func init() {
+	cblog = config.Cblogger
+}
+
+// Roles of the API tokens, a higher role has all permissions of the lower roles.
+// readonly: GET APIs
+// operator: readonly + create/delete/control the cloud resources
+// admin:    operator + cloud info(driver, credential, region, connection config), tokens and server settings
+const (
+	READONLY = "readonly"
+	OPERATOR = "operator"
+	ADMIN    = "admin"
+)
+
+var roleLevelMap = map[string]int{
+	READONLY: 1,
+	OPERATOR: 2,
+	ADMIN:    3,
+}
+
+// prefix of the token string to distinguish it from the other secrets
+const tokenPrefix = "spider_"
+
+//====================================================================
+type TokenInfo struct {
+	TokenName          string    // ex) "ops-token01"
+	Role               string    // admin | operator | readonly
+	ConnectionNameList []string  // empty: all connections, ex) ["aws-ohio-config", "gcp-iowa-config"]
+	CreatedTime        time.Time // ex) "2022-10-17T09:00:00+09:00"
+}
+
+//====================================================================
+
+// true if the role has the permission of the requiredRole.
+func HasPermission(role string, requiredRole string) bool {
+	return roleLevelMap[role] > 0 && roleLevelMap[role] >= roleLevelMap[requiredRole]
+}
+
+// true if the token can access the connection.
+func (tokenInfo *TokenInfo) AllowConnection(connectionName string) bool {
+	if len(tokenInfo.ConnectionNameList) == 0 {
+		return true
+	}
+	for _, name := range tokenInfo.ConnectionNameList {
+		if name == connectionName {
+			return true
+		}
+	}
+	return false
+}
+
+// 1. check params
+// 2. generate a token
+// 3. insert the hash of the token into cb-store
+// The token string is returned only at this time, it is not stored.
+func CreateToken(tokenName string, role string, connectionNameList []string) (*TokenInfo, string, error) {
+	cblog.Info("call CreateToken()")
+
+	tokenName = strings.TrimSpace(tokenName)
+	role = strings.ToLower(strings.TrimSpace(role))
+	err := checkParams(tokenName, role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// trim user inputs and check the connection configs
+	trimmedNameList := []string{}
+	for _, connectionName := range connectionNameList {
+		connectionName = strings.TrimSpace(connectionName)
+		if connectionName == "" {
+			continue
+		}
+		if _, err := ccim.GetConnectionConfig(connectionName); err != nil {
+			cblog.Error(err)
+			return nil, "", err
+		}
+		trimmedNameList = append(trimmedNameList, connectionName)
+	}
+
+	// check the existence of the key to be inserted
+	if _, err := getInfo(tokenName); err == nil {
+		return nil, "", fmt.Errorf(tokenName + ": already exists!")
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		cblog.Error(err)
+		return nil, "", err
+	}
+
+	tokenInfo := &TokenInfo{tokenName, role, trimmedNameList, time.Now().Round(time.Second)}
+
+	cblog.Debug("insert metainfo into store")
+	err = insertInfo(tokenInfo, hashToken(token))
+	if err != nil {
+		cblog.Error(err)
+		return nil, "", err
+	}
+	setHasTokenCache(true)
+
+	return tokenInfo, token, nil
+}
+
+func ListToken() ([]*TokenInfo, error) {
+	cblog.Info("call ListToken()")
+
+	storedInfoList, err := listInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenInfoList := []*TokenInfo{}
+	for _, storedInfo := range storedInfoList {
+		tokenInfoList = append(tokenInfoList, &storedInfo.TokenInfo)
+	}
+	return tokenInfoList, nil
+}
+
+func GetToken(tokenName string) (*TokenInfo, error) {
+	cblog.Info("call GetToken()")
+
+	if tokenName == "" {
+		return nil, fmt.Errorf("TokenName is empty!")
+	}
+
+	storedInfo, err := getInfo(tokenName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return &storedInfo.TokenInfo, nil
+}
+
+func RevokeToken(tokenName string) (bool, error) {
+	cblog.Info("call RevokeToken()")
+
+	if tokenName == "" {
+		return false, fmt.Errorf("TokenName is empty!")
+	}
+
+	result, err := deleteInfo(tokenName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	// the last token can be revoked, list the tokens again at the next HasToken()
+	resetHasTokenCache()
+	return result, nil
+}
+
+// HasToken() is called by every REST request, so the result is cached.
+// CreateToken() and RevokeToken() update the cache, and it is refreshed
+// after hasTokenCacheTTL for the tokens changed by the other servers sharing the store.
+var hasTokenCacheTTL = 10 * time.Second
+
+var hasTokenLock sync.Mutex
+var hasTokenCached bool
+var hasTokenCheckedTime time.Time // zero: not cached
+
+func setHasTokenCache(hasToken bool) {
+	hasTokenLock.Lock()
+	defer hasTokenLock.Unlock()
+	hasTokenCached = hasToken
+	hasTokenCheckedTime = time.Now()
+}
+
+func resetHasTokenCache() {
+	hasTokenLock.Lock()
+	defer hasTokenLock.Unlock()
+	hasTokenCheckedTime = time.Time{}
+}
+
+// true if at least one token is registered.
+func HasToken() bool {
+	hasTokenLock.Lock()
+	defer hasTokenLock.Unlock()
+
+	if !hasTokenCheckedTime.IsZero() && time.Since(hasTokenCheckedTime) < hasTokenCacheTTL {
+		return hasTokenCached
+	}
+
+	storedInfoList, err := listInfo()
+	if err != nil {
+		cblog.Error(err)
+		// keep the last result, the auth must not be disabled by an error of the store
+		return hasTokenCached
+	}
+	hasTokenCached = len(storedInfoList) > 0
+	hasTokenCheckedTime = time.Now()
+	return hasTokenCached
+}
+
+// get the TokenInfo of the token string.
+// tokenName: "" if the name is not given, ex) Bearer token
+func Authenticate(tokenName string, token string) (*TokenInfo, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, fmt.Errorf("invalid token!")
+	}
+	tokenHash := hashToken(token)
+
+	var storedInfoList []*storedTokenInfo
+	if tokenName != "" {
+		storedInfo, err := getInfo(tokenName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token!")
+		}
+		storedInfoList = []*storedTokenInfo{storedInfo}
+	} else {
+		var err error
+		storedInfoList, err = listInfo()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, storedInfo := range storedInfoList {
+		if storedInfo.matchHash(tokenHash) {
+			return &storedInfo.TokenInfo, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid token!")
+}
+
+func checkParams(tokenName string, role string) error {
+	if tokenName == "" {
+		return fmt.Errorf("TokenName is empty!")
+	}
+	if strings.ContainsAny(tokenName, "/ ") {
+		return fmt.Errorf("TokenName(%s) should not have '/' or ' '!", tokenName)
+	}
+	if _, ok := roleLevelMap[role]; !ok {
+		return fmt.Errorf("Role(%s) should be one of %s, %s and %s!", role, ADMIN, OPERATOR, READONLY)
+	}
+	return nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
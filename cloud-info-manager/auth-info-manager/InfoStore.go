@@ -0,0 +1,100 @@
+// TokenInfo <-> CB-Store Handler for API Token Info. Manager.
+// API Token Info. Manager of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package authinfomanager
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+	"github.com/cloud-barista/cb-store/utils"
+)
+
+var store icbs.Store
+
+func init() {
+	store = cbstore.GetStore()
+}
+
+// format
+// /auth-info-spaces/tokens/<TokenName> [json of storedTokenInfo]
+// ex)
+// /auth-info-spaces/tokens/ops-token01 [{"TokenName":"ops-token01","Role":"operator",...,"TokenHash":"9f86d08..."}]
+
+const tokenSpace = "/auth-info-spaces/tokens/"
+
+// Only the SHA-256 hash of the token is stored.
+type storedTokenInfo struct {
+	TokenInfo
+	TokenHash string
+}
+
+func (storedInfo *storedTokenInfo) matchHash(tokenHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(storedInfo.TokenHash), []byte(tokenHash)) == 1
+}
+
+func insertInfo(tokenInfo *TokenInfo, tokenHash string) error {
+	value, err := json.Marshal(&storedTokenInfo{*tokenInfo, tokenHash})
+	if err != nil {
+		return err
+	}
+	return store.Put(tokenSpace+tokenInfo.TokenName, string(value))
+}
+
+func listInfo() ([]*storedTokenInfo, error) {
+	keyValueList, err := store.GetList(tokenSpace, true)
+	if err != nil {
+		return nil, err
+	}
+
+	storedInfoList := []*storedTokenInfo{}
+	for _, kv := range keyValueList {
+		storedInfo := &storedTokenInfo{}
+		if err := json.Unmarshal([]byte(kv.Value), storedInfo); err != nil {
+			return nil, fmt.Errorf("%s: %v", kv.Key, err)
+		}
+		storedInfoList = append(storedInfoList, storedInfo)
+	}
+	return storedInfoList, nil
+}
+
+func getInfo(tokenName string) (*storedTokenInfo, error) {
+	// key is not the key of cb-store, so we have to use GetList()
+	keyValueList, err := store.GetList(tokenSpace+tokenName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// keyValueList can have ~/tokenName-01, so we have to check the sameness of tokenName.
+	for _, kv := range keyValueList {
+		if utils.GetNodeValue(kv.Key, 3) == tokenName {
+			storedInfo := &storedTokenInfo{}
+			if err := json.Unmarshal([]byte(kv.Value), storedInfo); err != nil {
+				return nil, fmt.Errorf("%s: %v", kv.Key, err)
+			}
+			return storedInfo, nil
+		}
+	}
+	return nil, fmt.Errorf(tokenName + ": does not exist!")
+}
+
+func deleteInfo(tokenName string) (bool, error) {
+	if _, err := getInfo(tokenName); err != nil {
+		return false, err
+	}
+
+	err := store.Delete(tokenSpace + tokenName)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
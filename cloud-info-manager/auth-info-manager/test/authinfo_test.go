@@ -0,0 +1,113 @@
+// Test for API Token Info. Manager.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	cbstore "github.com/cloud-barista/cb-store"
+	aim "github.com/cloud-barista/cb-spider/cloud-info-manager/auth-info-manager"
+)
+
+const testTokenName = "authinfo-test-token01"
+
+func TestTokenCreateAuthenticateRevoke(t *testing.T) {
+	aim.RevokeToken(testTokenName)
+
+	tokenInfo, token, err := aim.CreateToken(testTokenName, "Operator", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokenInfo.Role != aim.OPERATOR || !strings.HasPrefix(token, "spider_") {
+		t.Errorf("created token: %v, %s", tokenInfo, token)
+	}
+	if !aim.HasToken() {
+		t.Error("HasToken() should be true after CreateToken()")
+	}
+
+	// the same name
+	if _, _, err := aim.CreateToken(testTokenName, aim.ADMIN, nil); err == nil {
+		t.Error("CreateToken() with an existing name should return an error!")
+	}
+
+	// only the hash of the token is stored
+	keyValueList, err := cbstore.GetStore().GetList("/auth-info-spaces/tokens/"+testTokenName, true)
+	if err != nil || len(keyValueList) != 1 {
+		t.Fatalf("The stored token of %s does not exist: %v", testTokenName, err)
+	}
+	if strings.Contains(keyValueList[0].Value, token) {
+		t.Error("The token string should not be stored!")
+	}
+
+	// Bearer token and Basic auth with the TokenName
+	for _, tokenName := range []string{"", testTokenName} {
+		authInfo, err := aim.Authenticate(tokenName, token)
+		if err != nil || authInfo.TokenName != testTokenName {
+			t.Errorf("Authenticate(%q): %v, %v", tokenName, authInfo, err)
+		}
+	}
+	if _, err := aim.Authenticate(testTokenName, token+"0"); err == nil {
+		t.Error("Authenticate() with a wrong token should return an error!")
+	}
+	if _, err := aim.Authenticate("authinfo-test-none", token); err == nil {
+		t.Error("Authenticate() with a wrong TokenName should return an error!")
+	}
+
+	result, err := aim.RevokeToken(testTokenName)
+	if err != nil || !result {
+		t.Errorf("RevokeToken(): %v, %v", result, err)
+	}
+	if _, err := aim.Authenticate("", token); err == nil {
+		t.Error("Authenticate() with a revoked token should return an error!")
+	}
+	// the cached HasToken() should be updated by RevokeToken()
+	if tokenInfoList, err := aim.ListToken(); err == nil && len(tokenInfoList) == 0 && aim.HasToken() {
+		t.Error("HasToken() should be false after the last token is revoked")
+	}
+}
+
+func TestTokenParams(t *testing.T) {
+	if _, _, err := aim.CreateToken(testTokenName, "superuser", nil); err == nil {
+		t.Error("CreateToken() with a wrong role should return an error!")
+	}
+	if _, _, err := aim.CreateToken("authinfo/test", aim.ADMIN, nil); err == nil {
+		t.Error("CreateToken() with '/' in the name should return an error!")
+	}
+	if _, _, err := aim.CreateToken(testTokenName, aim.READONLY, []string{"authinfo-test-none-config"}); err == nil {
+		t.Error("CreateToken() with a not existing connection should return an error!")
+	}
+}
+
+func TestRolePermission(t *testing.T) {
+	testList := []struct {
+		role, requiredRole string
+		expected           bool
+	}{
+		{aim.ADMIN, aim.OPERATOR, true},
+		{aim.OPERATOR, aim.OPERATOR, true},
+		{aim.OPERATOR, aim.ADMIN, false},
+		{aim.READONLY, aim.READONLY, true},
+		{aim.READONLY, aim.OPERATOR, false},
+		{"unknown", aim.READONLY, false},
+	}
+	for _, test := range testList {
+		if aim.HasPermission(test.role, test.requiredRole) != test.expected {
+			t.Errorf("HasPermission(%s, %s) should be %v", test.role, test.requiredRole, test.expected)
+		}
+	}
+
+	tokenInfo := &aim.TokenInfo{ConnectionNameList: []string{"aws-ohio-config"}}
+	if !tokenInfo.AllowConnection("aws-ohio-config") || tokenInfo.AllowConnection("gcp-iowa-config") {
+		t.Error("AllowConnection() of a scoped token")
+	}
+	if !(&aim.TokenInfo{}).AllowConnection("gcp-iowa-config") {
+		t.Error("AllowConnection() of an unscoped token")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"github.com/cloud-barista/cb-store/config"
 	"github.com/sirupsen/logrus"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager"
 	// import cycle mini "github.com/cloud-barista/cb-spider/spider-mini/mini"
 )
 
@@ -79,6 +80,7 @@ func CreateConnectionConfig(configName string, providerName string, driverName s
 		cblog.Error(err)
 		return nil, err
 	}
+	cim.NotifyInfoChanged(cim.CONNECTIONCONFIG, configName)
 
 	cncInfo := &ConnectionConfigInfo{configName, providerName, driverName, credentialName, regionName}
 
@@ -134,6 +136,7 @@ func DeleteConnectionConfig(configName string) (bool, error) {
 		cblog.Error(err)
 		return false, err
 	}
+	cim.NotifyInfoChanged(cim.CONNECTIONCONFIG, configName)
 
 	return result, nil
 }
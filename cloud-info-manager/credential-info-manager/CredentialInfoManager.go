@@ -16,12 +16,6 @@ import (
 	cim "github.com/cloud-barista/cb-spider/cloud-info-manager"
 
 	"github.com/sirupsen/logrus"
-
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"encoding/base64"
-	"io"
 )
 
 var cblog *logrus.Logger
@@ -61,7 +55,7 @@ func RegisterCredential(credentialName string, providerName string, keyValueInfo
 
 	cblog.Debug("insert metainfo into store")
 
-        err = encryptKeyValueList(keyValueInfoList)
+        err = encryptKeyValueList(credentialName, keyValueInfoList)
         if err != nil {
                 return &CredentialInfo{}, err
 	}
@@ -71,6 +65,7 @@ func RegisterCredential(credentialName string, providerName string, keyValueInfo
 		cblog.Error(err)
 		return nil, err
 	}
+	cim.NotifyInfoChanged(cim.CREDENTIAL, credentialName)
 
 	// Hide credential data for security
         kvList := []icbs.KeyValue{}
@@ -148,86 +143,90 @@ func GetCredentialDecrypt(credentialName string) (*CredentialInfo, error) {
                 return nil, err
         }
 
-	err = decryptKeyValueList(crdInfo.KeyValueInfoList)	
+	// the values stored before versioning are decrypted with the built-in key
+	err = decryptKeyValueList(credentialName, crdInfo.KeyValueInfoList, true)
 	if err != nil {
 		return &CredentialInfo{}, err
 	}
 	return crdInfo, nil
 }
 
-// @todo env by powerkim, 2020.06.01.
-var spider_key = []byte("cloud-barista-cb-spider-cloud-ba") // 32 bytes
-
-func encryptKeyValueList(keyValueInfoList []icbs.KeyValue) error {
+func encryptKeyValueList(credentialName string, keyValueInfoList []icbs.KeyValue) error {
 
         for i, kv := range keyValueInfoList {
-                encb, err := encrypt(spider_key, []byte(kv.Value))
-                kv.Value = string(encb)
+                encValue, err := encryptValue(credentialName + "/" + kv.Key, kv.Value)
                 if err != nil {
                         return err
                 }
+                kv.Value = encValue
                 keyValueInfoList[i] = kv
         }
         return  nil
 }
 
-func decryptKeyValueList(keyValueInfoList []icbs.KeyValue) error {
+// allowLegacy: decrypt the values stored before versioning with the built-in key.
+func decryptKeyValueList(credentialName string, keyValueInfoList []icbs.KeyValue, allowLegacy bool) error {
 
 	for i, kv := range keyValueInfoList {
-		decb, err := decrypt(spider_key, []byte(kv.Value))
-		kv.Value = string(decb)
+		decValue, err := decryptValue(credentialName + "/" + kv.Key, kv.Value, allowLegacy)
 		if err != nil {
+			cblog.Error(err)
 			return err
 		}
+		kv.Value = decValue
 		keyValueInfoList[i] = kv
 	}
 	return nil	
 }
 
-// encription with spider key
-func encrypt(spider_key, contents []byte) ([]byte, error) {
+// 1. get all CredentialInfos from cb-store
+// 2. decrypt the values encrypted with an old key
+// 3. encrypt them with the current key and rewrite them
+// return the number of the rewritten Credentials.
+func RotateCredentialKey() (int, error) {
+	cblog.Info("call RotateCredentialKey()")
 
-	base64Encoding := base64.StdEncoding.EncodeToString(contents)
-	encryptData := make([]byte, aes.BlockSize+len(base64Encoding))
-	initVector := encryptData[:aes.BlockSize]
-	if _, err := io.ReadFull(rand.Reader, initVector); err != nil {
-		return nil, err
-	}
-
-	cipherBlock, err := aes.NewCipher(spider_key)
+	currentVersion, err := GetCurrentKeyVersion()
 	if err != nil {
-		return nil, err
-	}
-	cipherTextFB := cipher.NewCFBEncrypter(cipherBlock, initVector)
-	cipherTextFB.XORKeyStream(encryptData[aes.BlockSize:], []byte(base64Encoding))
-
-	return encryptData, nil
-}
-
-// decryption with spider key
-func decrypt(spider_key, contents []byte) ([]byte, error) {
-
-	if len(contents) < aes.BlockSize {
-		err := fmt.Errorf("decryption: " + "contents too short")
-		cblog.Error(err)
-		return nil, err
+		return 0, err
 	}
 
-	cipherBlock, err := aes.NewCipher(spider_key)
+	credentialInfoList, err := listInfo()
 	if err != nil {
-		return nil, err
+		cblog.Error(err)
+		return 0, err
 	}
 
-	initVector := contents[:aes.BlockSize]
-	contents = contents[aes.BlockSize:]
-	cipherTextFB := cipher.NewCFBDecrypter(cipherBlock, initVector)
-	cipherTextFB.XORKeyStream(contents, contents)
-	decryptData, err := base64.StdEncoding.DecodeString(string(contents))
+	count := 0
+	for _, crdInfo := range credentialInfoList {
+		isOld := false
+		for _, kv := range crdInfo.KeyValueInfoList {
+			if getKeyVersion(kv.Value) != currentVersion {
+				isOld = true
+			}
+		}
+		if !isOld {
+			continue
+		}
 
-	if err != nil {
-		return nil, err
+		err = decryptKeyValueList(crdInfo.CredentialName, crdInfo.KeyValueInfoList, true)
+		if err != nil {
+			return count, fmt.Errorf("%s: %v", crdInfo.CredentialName, err)
+		}
+		err = encryptKeyValueList(crdInfo.CredentialName, crdInfo.KeyValueInfoList)
+		if err != nil {
+			return count, fmt.Errorf("%s: %v", crdInfo.CredentialName, err)
+		}
+		err = insertInfo(crdInfo.CredentialName, crdInfo.ProviderName, crdInfo.KeyValueInfoList)
+		if err != nil {
+			cblog.Error(err)
+			return count, err
+		}
+		cblog.Infof("Credential %s is re-encrypted with the key version %s.", crdInfo.CredentialName, currentVersion)
+		count++
 	}
-	return decryptData, nil
+
+	return count, nil
 }
 
 func UnRegisterCredential(credentialName string) (bool, error) {
@@ -242,6 +241,7 @@ func UnRegisterCredential(credentialName string) (bool, error) {
 		cblog.Error(err)
 		return false, err
 	}
+	cim.NotifyInfoChanged(cim.CREDENTIAL, credentialName)
 
 	return result, nil
 }
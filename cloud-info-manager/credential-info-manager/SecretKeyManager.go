@@ -0,0 +1,331 @@
+// Secret Key Manager for Cloud Credential Info. Manager.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package credentialinfomanager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+)
+
+// Master key of the credential encryption.
+//
+// configuration by env:
+//   SPIDER_KEY:         base64 encoded AES key(16, 24 or 32 bytes), ex) $ openssl rand -base64 32
+//   SPIDER_KEY_VERSION: version of SPIDER_KEY, default: "1"
+//   SPIDER_KEY_FILE:    yaml file with the versioned keys, ex)
+//                         current: "2"
+//                         keys:
+//                           "1": "mKXbZ2Y0...="
+//                           "2": "q1u8Yx3T...="
+//
+// SPIDER_KEY is used as the current key over the current key of SPIDER_KEY_FILE,
+// and the old keys of SPIDER_KEY_FILE are used to decrypt or to rotate the stored values.
+// Without any key, the new values are encrypted with the built-in key(version "0") with a warning,
+// which is not a secret, so set SPIDER_KEY and rotate them with utils/credential-key-mgmt.
+// The Credentials stored before versioning(without the prefix) are decrypted with the built-in key,
+// and they are encrypted with the current key on the next write or the rotation.
+
+// SecretKeyProvider is the backend of the versioned master keys.
+// The default backend uses the env and the key file, and other backends(ex. Vault) can be
+// plugged with SetSecretKeyProvider().
+type SecretKeyProvider interface {
+	// the current key and its version to encrypt the new values
+	CurrentKey() (string, []byte, error)
+	// the key of the version to decrypt the stored values
+	GetKey(version string) ([]byte, error)
+}
+
+// @todo env by powerkim, 2020.06.01.
+// the built-in key, used only without SPIDER_KEY and SPIDER_KEY_FILE, and for the values stored before versioning.
+var spider_key = []byte("cloud-barista-cb-spider-cloud-ba") // 32 bytes
+
+const (
+	builtinKeyVersion = "0"
+	defaultKeyVersion = "1"
+
+	// format of an encrypted value: "spkey:<version>:<base64(nonce + AES-GCM sealed data)>"
+	// ex) "spkey:2:3q2+7w8A..."
+	encryptedPrefix = "spkey:"
+)
+
+var keyProvider SecretKeyProvider
+var keyProviderLock sync.Mutex
+
+func SetSecretKeyProvider(provider SecretKeyProvider) {
+	keyProviderLock.Lock()
+	defer keyProviderLock.Unlock()
+
+	keyProvider = provider
+}
+
+func getSecretKeyProvider() (SecretKeyProvider, error) {
+	keyProviderLock.Lock()
+	defer keyProviderLock.Unlock()
+
+	if keyProvider == nil {
+		provider, err := newEnvKeyProvider()
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		keyProvider = provider
+	}
+	return keyProvider, nil
+}
+
+//====================================================================
+// default backend: SPIDER_KEY, SPIDER_KEY_VERSION and SPIDER_KEY_FILE
+type envKeyProvider struct {
+	currentVersion string
+	keyMap         map[string][]byte // key: version
+}
+
+type keyFileInfo struct {
+	Current string            `yaml:"current"`
+	Keys    map[string]string `yaml:"keys"`
+}
+
+//====================================================================
+
+func newEnvKeyProvider() (*envKeyProvider, error) {
+	provider := &envKeyProvider{
+		currentVersion: builtinKeyVersion,
+		keyMap:         map[string][]byte{builtinKeyVersion: spider_key},
+	}
+
+	keyFile := os.Getenv("SPIDER_KEY_FILE")
+	if keyFile != "" {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		fileInfo := keyFileInfo{}
+		err = yaml.Unmarshal(data, &fileInfo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", keyFile, err)
+		}
+		for version, strKey := range fileInfo.Keys {
+			err = provider.addKey(version, strKey)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", keyFile, err)
+			}
+		}
+		if fileInfo.Current != "" {
+			if _, ok := provider.keyMap[fileInfo.Current]; !ok {
+				return nil, fmt.Errorf("%s: the current key version %s does not exist!", keyFile, fileInfo.Current)
+			}
+			provider.currentVersion = fileInfo.Current
+		}
+	}
+
+	strKey := os.Getenv("SPIDER_KEY")
+	if strKey != "" {
+		version := os.Getenv("SPIDER_KEY_VERSION")
+		if version == "" {
+			version = defaultKeyVersion
+		}
+		err := provider.addKey(version, strKey)
+		if err != nil {
+			return nil, fmt.Errorf("SPIDER_KEY: %v", err)
+		}
+		provider.currentVersion = version
+	}
+
+	if provider.currentVersion == builtinKeyVersion {
+		cblog.Warn("==================================================================================")
+		cblog.Warn("SPIDER_KEY or SPIDER_KEY_FILE is not set, the Credentials are encrypted with the built-in key!")
+		cblog.Warn("==================================================================================")
+	}
+	return provider, nil
+}
+
+func (provider *envKeyProvider) addKey(version string, strKey string) error {
+	if version == "" || version == builtinKeyVersion || strings.Contains(version, ":") {
+		return fmt.Errorf("%q is not a valid key version!", version)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(strKey))
+	if err != nil {
+		return fmt.Errorf("the key of version %s is not base64 encoded: %v", version, err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return fmt.Errorf("the key of version %s must be 16, 24 or 32 bytes, but it is %d bytes!", version, len(key))
+	}
+	if oldKey, ok := provider.keyMap[version]; ok && string(oldKey) != string(key) {
+		return fmt.Errorf("the key version %s is duplicated with another key!", version)
+	}
+
+	provider.keyMap[version] = key
+	return nil
+}
+
+func (provider *envKeyProvider) CurrentKey() (string, []byte, error) {
+	return provider.currentVersion, provider.keyMap[provider.currentVersion], nil
+}
+
+func (provider *envKeyProvider) GetKey(version string) ([]byte, error) {
+	key, ok := provider.keyMap[version]
+	if !ok {
+		return nil, fmt.Errorf("the key version %s does not exist!", version)
+	}
+	return key, nil
+}
+
+// the current key version, ex) "2"
+func GetCurrentKeyVersion() (string, error) {
+	provider, err := getSecretKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	version, _, err := provider.CurrentKey()
+	return version, err
+}
+
+// the key version of a stored value, "" for the value encrypted by the built-in key before versioning.
+func getKeyVersion(value string) string {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return ""
+	}
+	strList := strings.SplitN(strings.TrimPrefix(value, encryptedPrefix), ":", 2)
+	return strList[0]
+}
+
+// EncryptSecret encrypts a secret value of other infos(ex. the private key of a jump host) with the current key.
+// aad(ex. "<ConnectionName>/<JumpHostName>") binds the value to its place.
+func EncryptSecret(aad string, contents string) (string, error) {
+	return encryptValue(aad, contents)
+}
+
+// DecryptSecret decrypts a value encrypted by EncryptSecret().
+func DecryptSecret(aad string, value string) (string, error) {
+	return decryptValue(aad, value, false)
+}
+
+// AES-GCM encryption with the current key.
+// aad(ex. "<CredentialName>/<Key>") binds the value to its place, so a moved value can not be decrypted.
+func encryptValue(aad string, contents string) (string, error) {
+	provider, err := getSecretKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	version, key, err := provider.CurrentKey()
+	if err != nil {
+		return "", err
+	}
+	if version == builtinKeyVersion {
+		cblog.Warnf("encryption: SPIDER_KEY or SPIDER_KEY_FILE is not set, %s is encrypted with the built-in key!", aad)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealedData := gcm.Seal(nonce, nonce, []byte(contents), []byte(aad))
+
+	return encryptedPrefix + version + ":" + base64.StdEncoding.EncodeToString(sealedData), nil
+}
+
+// AES-GCM decryption with the key of the stored version.
+// The Credential stored before versioning(AES-CFB without the prefix) is decrypted with the built-in key(allowLegacy).
+// The other secrets are always versioned, so a prefix-stripped value can not bypass the integrity check.
+func decryptValue(aad string, value string, allowLegacy bool) (string, error) {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		if !allowLegacy {
+			return "", fmt.Errorf("decryption: %s is not a versioned value!", aad)
+		}
+		decb, err := decrypt(spider_key, []byte(value))
+		if err != nil {
+			return "", err
+		}
+		return string(decb), nil
+	}
+
+	strList := strings.SplitN(strings.TrimPrefix(value, encryptedPrefix), ":", 2)
+	if len(strList) != 2 {
+		return "", fmt.Errorf("decryption: invalid format of the encrypted value")
+	}
+	version := strList[0]
+
+	provider, err := getSecretKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	key, err := provider.GetKey(version)
+	if err != nil {
+		return "", err
+	}
+
+	sealedData, err := base64.StdEncoding.DecodeString(strList[1])
+	if err != nil {
+		return "", fmt.Errorf("decryption: %v", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealedData) < gcm.NonceSize() {
+		return "", fmt.Errorf("decryption: contents too short")
+	}
+	nonce := sealedData[:gcm.NonceSize()]
+	decb, err := gcm.Open(nil, nonce, sealedData[gcm.NonceSize():], []byte(aad))
+	if err != nil {
+		return "", fmt.Errorf("decryption: %s is tampered or the key of version %s is wrong: %v", aad, version, err)
+	}
+	return string(decb), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	cipherBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(cipherBlock)
+}
+
+// decryption with spider key, only for the values stored before versioning(AES-CFB)
+func decrypt(spider_key, contents []byte) ([]byte, error) {
+
+	if len(contents) < aes.BlockSize {
+		err := fmt.Errorf("decryption: " + "contents too short")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cipherBlock, err := aes.NewCipher(spider_key)
+	if err != nil {
+		return nil, err
+	}
+
+	initVector := contents[:aes.BlockSize]
+	contents = contents[aes.BlockSize:]
+	cipherTextFB := cipher.NewCFBDecrypter(cipherBlock, initVector)
+	cipherTextFB.XORKeyStream(contents, contents)
+	decryptData, err := base64.StdEncoding.DecodeString(string(contents))
+
+	if err != nil {
+		return nil, err
+	}
+	return decryptData, nil
+}
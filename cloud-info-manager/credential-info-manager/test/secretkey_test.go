@@ -0,0 +1,244 @@
+// Test for the Secret Key of Cloud Credential Info. Manager.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	cbstore "github.com/cloud-barista/cb-store"
+	icbs "github.com/cloud-barista/cb-store/interfaces"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+)
+
+type testKeyProvider struct {
+	current string
+	keyMap  map[string][]byte
+}
+
+func (provider *testKeyProvider) CurrentKey() (string, []byte, error) {
+	return provider.current, provider.keyMap[provider.current], nil
+}
+
+func (provider *testKeyProvider) GetKey(version string) ([]byte, error) {
+	key, ok := provider.keyMap[version]
+	if !ok {
+		return nil, fmt.Errorf("the key version %s does not exist!", version)
+	}
+	return key, nil
+}
+
+const testCredentialName = "secretkey-test-credential01"
+
+func getStoredValue(t *testing.T) *icbs.KeyValue {
+	keyValueList, err := cbstore.GetStore().GetList("/cloud-info-spaces/credentials/"+testCredentialName+"/", true)
+	if err != nil || len(keyValueList) != 1 {
+		t.Fatalf("The stored value of %s does not exist: %v", testCredentialName, err)
+	}
+	return keyValueList[0]
+}
+
+func checkDecryptedValue(t *testing.T, value string) {
+	crdInfo, err := cim.GetCredentialDecrypt(testCredentialName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if crdInfo.KeyValueInfoList[0].Value != value {
+		t.Errorf("The decrypted value is not %s. It is %s.", value, crdInfo.KeyValueInfoList[0].Value)
+	}
+}
+
+func TestSecretKeyRotation(t *testing.T) {
+	provider := &testKeyProvider{"1", map[string][]byte{
+		"1": []byte("0123456789abcdef0123456789abcdef"),
+		"2": []byte("fedcba9876543210fedcba9876543210"),
+	}}
+	cim.SetSecretKeyProvider(provider)
+	defer cim.SetSecretKeyProvider(nil)
+	defer cim.UnRegisterCredential(testCredentialName)
+
+	_, err := cim.RegisterCredential(testCredentialName, "MOCK", []icbs.KeyValue{{"MockName", "mock-secret-01"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if kv := getStoredValue(t); !strings.HasPrefix(kv.Value, "spkey:1:") {
+		t.Errorf("The stored value is not encrypted with the key version 1: %s", kv.Value)
+	}
+	checkDecryptedValue(t, "mock-secret-01")
+
+	// rotate: 1 => 2
+	provider.current = "2"
+	count, err := cim.RotateCredentialKey()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if count < 1 {
+		t.Errorf("The number of rotated Credentials is %d.", count)
+	}
+	if kv := getStoredValue(t); !strings.HasPrefix(kv.Value, "spkey:2:") {
+		t.Errorf("The stored value is not encrypted with the key version 2: %s", kv.Value)
+	}
+	checkDecryptedValue(t, "mock-secret-01")
+
+	// nothing to rotate
+	count, err = cim.RotateCredentialKey()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if count != 0 {
+		t.Errorf("The number of rotated Credentials is not 0. It is %d.", count)
+	}
+}
+
+func TestSecretKeyTampered(t *testing.T) {
+	cim.SetSecretKeyProvider(&testKeyProvider{"1", map[string][]byte{
+		"1": []byte("0123456789abcdef0123456789abcdef"),
+	}})
+	defer cim.SetSecretKeyProvider(nil)
+	defer cim.UnRegisterCredential(testCredentialName)
+
+	_, err := cim.RegisterCredential(testCredentialName, "MOCK", []icbs.KeyValue{{"MockName", "mock-secret-02"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// flip a bit of the sealed data
+	kv := getStoredValue(t)
+	sealedData, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(kv.Value, "spkey:1:"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	sealedData[len(sealedData)-1] ^= 0x01
+	err = cbstore.GetStore().Put(kv.Key, "spkey:1:"+base64.StdEncoding.EncodeToString(sealedData))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = cim.GetCredentialDecrypt(testCredentialName)
+	if err == nil {
+		t.Errorf("GetCredentialDecrypt() with a tampered value must be failed.")
+	}
+}
+
+func TestSecretKeyEnv(t *testing.T) {
+	defer cim.SetSecretKeyProvider(nil)
+	defer os.Unsetenv("SPIDER_KEY")
+	defer os.Unsetenv("SPIDER_KEY_VERSION")
+
+	os.Setenv("SPIDER_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	os.Setenv("SPIDER_KEY_VERSION", "7")
+	cim.SetSecretKeyProvider(nil)
+	version, err := cim.GetCurrentKeyVersion()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if version != "7" {
+		t.Errorf("The current key version is not 7. It is %s.", version)
+	}
+
+	// 10 bytes key
+	os.Setenv("SPIDER_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789")))
+	cim.SetSecretKeyProvider(nil)
+	_, err = cim.GetCurrentKeyVersion()
+	if err == nil {
+		t.Errorf("SPIDER_KEY with an invalid size must be failed.")
+	}
+}
+
+func TestSecretKeyBuiltin(t *testing.T) {
+	defer cim.SetSecretKeyProvider(nil)
+	defer cim.UnRegisterCredential(testCredentialName)
+
+	// without SPIDER_KEY and SPIDER_KEY_FILE, the new values are encrypted with the built-in key
+	os.Unsetenv("SPIDER_KEY")
+	os.Unsetenv("SPIDER_KEY_FILE")
+	cim.SetSecretKeyProvider(nil)
+	_, err := cim.RegisterCredential(testCredentialName, "MOCK", []icbs.KeyValue{{"MockName", "mock-secret-03"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if kv := getStoredValue(t); !strings.HasPrefix(kv.Value, "spkey:0:") {
+		t.Errorf("The stored value is not encrypted with the built-in key: %s", kv.Value)
+	}
+	checkDecryptedValue(t, "mock-secret-03")
+
+	// the rotation with the new key
+	cim.SetSecretKeyProvider(&testKeyProvider{"1", map[string][]byte{
+		"0": []byte("cloud-barista-cb-spider-cloud-ba"),
+		"1": []byte("0123456789abcdef0123456789abcdef"),
+	}})
+	if _, err := cim.RotateCredentialKey(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if kv := getStoredValue(t); !strings.HasPrefix(kv.Value, "spkey:1:") {
+		t.Errorf("The stored value is not encrypted with the key version 1: %s", kv.Value)
+	}
+	checkDecryptedValue(t, "mock-secret-03")
+}
+
+// the value stored before versioning: built-in key, AES-CFB of base64(contents), without the prefix
+func legacyEncrypt(t *testing.T, contents string) string {
+	cipherBlock, err := aes.NewCipher([]byte("cloud-barista-cb-spider-cloud-ba"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(contents))
+	data := make([]byte, aes.BlockSize+len(encoded))
+	if _, err := io.ReadFull(rand.Reader, data[:aes.BlockSize]); err != nil {
+		t.Fatal(err.Error())
+	}
+	cipher.NewCFBEncrypter(cipherBlock, data[:aes.BlockSize]).XORKeyStream(data[aes.BlockSize:], []byte(encoded))
+	return string(data)
+}
+
+func TestSecretKeyLegacy(t *testing.T) {
+	cim.SetSecretKeyProvider(&testKeyProvider{"1", map[string][]byte{
+		"1": []byte("0123456789abcdef0123456789abcdef"),
+	}})
+	defer cim.SetSecretKeyProvider(nil)
+	defer cim.UnRegisterCredential(testCredentialName)
+
+	_, err := cim.RegisterCredential(testCredentialName, "MOCK", []icbs.KeyValue{{"MockName", "mock-secret-04"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	kv := getStoredValue(t)
+	err = cbstore.GetStore().Put(kv.Key, legacyEncrypt(t, "mock-secret-04"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the unversioned value is decrypted with the built-in key after the upgrade
+	checkDecryptedValue(t, "mock-secret-04")
+
+	// the other secrets should be versioned
+	if _, err := cim.DecryptSecret(testCredentialName+"/MockName", legacyEncrypt(t, "mock-secret-04")); err == nil {
+		t.Errorf("DecryptSecret() with an unversioned value must be failed.")
+	}
+
+	// the rotation migrates it to the current key
+	count, err := cim.RotateCredentialKey()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if count < 1 {
+		t.Errorf("The number of rotated Credentials is %d.", count)
+	}
+	if kv := getStoredValue(t); !strings.HasPrefix(kv.Value, "spkey:1:") {
+		t.Errorf("The stored value is not encrypted with the key version 1: %s", kv.Value)
+	}
+	checkDecryptedValue(t, "mock-secret-04")
+}
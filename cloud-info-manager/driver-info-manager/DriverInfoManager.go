@@ -13,6 +13,7 @@ import (
 	"strings"
 	"github.com/cloud-barista/cb-store/config"
 	"github.com/sirupsen/logrus"
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager"
 )
 
 var cblog *logrus.Logger
@@ -67,6 +68,7 @@ func RegisterCloudDriver(driverName string, providerName string, driverLibFileNa
 		cblog.Error(err)
 		return nil, err
 	}
+	cim.NotifyInfoChanged(cim.DRIVER, driverName)
 
 	drvInfo := &CloudDriverInfo{driverName, providerName, driverLibFileName}
 	return drvInfo, nil
@@ -113,6 +115,7 @@ func UnRegisterCloudDriver(driverName string) (bool, error) {
 		cblog.Error(err)
 		return false, err
 	}
+	cim.NotifyInfoChanged(cim.DRIVER, driverName)
 
 	return result, nil
 }
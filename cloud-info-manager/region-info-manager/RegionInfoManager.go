@@ -61,6 +61,7 @@ func RegisterRegion(regionName string, providerName string, keyValueInfoList []i
 		cblog.Error(err)
 		return nil, err
 	}
+	cim.NotifyInfoChanged(cim.REGION, regionName)
 
 	rgnInfo := &RegionInfo{regionName, providerName, keyValueInfoList}
 	return rgnInfo, nil
@@ -107,6 +108,7 @@ func UnRegisterRegion(regionName string) (bool, error) {
 		cblog.Error(err)
 		return false, err
 	}
+	cim.NotifyInfoChanged(cim.REGION, regionName)
 
 	return result, nil
 }
@@ -9,6 +9,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -192,6 +193,203 @@ type SSHRUNReq struct {
 	Command    string   `yaml:"Command" json:"Command"`
 }
 
+// NLBReq - NLB 정보 생성 요청 구조 정의
+type NLBReq struct {
+	ConnectionName string  `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        NLBInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// NLBInfo - NLB 정보 구조 정의
+type NLBInfo struct {
+	Name    string `yaml:"Name" json:"Name"`
+	VPCName string `yaml:"VPCName" json:"VPCName"`
+	Type    string `yaml:"Type" json:"Type"`
+	Scope   string `yaml:"Scope" json:"Scope"`
+
+	Listener      NLBProtocolPortInfo  `yaml:"Listener" json:"Listener"`
+	VMGroup       NLBVMGroupInfo       `yaml:"VMGroup" json:"VMGroup"`
+	HealthChecker NLBHealthCheckerInfo `yaml:"HealthChecker" json:"HealthChecker"`
+
+	TagList []cres.KeyValue `yaml:"TagList" json:"TagList"`
+}
+
+// NLBProtocolPortInfo - NLB Listener/VMGroup 의 Protocol, Port 정보 구조 정의
+type NLBProtocolPortInfo struct {
+	Protocol string `yaml:"Protocol" json:"Protocol"`
+	Port     string `yaml:"Port" json:"Port"`
+}
+
+// NLBVMGroupInfo - NLB VMGroup 정보 구조 정의
+type NLBVMGroupInfo struct {
+	Protocol string   `yaml:"Protocol" json:"Protocol"`
+	Port     string   `yaml:"Port" json:"Port"`
+	VMs      []string `yaml:"VMs" json:"VMs"`
+}
+
+// NLBHealthCheckerInfo - NLB HealthChecker 정보 구조 정의 ("default" 또는 "" 는 CSP 기본값)
+type NLBHealthCheckerInfo struct {
+	Protocol  string `yaml:"Protocol" json:"Protocol"`
+	Port      string `yaml:"Port" json:"Port"`
+	Interval  string `yaml:"Interval" json:"Interval"`
+	Timeout   string `yaml:"Timeout" json:"Timeout"`
+	Threshold string `yaml:"Threshold" json:"Threshold"`
+}
+
+// NLBVMsInfo - NLB VM 추가/제거 정보 구조 정의
+type NLBVMsInfo struct {
+	VMs []string `yaml:"VMs" json:"VMs"`
+}
+
+// NLBRegisterReq - NLB Register 정보 생성 요청 구조 정의
+type NLBRegisterReq struct {
+	ConnectionName string          `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        NLBRegisterInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// NLBRegisterInfo - NLB Register 정보 구조 정의
+type NLBRegisterInfo struct {
+	VPCName string `yaml:"VPCName" json:"VPCName"`
+	Name    string `yaml:"Name" json:"Name"`
+	CSPId   string `yaml:"CSPId" json:"CSPId"`
+}
+
+// DiskReq - Disk 정보 생성 요청 구조 정의
+type DiskReq struct {
+	ConnectionName string   `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        DiskInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// DiskInfo - Disk 정보 구조 정의
+type DiskInfo struct {
+	Name     string          `yaml:"Name" json:"Name"`
+	DiskType string          `yaml:"DiskType" json:"DiskType"`
+	DiskSize string          `yaml:"DiskSize" json:"DiskSize"`
+	TagList  []cres.KeyValue `yaml:"TagList" json:"TagList"`
+}
+
+// DiskSizeInfo - Disk 크기 변경 정보 구조 정의
+type DiskSizeInfo struct {
+	Size string `yaml:"Size" json:"Size"`
+}
+
+// DiskAttachInfo - Disk 연결/분리 정보 구조 정의
+type DiskAttachInfo struct {
+	VMName string `yaml:"VMName" json:"VMName"`
+}
+
+// DiskRegisterReq - Disk Register 정보 생성 요청 구조 정의
+type DiskRegisterReq struct {
+	ConnectionName string           `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        DiskRegisterInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// DiskRegisterInfo - Disk Register 정보 구조 정의
+type DiskRegisterInfo struct {
+	Name  string `yaml:"Name" json:"Name"`
+	CSPId string `yaml:"CSPId" json:"CSPId"`
+}
+
+// MyImageReq - MyImage 정보 생성 요청 구조 정의
+type MyImageReq struct {
+	ConnectionName string      `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        MyImageInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// MyImageInfo - MyImage 정보 구조 정의
+type MyImageInfo struct {
+	Name     string `yaml:"Name" json:"Name"`
+	SourceVM string `yaml:"SourceVM" json:"SourceVM"`
+}
+
+// MyImageRegisterReq - MyImage Register 정보 생성 요청 구조 정의
+type MyImageRegisterReq struct {
+	ConnectionName string              `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        MyImageRegisterInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// MyImageRegisterInfo - MyImage Register 정보 구조 정의
+type MyImageRegisterInfo struct {
+	Name  string `yaml:"Name" json:"Name"`
+	CSPId string `yaml:"CSPId" json:"CSPId"`
+}
+
+// ClusterReq - Cluster 정보 생성 요청 구조 정의
+type ClusterReq struct {
+	ConnectionName string      `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        ClusterInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// ClusterInfo - Cluster 정보 구조 정의
+type ClusterInfo struct {
+	Name    string `yaml:"Name" json:"Name"`
+	Version string `yaml:"Version" json:"Version"`
+
+	VPCName            string   `yaml:"VPCName" json:"VPCName"`
+	SubnetNames        []string `yaml:"SubnetNames" json:"SubnetNames"`
+	SecurityGroupNames []string `yaml:"SecurityGroupNames" json:"SecurityGroupNames"`
+
+	NodeGroupList []NodeGroupInfo `yaml:"NodeGroupList" json:"NodeGroupList"`
+
+	TagList []cres.KeyValue `yaml:"TagList" json:"TagList"`
+}
+
+// NodeGroupInfo - Cluster NodeGroup 정보 구조 정의
+type NodeGroupInfo struct {
+	Name         string `yaml:"Name" json:"Name"`
+	ImageName    string `yaml:"ImageName" json:"ImageName"`
+	VMSpecName   string `yaml:"VMSpecName" json:"VMSpecName"`
+	RootDiskType string `yaml:"RootDiskType" json:"RootDiskType"`
+	RootDiskSize string `yaml:"RootDiskSize" json:"RootDiskSize"`
+	KeyPairName  string `yaml:"KeyPairName" json:"KeyPairName"`
+
+	OnAutoScaling   string `yaml:"OnAutoScaling" json:"OnAutoScaling"`
+	DesiredNodeSize string `yaml:"DesiredNodeSize" json:"DesiredNodeSize"`
+	MinNodeSize     string `yaml:"MinNodeSize" json:"MinNodeSize"`
+	MaxNodeSize     string `yaml:"MaxNodeSize" json:"MaxNodeSize"`
+}
+
+// NodeGroupAutoScalingInfo - NodeGroup AutoScaling 설정 정보 구조 정의
+type NodeGroupAutoScalingInfo struct {
+	OnAutoScaling string `yaml:"OnAutoScaling" json:"OnAutoScaling"`
+}
+
+// NodeGroupScalingInfo - NodeGroup Scaling 크기 정보 구조 정의
+type NodeGroupScalingInfo struct {
+	DesiredNodeSize string `yaml:"DesiredNodeSize" json:"DesiredNodeSize"`
+	MinNodeSize     string `yaml:"MinNodeSize" json:"MinNodeSize"`
+	MaxNodeSize     string `yaml:"MaxNodeSize" json:"MaxNodeSize"`
+}
+
+// ClusterUpgradeInfo - Cluster 버전 업그레이드 정보 구조 정의
+type ClusterUpgradeInfo struct {
+	Version string `yaml:"Version" json:"Version"`
+}
+
+// ClusterRegisterReq - Cluster Register 정보 생성 요청 구조 정의
+type ClusterRegisterReq struct {
+	ConnectionName string              `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        ClusterRegisterInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// ClusterRegisterInfo - Cluster Register 정보 구조 정의
+type ClusterRegisterInfo struct {
+	VPCName string `yaml:"VPCName" json:"VPCName"`
+	Name    string `yaml:"Name" json:"Name"`
+	CSPId   string `yaml:"CSPId" json:"CSPId"`
+}
+
+// AnyCallReq - AnyCall 요청 구조 정의
+type AnyCallReq struct {
+	ConnectionName string      `yaml:"ConnectionName" json:"ConnectionName"`
+	ReqInfo        AnyCallInfo `yaml:"ReqInfo" json:"ReqInfo"`
+}
+
+// AnyCallInfo - AnyCall 정보 구조 정의
+type AnyCallInfo struct {
+	FID           string          `yaml:"FID" json:"FID"`
+	IKeyValueList []cres.KeyValue `yaml:"IKeyValueList" json:"IKeyValueList"`
+}
+
 // ===== [ Implementations ] =====
 
 // SetServerAddr - Spider 서버 주소 설정
@@ -1637,6 +1835,1478 @@ func (ccm *CCMApi) UnregisterVMByParam(connectionName string, name string) (stri
 	return result, err
 }
 
+// PlanInfra - Infra 문서(YAML/JSON)의 실행 계획 조회
+func (ccm *CCMApi) PlanInfra(doc string, destroy bool) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.PlanInfra(destroy)
+}
+
+// ApplyInfra - Infra 문서(YAML/JSON)의 자원 생성
+func (ccm *CCMApi) ApplyInfra(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ApplyInfra()
+}
+
+// DestroyInfra - Infra 문서(YAML/JSON)의 자원 삭제
+func (ccm *CCMApi) DestroyInfra(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DestroyInfra()
+}
+
+// WatchStatus - VM/NLB/Cluster 상태 변경 스트림 수신
+func (ccm *CCMApi) WatchStatus(ctx context.Context, doc string, handler func(string) error) error {
+	if ccm.requestCCM == nil {
+		return errors.New("The Open() function must be called")
+	}
+
+	// 스트림 수신중 다른 요청의 입력데이터 변경 방지
+	req := *ccm.requestCCM
+	req.InData = doc
+	return req.WatchStatus(ctx, handler)
+}
+
+// WatchStatusByParam - VM/NLB/Cluster 상태 변경 스트림 수신 (name 미지정시 해당 자원 전체)
+func (ccm *CCMApi) WatchStatusByParam(ctx context.Context, connectionName string, resourceType string, name string, handler func(string) error) error {
+	if ccm.requestCCM == nil {
+		return errors.New("The Open() function must be called")
+	}
+
+	// 스트림 수신중 다른 요청의 입력형식 변경 방지
+	req := *ccm.requestCCM
+	req.InType = "json"
+	req.InData = `{"ConnectionName":"` + connectionName + `", "ResourceType":"` + resourceType + `", "Name":"` + name + `"}`
+	return req.WatchStatus(ctx, handler)
+}
+
+// CreateNLB - NLB 생성
+func (ccm *CCMApi) CreateNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.CreateNLB()
+}
+
+// CreateNLBByParam - NLB 생성
+func (ccm *CCMApi) CreateNLBByParam(req *NLBReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.CreateNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListNLB - NLB 목록
+func (ccm *CCMApi) ListNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListNLB()
+}
+
+// ListNLBByParam - NLB 목록
+func (ccm *CCMApi) ListNLBByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetNLB - NLB 조회
+func (ccm *CCMApi) GetNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.GetNLB()
+}
+
+// GetNLBByParam - NLB 조회
+func (ccm *CCMApi) GetNLBByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.GetNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteNLB - NLB 삭제
+func (ccm *CCMApi) DeleteNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteNLB()
+}
+
+// DeleteNLBByParam - NLB 삭제
+func (ccm *CCMApi) DeleteNLBByParam(connectionName string, name string, force string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `", "force":"` + force + `"}`
+	result, err := ccm.requestCCM.DeleteNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListAllNLB - 관리 NLB 목록
+func (ccm *CCMApi) ListAllNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListAllNLB()
+}
+
+// ListAllNLBByParam - 관리 NLB 목록
+func (ccm *CCMApi) ListAllNLBByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListAllNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteCSPNLB - CSP NLB 삭제
+func (ccm *CCMApi) DeleteCSPNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteCSPNLB()
+}
+
+// DeleteCSPNLBByParam - CSP NLB 삭제
+func (ccm *CCMApi) DeleteCSPNLBByParam(connectionName string, id string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Id":"` + id + `"}`
+	result, err := ccm.requestCCM.DeleteCSPNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// RegisterNLB - NLB 등록
+func (ccm *CCMApi) RegisterNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.RegisterNLB()
+}
+
+// RegisterNLBByParam - NLB 등록
+func (ccm *CCMApi) RegisterNLBByParam(req *NLBRegisterReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.RegisterNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// UnregisterNLB - NLB 제거
+func (ccm *CCMApi) UnregisterNLB(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.UnregisterNLB()
+}
+
+// UnregisterNLBByParam - NLB 제거
+func (ccm *CCMApi) UnregisterNLBByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.UnregisterNLB()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// AddNLBVMs - NLB VM 추가
+func (ccm *CCMApi) AddNLBVMs(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.AddNLBVMs()
+}
+
+// AddNLBVMsByParam - NLB VM 추가
+func (ccm *CCMApi) AddNLBVMsByParam(connectionName string, name string, vmNames []string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string     `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string     `yaml:"Name" json:"Name"`
+		ReqInfo        NLBVMsInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        NLBVMsInfo{VMs: vmNames},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.AddNLBVMs()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// RemoveNLBVMs - NLB VM 제거
+func (ccm *CCMApi) RemoveNLBVMs(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.RemoveNLBVMs()
+}
+
+// RemoveNLBVMsByParam - NLB VM 제거
+func (ccm *CCMApi) RemoveNLBVMsByParam(connectionName string, name string, vmNames []string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string     `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string     `yaml:"Name" json:"Name"`
+		ReqInfo        NLBVMsInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        NLBVMsInfo{VMs: vmNames},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.RemoveNLBVMs()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ChangeListener - NLB Listener 변경
+func (ccm *CCMApi) ChangeListener(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ChangeListener()
+}
+
+// ChangeListenerByParam - NLB Listener 변경
+func (ccm *CCMApi) ChangeListenerByParam(connectionName string, name string, listener *NLBProtocolPortInfo) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string               `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string               `yaml:"Name" json:"Name"`
+		ReqInfo        *NLBProtocolPortInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        listener,
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.ChangeListener()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ChangeVMGroup - NLB VMGroup 변경
+func (ccm *CCMApi) ChangeVMGroup(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ChangeVMGroup()
+}
+
+// ChangeVMGroupByParam - NLB VMGroup 변경
+func (ccm *CCMApi) ChangeVMGroupByParam(connectionName string, name string, vmGroup *NLBProtocolPortInfo) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string               `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string               `yaml:"Name" json:"Name"`
+		ReqInfo        *NLBProtocolPortInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        vmGroup,
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.ChangeVMGroup()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ChangeHealthChecker - NLB HealthChecker 변경
+func (ccm *CCMApi) ChangeHealthChecker(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ChangeHealthChecker()
+}
+
+// ChangeHealthCheckerByParam - NLB HealthChecker 변경
+func (ccm *CCMApi) ChangeHealthCheckerByParam(connectionName string, name string, healthChecker *NLBHealthCheckerInfo) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string                `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string                `yaml:"Name" json:"Name"`
+		ReqInfo        *NLBHealthCheckerInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        healthChecker,
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.ChangeHealthChecker()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetVMGroupHealthInfo - NLB VMGroup Health 조회
+func (ccm *CCMApi) GetVMGroupHealthInfo(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.GetVMGroupHealthInfo()
+}
+
+// GetVMGroupHealthInfoByParam - NLB VMGroup Health 조회
+func (ccm *CCMApi) GetVMGroupHealthInfoByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.GetVMGroupHealthInfo()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// CreateDisk - Disk 생성
+func (ccm *CCMApi) CreateDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.CreateDisk()
+}
+
+// CreateDiskByParam - Disk 생성
+func (ccm *CCMApi) CreateDiskByParam(req *DiskReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.CreateDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListDisk - Disk 목록
+func (ccm *CCMApi) ListDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListDisk()
+}
+
+// ListDiskByParam - Disk 목록
+func (ccm *CCMApi) ListDiskByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetDisk - Disk 조회
+func (ccm *CCMApi) GetDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.GetDisk()
+}
+
+// GetDiskByParam - Disk 조회
+func (ccm *CCMApi) GetDiskByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.GetDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteDisk - Disk 삭제
+func (ccm *CCMApi) DeleteDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteDisk()
+}
+
+// DeleteDiskByParam - Disk 삭제
+func (ccm *CCMApi) DeleteDiskByParam(connectionName string, name string, force string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `", "force":"` + force + `"}`
+	result, err := ccm.requestCCM.DeleteDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListAllDisk - 관리 Disk 목록
+func (ccm *CCMApi) ListAllDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListAllDisk()
+}
+
+// ListAllDiskByParam - 관리 Disk 목록
+func (ccm *CCMApi) ListAllDiskByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListAllDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteCSPDisk - CSP Disk 삭제
+func (ccm *CCMApi) DeleteCSPDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteCSPDisk()
+}
+
+// DeleteCSPDiskByParam - CSP Disk 삭제
+func (ccm *CCMApi) DeleteCSPDiskByParam(connectionName string, id string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Id":"` + id + `"}`
+	result, err := ccm.requestCCM.DeleteCSPDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// RegisterDisk - Disk 등록
+func (ccm *CCMApi) RegisterDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.RegisterDisk()
+}
+
+// RegisterDiskByParam - Disk 등록
+func (ccm *CCMApi) RegisterDiskByParam(req *DiskRegisterReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.RegisterDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// UnregisterDisk - Disk 제거
+func (ccm *CCMApi) UnregisterDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.UnregisterDisk()
+}
+
+// UnregisterDiskByParam - Disk 제거
+func (ccm *CCMApi) UnregisterDiskByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.UnregisterDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ChangeDiskSize - Disk 크기 변경
+func (ccm *CCMApi) ChangeDiskSize(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ChangeDiskSize()
+}
+
+// ChangeDiskSizeByParam - Disk 크기 변경
+func (ccm *CCMApi) ChangeDiskSizeByParam(connectionName string, name string, size string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string       `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string       `yaml:"Name" json:"Name"`
+		ReqInfo        DiskSizeInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        DiskSizeInfo{Size: size},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.ChangeDiskSize()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// AttachDisk - Disk 연결
+func (ccm *CCMApi) AttachDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.AttachDisk()
+}
+
+// AttachDiskByParam - Disk 연결
+func (ccm *CCMApi) AttachDiskByParam(connectionName string, name string, vmName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string         `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string         `yaml:"Name" json:"Name"`
+		ReqInfo        DiskAttachInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        DiskAttachInfo{VMName: vmName},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.AttachDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DetachDisk - Disk 분리
+func (ccm *CCMApi) DetachDisk(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DetachDisk()
+}
+
+// DetachDiskByParam - Disk 분리
+func (ccm *CCMApi) DetachDiskByParam(connectionName string, name string, vmName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string         `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string         `yaml:"Name" json:"Name"`
+		ReqInfo        DiskAttachInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        DiskAttachInfo{VMName: vmName},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.DetachDisk()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// SnapshotVM - MyImage 생성 (VM 스냅샷)
+func (ccm *CCMApi) SnapshotVM(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.SnapshotVM()
+}
+
+// SnapshotVMByParam - MyImage 생성 (VM 스냅샷)
+func (ccm *CCMApi) SnapshotVMByParam(req *MyImageReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.SnapshotVM()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListMyImage - MyImage 목록
+func (ccm *CCMApi) ListMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListMyImage()
+}
+
+// ListMyImageByParam - MyImage 목록
+func (ccm *CCMApi) ListMyImageByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetMyImage - MyImage 조회
+func (ccm *CCMApi) GetMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.GetMyImage()
+}
+
+// GetMyImageByParam - MyImage 조회
+func (ccm *CCMApi) GetMyImageByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.GetMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteMyImage - MyImage 삭제
+func (ccm *CCMApi) DeleteMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteMyImage()
+}
+
+// DeleteMyImageByParam - MyImage 삭제
+func (ccm *CCMApi) DeleteMyImageByParam(connectionName string, name string, force string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `", "force":"` + force + `"}`
+	result, err := ccm.requestCCM.DeleteMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListAllMyImage - 관리 MyImage 목록
+func (ccm *CCMApi) ListAllMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListAllMyImage()
+}
+
+// ListAllMyImageByParam - 관리 MyImage 목록
+func (ccm *CCMApi) ListAllMyImageByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListAllMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteCSPMyImage - CSP MyImage 삭제
+func (ccm *CCMApi) DeleteCSPMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteCSPMyImage()
+}
+
+// DeleteCSPMyImageByParam - CSP MyImage 삭제
+func (ccm *CCMApi) DeleteCSPMyImageByParam(connectionName string, id string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Id":"` + id + `"}`
+	result, err := ccm.requestCCM.DeleteCSPMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// RegisterMyImage - MyImage 등록
+func (ccm *CCMApi) RegisterMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.RegisterMyImage()
+}
+
+// RegisterMyImageByParam - MyImage 등록
+func (ccm *CCMApi) RegisterMyImageByParam(req *MyImageRegisterReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.RegisterMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// UnregisterMyImage - MyImage 제거
+func (ccm *CCMApi) UnregisterMyImage(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.UnregisterMyImage()
+}
+
+// UnregisterMyImageByParam - MyImage 제거
+func (ccm *CCMApi) UnregisterMyImageByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.UnregisterMyImage()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// CreateCluster - Cluster 생성
+func (ccm *CCMApi) CreateCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.CreateCluster()
+}
+
+// CreateClusterByParam - Cluster 생성
+func (ccm *CCMApi) CreateClusterByParam(req *ClusterReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.CreateCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListCluster - Cluster 목록
+func (ccm *CCMApi) ListCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListCluster()
+}
+
+// ListClusterByParam - Cluster 목록
+func (ccm *CCMApi) ListClusterByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetCluster - Cluster 조회
+func (ccm *CCMApi) GetCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.GetCluster()
+}
+
+// GetClusterByParam - Cluster 조회
+func (ccm *CCMApi) GetClusterByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.GetCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteCluster - Cluster 삭제
+func (ccm *CCMApi) DeleteCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteCluster()
+}
+
+// DeleteClusterByParam - Cluster 삭제
+func (ccm *CCMApi) DeleteClusterByParam(connectionName string, name string, force string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `", "force":"` + force + `"}`
+	result, err := ccm.requestCCM.DeleteCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ListAllCluster - 관리 Cluster 목록
+func (ccm *CCMApi) ListAllCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ListAllCluster()
+}
+
+// ListAllClusterByParam - 관리 Cluster 목록
+func (ccm *CCMApi) ListAllClusterByParam(connectionName string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `"}`
+	result, err := ccm.requestCCM.ListAllCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// DeleteCSPCluster - CSP Cluster 삭제
+func (ccm *CCMApi) DeleteCSPCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.DeleteCSPCluster()
+}
+
+// DeleteCSPClusterByParam - CSP Cluster 삭제
+func (ccm *CCMApi) DeleteCSPClusterByParam(connectionName string, id string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Id":"` + id + `"}`
+	result, err := ccm.requestCCM.DeleteCSPCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// RegisterCluster - Cluster 등록
+func (ccm *CCMApi) RegisterCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.RegisterCluster()
+}
+
+// RegisterClusterByParam - Cluster 등록
+func (ccm *CCMApi) RegisterClusterByParam(req *ClusterRegisterReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.RegisterCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// UnregisterCluster - Cluster 제거
+func (ccm *CCMApi) UnregisterCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.UnregisterCluster()
+}
+
+// UnregisterClusterByParam - Cluster 제거
+func (ccm *CCMApi) UnregisterClusterByParam(connectionName string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.UnregisterCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// AddNodeGroup - Cluster NodeGroup 추가
+func (ccm *CCMApi) AddNodeGroup(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.AddNodeGroup()
+}
+
+// AddNodeGroupByParam - Cluster NodeGroup 추가
+func (ccm *CCMApi) AddNodeGroupByParam(connectionName string, name string, nodeGroup *NodeGroupInfo) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string         `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string         `yaml:"Name" json:"Name"`
+		ReqInfo        *NodeGroupInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        nodeGroup,
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.AddNodeGroup()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// RemoveNodeGroup - Cluster NodeGroup 제거
+func (ccm *CCMApi) RemoveNodeGroup(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.RemoveNodeGroup()
+}
+
+// RemoveNodeGroupByParam - Cluster NodeGroup 제거
+func (ccm *CCMApi) RemoveNodeGroupByParam(connectionName string, name string, nodeGroupName string, force string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "Name":"` + name + `", "NodeGroupName":"` + nodeGroupName + `", "force":"` + force + `"}`
+	result, err := ccm.requestCCM.RemoveNodeGroup()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// SetNodeGroupAutoScaling - NodeGroup AutoScaling 설정
+func (ccm *CCMApi) SetNodeGroupAutoScaling(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.SetNodeGroupAutoScaling()
+}
+
+// SetNodeGroupAutoScalingByParam - NodeGroup AutoScaling 설정
+func (ccm *CCMApi) SetNodeGroupAutoScalingByParam(connectionName string, name string, nodeGroupName string, onAutoScaling string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string                   `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string                   `yaml:"Name" json:"Name"`
+		NodeGroupName  string                   `yaml:"NodeGroupName" json:"NodeGroupName"`
+		ReqInfo        NodeGroupAutoScalingInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		NodeGroupName:  nodeGroupName,
+		ReqInfo:        NodeGroupAutoScalingInfo{OnAutoScaling: onAutoScaling},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.SetNodeGroupAutoScaling()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// ChangeNodeGroupScaling - NodeGroup Scaling 크기 변경
+func (ccm *CCMApi) ChangeNodeGroupScaling(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.ChangeNodeGroupScaling()
+}
+
+// ChangeNodeGroupScalingByParam - NodeGroup Scaling 크기 변경
+func (ccm *CCMApi) ChangeNodeGroupScalingByParam(connectionName string, name string, nodeGroupName string, scaling *NodeGroupScalingInfo) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string                `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string                `yaml:"Name" json:"Name"`
+		NodeGroupName  string                `yaml:"NodeGroupName" json:"NodeGroupName"`
+		ReqInfo        *NodeGroupScalingInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		NodeGroupName:  nodeGroupName,
+		ReqInfo:        scaling,
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.ChangeNodeGroupScaling()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// UpgradeCluster - Cluster 버전 업그레이드
+func (ccm *CCMApi) UpgradeCluster(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.UpgradeCluster()
+}
+
+// UpgradeClusterByParam - Cluster 버전 업그레이드
+func (ccm *CCMApi) UpgradeClusterByParam(connectionName string, name string, version string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	req := struct {
+		ConnectionName string             `yaml:"ConnectionName" json:"ConnectionName"`
+		Name           string             `yaml:"Name" json:"Name"`
+		ReqInfo        ClusterUpgradeInfo `yaml:"ReqInfo" json:"ReqInfo"`
+	}{
+		ConnectionName: connectionName,
+		Name:           name,
+		ReqInfo:        ClusterUpgradeInfo{Version: version},
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.UpgradeCluster()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// AnyCall - CSP 전용 기능 호출
+func (ccm *CCMApi) AnyCall(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.AnyCall()
+}
+
+// AnyCallByParam - CSP 전용 기능 호출
+func (ccm *CCMApi) AnyCallByParam(req *AnyCallReq) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	j, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	ccm.requestCCM.InData = string(j)
+	result, err := ccm.requestCCM.AnyCall()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetCSPResourceName - 자원의 CSP 이름 조회
+func (ccm *CCMApi) GetCSPResourceName(doc string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	ccm.requestCCM.InData = doc
+	return ccm.requestCCM.GetCSPResourceName()
+}
+
+// GetCSPResourceNameByParam - 자원의 CSP 이름 조회
+func (ccm *CCMApi) GetCSPResourceNameByParam(connectionName string, resourceType string, name string) (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	holdType, _ := ccm.GetInType()
+	ccm.SetInType("json")
+	ccm.requestCCM.InData = `{"ConnectionName":"` + connectionName + `", "ResourceType":"` + resourceType + `", "Name":"` + name + `"}`
+	result, err := ccm.requestCCM.GetCSPResourceName()
+	ccm.SetInType(holdType)
+
+	return result, err
+}
+
+// GetAllSPLockInfo - Spider 자원 Lock 정보 목록
+func (ccm *CCMApi) GetAllSPLockInfo() (string, error) {
+	if ccm.requestCCM == nil {
+		return "", errors.New("The Open() function must be called")
+	}
+
+	return ccm.requestCCM.GetAllSPLockInfo()
+}
+
 // SSHRun - SSH 실행
 func (ccm *CCMApi) SSHRun(doc string) (string, error) {
 	if ccm.requestSSH == nil {
@@ -0,0 +1,71 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package request
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	gc "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/common"
+	pb "github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/stub/cbspider"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// WatchStatus - 상태 변경 스트림 수신 (ctx 취소 또는 handler 오류시 종료)
+func (r *CCMRequest) WatchStatus(ctx context.Context, handler func(string) error) error {
+	// 입력데이터 검사
+	if r.InData == "" {
+		return errors.New("input data required")
+	}
+
+	// 입력데이터 언마샬링
+	var item pb.WatchRequest
+	err := gc.ConvertToMessage(r.InType, r.InData, &item)
+	if err != nil {
+		return err
+	}
+
+	// 서버에 요청 (스트림이므로 Timeout 미적용)
+	stream, err := r.Client.WatchStatus(ctx, &item)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		// 결과값 마샬링
+		out, err := gc.ConvertToOutput(r.OutType, &resp)
+		if err != nil {
+			return err
+		}
+		if err := handler(out); err != nil {
+			return err
+		}
+	}
+}
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,102 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cmd
+
+import (
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	"github.com/spf13/cobra"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewAnyCallCmd - CSP 전용 기능 호출 기능을 수행하는 Cobra Command 생성
+func NewAnyCallCmd() *cobra.Command {
+
+	anyCallCmd := &cobra.Command{
+		Use:   "anycall",
+		Short: "This is anycall command for the CSP specific function",
+		Long:  "This is anycall command for the CSP specific function",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	anyCallCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	anyCallCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return anyCallCmd
+}
+
+// NewCSPNameCmd - 자원의 CSP 이름 조회 기능을 수행하는 Cobra Command 생성
+func NewCSPNameCmd() *cobra.Command {
+
+	cspNameCmd := &cobra.Command{
+		Use:   "cspname",
+		Short: "This is get command for the CSP name of a resource",
+		Long:  "This is get command for the CSP name of a resource",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if resourceType == "" {
+				logger.Error("failed to validate --type parameter")
+				return
+			}
+			if resourceName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--type parameter value : ", resourceType)
+			logger.Debug("--name parameter value : ", resourceName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	cspNameCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	cspNameCmd.PersistentFlags().StringVarP(&resourceType, "type", "", "", "resource type (vpc/sg/keypair/vm/nlb/disk/myimage/cluster)")
+	cspNameCmd.PersistentFlags().StringVarP(&resourceName, "name", "n", "", "resource name")
+
+	return cspNameCmd
+}
+
+// NewSPLockCmd - Spider 자원 Lock 정보 목록 기능을 수행하는 Cobra Command 생성
+func NewSPLockCmd() *cobra.Command {
+
+	spLockCmd := &cobra.Command{
+		Use:   "splock",
+		Short: "This is list command for the spider resource locks",
+		Long:  "This is list command for the spider resource locks",
+		Run: func(cmd *cobra.Command, args []string) {
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	return spLockCmd
+}
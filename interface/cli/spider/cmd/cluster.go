@@ -0,0 +1,498 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	"github.com/spf13/cobra"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewClusterCmd - Cluster 관리 기능을 수행하는 Cobra Command 생성
+func NewClusterCmd() *cobra.Command {
+
+	clusterCmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "This is a manageable command for cluster",
+		Long:  "This is a manageable command for cluster",
+	}
+
+	//  Adds the commands for application.
+	clusterCmd.AddCommand(NewClusterCreateCmd())
+	clusterCmd.AddCommand(NewClusterListCmd())
+	clusterCmd.AddCommand(NewClusterGetCmd())
+	clusterCmd.AddCommand(NewClusterDeleteCmd())
+	clusterCmd.AddCommand(NewClusterListAllCmd())
+	clusterCmd.AddCommand(NewClusterDeleteCSPCmd())
+	clusterCmd.AddCommand(NewClusterRegisterCmd())
+	clusterCmd.AddCommand(NewClusterUnregisterCmd())
+	clusterCmd.AddCommand(NewClusterAddNodeGroupCmd())
+	clusterCmd.AddCommand(NewClusterRemoveNodeGroupCmd())
+	clusterCmd.AddCommand(NewClusterSetAutoScalingCmd())
+	clusterCmd.AddCommand(NewClusterChangeScalingCmd())
+	clusterCmd.AddCommand(NewClusterUpgradeCmd())
+	clusterCmd.AddCommand(ExClusterCmd())
+
+	return clusterCmd
+}
+
+// NewClusterCreateCmd - Cluster 생성 기능을 수행하는 Cobra Command 생성
+func NewClusterCreateCmd() *cobra.Command {
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "This is create command for cluster",
+		Long:  "This is create command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	createCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	createCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return createCmd
+}
+
+// NewClusterListCmd - Cluster 목록 기능을 수행하는 Cobra Command 생성
+func NewClusterListCmd() *cobra.Command {
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "This is list command for cluster",
+		Long:  "This is list command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listCmd
+}
+
+// NewClusterGetCmd - Cluster 조회 기능을 수행하는 Cobra Command 생성
+func NewClusterGetCmd() *cobra.Command {
+
+	getCmd := &cobra.Command{
+		Use:   "get",
+		Short: "This is get command for cluster",
+		Long:  "This is get command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if clusterName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", clusterName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	getCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	getCmd.PersistentFlags().StringVarP(&clusterName, "name", "n", "", "cluster name")
+
+	return getCmd
+}
+
+// NewClusterDeleteCmd - Cluster 삭제 기능을 수행하는 Cobra Command 생성
+func NewClusterDeleteCmd() *cobra.Command {
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "This is delete command for cluster",
+		Long:  "This is delete command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if clusterName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", clusterName)
+			logger.Debug("--force parameter value : ", force)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCmd.PersistentFlags().StringVarP(&clusterName, "name", "n", "", "cluster name")
+	deleteCmd.PersistentFlags().StringVarP(&force, "force", "", "false", "force flg (true/false)")
+
+	return deleteCmd
+}
+
+// NewClusterListAllCmd - 관리 Cluster 목록 기능을 수행하는 Cobra Command 생성
+func NewClusterListAllCmd() *cobra.Command {
+
+	listAllCmd := &cobra.Command{
+		Use:   "listall",
+		Short: "This is list all command for cluster",
+		Long:  "This is list all command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listAllCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listAllCmd
+}
+
+// NewClusterDeleteCSPCmd - CSP Cluster 삭제 기능을 수행하는 Cobra Command 생성
+func NewClusterDeleteCSPCmd() *cobra.Command {
+
+	deleteCSPCmd := &cobra.Command{
+		Use:   "deletecsp",
+		Short: "This is delete csp command for cluster",
+		Long:  "This is delete csp command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if cspID == "" {
+				logger.Error("failed to validate --id parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--id parameter value : ", cspID)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCSPCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCSPCmd.PersistentFlags().StringVarP(&cspID, "id", "", "", "csp id")
+
+	return deleteCSPCmd
+}
+
+// NewClusterRegisterCmd - Cluster Register 등록 기능을 수행하는 Cobra Command 생성
+func NewClusterRegisterCmd() *cobra.Command {
+
+	registerCmd := &cobra.Command{
+		Use:   "register",
+		Short: "This is register command for cluster",
+		Long:  "This is register command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	registerCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	registerCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return registerCmd
+}
+
+// NewClusterUnregisterCmd - Cluster Register 제거 기능을 수행하는 Cobra Command 생성
+func NewClusterUnregisterCmd() *cobra.Command {
+
+	unregisterCmd := &cobra.Command{
+		Use:   "unregister",
+		Short: "This is unregister command for cluster",
+		Long:  "This is unregister command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if clusterName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", clusterName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	unregisterCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	unregisterCmd.PersistentFlags().StringVarP(&clusterName, "name", "n", "", "cluster name")
+
+	return unregisterCmd
+}
+
+// NewClusterAddNodeGroupCmd - Cluster NodeGroup 추가 기능을 수행하는 Cobra Command 생성
+func NewClusterAddNodeGroupCmd() *cobra.Command {
+
+	addNodeGroupCmd := &cobra.Command{
+		Use:   "add-nodegroup",
+		Short: "This is add nodegroup command for cluster",
+		Long:  "This is add nodegroup command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	addNodeGroupCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	addNodeGroupCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return addNodeGroupCmd
+}
+
+// NewClusterRemoveNodeGroupCmd - Cluster NodeGroup 제거 기능을 수행하는 Cobra Command 생성
+func NewClusterRemoveNodeGroupCmd() *cobra.Command {
+
+	removeNodeGroupCmd := &cobra.Command{
+		Use:   "remove-nodegroup",
+		Short: "This is remove nodegroup command for cluster",
+		Long:  "This is remove nodegroup command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if clusterName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			if nodeGroupName == "" {
+				logger.Error("failed to validate --nodegroup parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", clusterName)
+			logger.Debug("--nodegroup parameter value : ", nodeGroupName)
+			logger.Debug("--force parameter value : ", force)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	removeNodeGroupCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	removeNodeGroupCmd.PersistentFlags().StringVarP(&clusterName, "name", "n", "", "cluster name")
+	removeNodeGroupCmd.PersistentFlags().StringVarP(&nodeGroupName, "nodegroup", "", "", "nodegroup name")
+	removeNodeGroupCmd.PersistentFlags().StringVarP(&force, "force", "", "false", "force flg (true/false)")
+
+	return removeNodeGroupCmd
+}
+
+// NewClusterSetAutoScalingCmd - NodeGroup AutoScaling 설정 기능을 수행하는 Cobra Command 생성
+func NewClusterSetAutoScalingCmd() *cobra.Command {
+
+	setAutoScalingCmd := &cobra.Command{
+		Use:   "set-autoscaling",
+		Short: "This is set autoscaling command for cluster",
+		Long:  "This is set autoscaling command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if clusterName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			if nodeGroupName == "" {
+				logger.Error("failed to validate --nodegroup parameter")
+				return
+			}
+			if onAutoScaling == "" {
+				logger.Error("failed to validate --on parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", clusterName)
+			logger.Debug("--nodegroup parameter value : ", nodeGroupName)
+			logger.Debug("--on parameter value : ", onAutoScaling)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	setAutoScalingCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	setAutoScalingCmd.PersistentFlags().StringVarP(&clusterName, "name", "n", "", "cluster name")
+	setAutoScalingCmd.PersistentFlags().StringVarP(&nodeGroupName, "nodegroup", "", "", "nodegroup name")
+	setAutoScalingCmd.PersistentFlags().StringVarP(&onAutoScaling, "on", "", "", "autoscaling flg (true/false)")
+
+	return setAutoScalingCmd
+}
+
+// NewClusterChangeScalingCmd - NodeGroup Scaling 크기 변경 기능을 수행하는 Cobra Command 생성
+func NewClusterChangeScalingCmd() *cobra.Command {
+
+	changeScalingCmd := &cobra.Command{
+		Use:   "change-scaling",
+		Short: "This is change scaling command for cluster",
+		Long:  "This is change scaling command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	changeScalingCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	changeScalingCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return changeScalingCmd
+}
+
+// NewClusterUpgradeCmd - Cluster 버전 업그레이드 기능을 수행하는 Cobra Command 생성
+func NewClusterUpgradeCmd() *cobra.Command {
+
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "This is upgrade command for cluster",
+		Long:  "This is upgrade command for cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if clusterName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			if clusterVersion == "" {
+				logger.Error("failed to validate --version parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", clusterName)
+			logger.Debug("--version parameter value : ", clusterVersion)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	upgradeCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	upgradeCmd.PersistentFlags().StringVarP(&clusterName, "name", "n", "", "cluster name")
+	upgradeCmd.PersistentFlags().StringVarP(&clusterVersion, "version", "", "", "cluster version")
+
+	return upgradeCmd
+}
+
+// ExClusterCmd - cluster 사용 예제를 출력하는 Cobra Command 생성
+func ExClusterCmd() *cobra.Command {
+
+	exClusterCmd := &cobra.Command{
+		Use:   "ex",
+		Short: "example to use cluster",
+		Long:  "example to use cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			excmd := `
+[Create Cluster]
+spctl cluster create -d \
+    '{
+      "ConnectionName":"aws-ohio-config",
+      "ReqInfo": {
+        "Name": "spider-cluster-01",
+        "Version": "1.23",
+        "VPCName": "vpc-01",
+        "SubnetNames": ["subnet-01", "subnet-02"],
+        "SecurityGroupNames": ["sg-01"],
+        "NodeGroupList": [
+          { "Name": "ng-01", "VMSpecName": "t3.medium", "KeyPairName": "keypair-01",
+            "OnAutoScaling": "true", "DesiredNodeSize": "2", "MinNodeSize": "1", "MaxNodeSize": "3" }
+        ]
+      }
+    }'
+
+[List Cluster]
+spctl cluster list --cname aws-ohio-config
+
+[Change NodeGroup Scaling]
+spctl cluster change-scaling -d \
+    '{
+      "ConnectionName":"aws-ohio-config", "Name":"spider-cluster-01", "NodeGroupName":"ng-01",
+      "ReqInfo": { "DesiredNodeSize": "3", "MinNodeSize": "1", "MaxNodeSize": "5" }
+    }'
+
+[Upgrade Cluster]
+spctl cluster upgrade --cname aws-ohio-config -n spider-cluster-01 --version 1.24
+
+[Delete Cluster]
+spctl cluster delete --cname aws-ohio-config -n spider-cluster-01
+
+`
+			fmt.Printf(excmd)
+		},
+	}
+
+	return exClusterCmd
+}
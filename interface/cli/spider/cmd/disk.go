@@ -0,0 +1,427 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	"github.com/spf13/cobra"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewDiskCmd - Disk 관리 기능을 수행하는 Cobra Command 생성
+func NewDiskCmd() *cobra.Command {
+
+	diskCmd := &cobra.Command{
+		Use:   "disk",
+		Short: "This is a manageable command for disk",
+		Long:  "This is a manageable command for disk",
+	}
+
+	//  Adds the commands for application.
+	diskCmd.AddCommand(NewDiskCreateCmd())
+	diskCmd.AddCommand(NewDiskListCmd())
+	diskCmd.AddCommand(NewDiskGetCmd())
+	diskCmd.AddCommand(NewDiskDeleteCmd())
+	diskCmd.AddCommand(NewDiskListAllCmd())
+	diskCmd.AddCommand(NewDiskDeleteCSPCmd())
+	diskCmd.AddCommand(NewDiskRegisterCmd())
+	diskCmd.AddCommand(NewDiskUnregisterCmd())
+	diskCmd.AddCommand(NewDiskChangeSizeCmd())
+	diskCmd.AddCommand(NewDiskAttachCmd())
+	diskCmd.AddCommand(NewDiskDetachCmd())
+	diskCmd.AddCommand(ExDiskCmd())
+
+	return diskCmd
+}
+
+// NewDiskCreateCmd - Disk 생성 기능을 수행하는 Cobra Command 생성
+func NewDiskCreateCmd() *cobra.Command {
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "This is create command for disk",
+		Long:  "This is create command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	createCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	createCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return createCmd
+}
+
+// NewDiskListCmd - Disk 목록 기능을 수행하는 Cobra Command 생성
+func NewDiskListCmd() *cobra.Command {
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "This is list command for disk",
+		Long:  "This is list command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listCmd
+}
+
+// NewDiskGetCmd - Disk 조회 기능을 수행하는 Cobra Command 생성
+func NewDiskGetCmd() *cobra.Command {
+
+	getCmd := &cobra.Command{
+		Use:   "get",
+		Short: "This is get command for disk",
+		Long:  "This is get command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if diskName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", diskName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	getCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	getCmd.PersistentFlags().StringVarP(&diskName, "name", "n", "", "disk name")
+
+	return getCmd
+}
+
+// NewDiskDeleteCmd - Disk 삭제 기능을 수행하는 Cobra Command 생성
+func NewDiskDeleteCmd() *cobra.Command {
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "This is delete command for disk",
+		Long:  "This is delete command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if diskName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", diskName)
+			logger.Debug("--force parameter value : ", force)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCmd.PersistentFlags().StringVarP(&diskName, "name", "n", "", "disk name")
+	deleteCmd.PersistentFlags().StringVarP(&force, "force", "", "false", "force flg (true/false)")
+
+	return deleteCmd
+}
+
+// NewDiskListAllCmd - 관리 Disk 목록 기능을 수행하는 Cobra Command 생성
+func NewDiskListAllCmd() *cobra.Command {
+
+	listAllCmd := &cobra.Command{
+		Use:   "listall",
+		Short: "This is list all command for disk",
+		Long:  "This is list all command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listAllCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listAllCmd
+}
+
+// NewDiskDeleteCSPCmd - CSP Disk 삭제 기능을 수행하는 Cobra Command 생성
+func NewDiskDeleteCSPCmd() *cobra.Command {
+
+	deleteCSPCmd := &cobra.Command{
+		Use:   "deletecsp",
+		Short: "This is delete csp command for disk",
+		Long:  "This is delete csp command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if cspID == "" {
+				logger.Error("failed to validate --id parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--id parameter value : ", cspID)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCSPCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCSPCmd.PersistentFlags().StringVarP(&cspID, "id", "", "", "csp id")
+
+	return deleteCSPCmd
+}
+
+// NewDiskRegisterCmd - Disk Register 등록 기능을 수행하는 Cobra Command 생성
+func NewDiskRegisterCmd() *cobra.Command {
+
+	registerCmd := &cobra.Command{
+		Use:   "register",
+		Short: "This is register command for disk",
+		Long:  "This is register command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	registerCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	registerCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return registerCmd
+}
+
+// NewDiskUnregisterCmd - Disk Register 제거 기능을 수행하는 Cobra Command 생성
+func NewDiskUnregisterCmd() *cobra.Command {
+
+	unregisterCmd := &cobra.Command{
+		Use:   "unregister",
+		Short: "This is unregister command for disk",
+		Long:  "This is unregister command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if diskName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", diskName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	unregisterCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	unregisterCmd.PersistentFlags().StringVarP(&diskName, "name", "n", "", "disk name")
+
+	return unregisterCmd
+}
+
+// NewDiskChangeSizeCmd - Disk 크기 변경 기능을 수행하는 Cobra Command 생성
+func NewDiskChangeSizeCmd() *cobra.Command {
+
+	changeSizeCmd := &cobra.Command{
+		Use:   "change-size",
+		Short: "This is change size command for disk",
+		Long:  "This is change size command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if diskName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			if diskSize == "" {
+				logger.Error("failed to validate --size parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", diskName)
+			logger.Debug("--size parameter value : ", diskSize)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	changeSizeCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	changeSizeCmd.PersistentFlags().StringVarP(&diskName, "name", "n", "", "disk name")
+	changeSizeCmd.PersistentFlags().StringVarP(&diskSize, "size", "", "", "disk size (GB)")
+
+	return changeSizeCmd
+}
+
+// NewDiskAttachCmd - Disk 연결 기능을 수행하는 Cobra Command 생성
+func NewDiskAttachCmd() *cobra.Command {
+
+	attachCmd := &cobra.Command{
+		Use:   "attach",
+		Short: "This is attach command for disk",
+		Long:  "This is attach command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if diskName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			if vmName == "" {
+				logger.Error("failed to validate --vm parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", diskName)
+			logger.Debug("--vm parameter value : ", vmName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	attachCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	attachCmd.PersistentFlags().StringVarP(&diskName, "name", "n", "", "disk name")
+	attachCmd.PersistentFlags().StringVarP(&vmName, "vm", "", "", "vm name")
+
+	return attachCmd
+}
+
+// NewDiskDetachCmd - Disk 분리 기능을 수행하는 Cobra Command 생성
+func NewDiskDetachCmd() *cobra.Command {
+
+	detachCmd := &cobra.Command{
+		Use:   "detach",
+		Short: "This is detach command for disk",
+		Long:  "This is detach command for disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if diskName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			if vmName == "" {
+				logger.Error("failed to validate --vm parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", diskName)
+			logger.Debug("--vm parameter value : ", vmName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	detachCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	detachCmd.PersistentFlags().StringVarP(&diskName, "name", "n", "", "disk name")
+	detachCmd.PersistentFlags().StringVarP(&vmName, "vm", "", "", "vm name")
+
+	return detachCmd
+}
+
+// ExDiskCmd - disk 사용 예제를 출력하는 Cobra Command 생성
+func ExDiskCmd() *cobra.Command {
+
+	exDiskCmd := &cobra.Command{
+		Use:   "ex",
+		Short: "example to use disk",
+		Long:  "example to use disk",
+		Run: func(cmd *cobra.Command, args []string) {
+			excmd := `
+[Create Disk]
+spctl disk create -d \
+    '{
+      "ConnectionName":"aws-ohio-config",
+      "ReqInfo": {
+        "Name": "spider-disk-01",
+        "DiskType": "default",
+        "DiskSize": "default"
+      }
+    }'
+
+[List Disk]
+spctl disk list --cname aws-ohio-config
+
+[Change Disk Size]
+spctl disk change-size --cname aws-ohio-config -n spider-disk-01 --size 200
+
+[Attach Disk]
+spctl disk attach --cname aws-ohio-config -n spider-disk-01 --vm vm-01
+
+[Detach Disk]
+spctl disk detach --cname aws-ohio-config -n spider-disk-01 --vm vm-01
+
+[Delete Disk]
+spctl disk delete --cname aws-ohio-config -n spider-disk-01
+
+`
+			fmt.Printf(excmd)
+		},
+	}
+
+	return exDiskCmd
+}
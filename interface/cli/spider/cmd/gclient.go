@@ -285,11 +285,136 @@ func SetupAndRun(cmd *cobra.Command, args []string) {
 		case "unregister":
 			result, err = ccm.UnregisterVMByParam(connectionName, vmName)
 		}
+	case "nlb":
+		switch cmd.Name() {
+		case "create":
+			result, err = ccm.CreateNLB(inData)
+		case "list":
+			result, err = ccm.ListNLBByParam(connectionName)
+		case "get":
+			result, err = ccm.GetNLBByParam(connectionName, nlbName)
+		case "delete":
+			result, err = ccm.DeleteNLBByParam(connectionName, nlbName, force)
+		case "listall":
+			result, err = ccm.ListAllNLBByParam(connectionName)
+		case "deletecsp":
+			result, err = ccm.DeleteCSPNLBByParam(connectionName, cspID)
+		case "register":
+			result, err = ccm.RegisterNLB(inData)
+		case "unregister":
+			result, err = ccm.UnregisterNLBByParam(connectionName, nlbName)
+		case "add-vms":
+			result, err = ccm.AddNLBVMs(inData)
+		case "remove-vms":
+			result, err = ccm.RemoveNLBVMs(inData)
+		case "change-listener":
+			result, err = ccm.ChangeListener(inData)
+		case "change-vmgroup":
+			result, err = ccm.ChangeVMGroup(inData)
+		case "change-healthchecker":
+			result, err = ccm.ChangeHealthChecker(inData)
+		case "gethealth":
+			result, err = ccm.GetVMGroupHealthInfoByParam(connectionName, nlbName)
+		}
+	case "disk":
+		switch cmd.Name() {
+		case "create":
+			result, err = ccm.CreateDisk(inData)
+		case "list":
+			result, err = ccm.ListDiskByParam(connectionName)
+		case "get":
+			result, err = ccm.GetDiskByParam(connectionName, diskName)
+		case "delete":
+			result, err = ccm.DeleteDiskByParam(connectionName, diskName, force)
+		case "listall":
+			result, err = ccm.ListAllDiskByParam(connectionName)
+		case "deletecsp":
+			result, err = ccm.DeleteCSPDiskByParam(connectionName, cspID)
+		case "register":
+			result, err = ccm.RegisterDisk(inData)
+		case "unregister":
+			result, err = ccm.UnregisterDiskByParam(connectionName, diskName)
+		case "change-size":
+			result, err = ccm.ChangeDiskSizeByParam(connectionName, diskName, diskSize)
+		case "attach":
+			result, err = ccm.AttachDiskByParam(connectionName, diskName, vmName)
+		case "detach":
+			result, err = ccm.DetachDiskByParam(connectionName, diskName, vmName)
+		}
+	case "myimage":
+		switch cmd.Name() {
+		case "snapshot":
+			result, err = ccm.SnapshotVM(inData)
+		case "list":
+			result, err = ccm.ListMyImageByParam(connectionName)
+		case "get":
+			result, err = ccm.GetMyImageByParam(connectionName, myImageName)
+		case "delete":
+			result, err = ccm.DeleteMyImageByParam(connectionName, myImageName, force)
+		case "listall":
+			result, err = ccm.ListAllMyImageByParam(connectionName)
+		case "deletecsp":
+			result, err = ccm.DeleteCSPMyImageByParam(connectionName, cspID)
+		case "register":
+			result, err = ccm.RegisterMyImage(inData)
+		case "unregister":
+			result, err = ccm.UnregisterMyImageByParam(connectionName, myImageName)
+		}
+	case "cluster":
+		switch cmd.Name() {
+		case "create":
+			result, err = ccm.CreateCluster(inData)
+		case "list":
+			result, err = ccm.ListClusterByParam(connectionName)
+		case "get":
+			result, err = ccm.GetClusterByParam(connectionName, clusterName)
+		case "delete":
+			result, err = ccm.DeleteClusterByParam(connectionName, clusterName, force)
+		case "listall":
+			result, err = ccm.ListAllClusterByParam(connectionName)
+		case "deletecsp":
+			result, err = ccm.DeleteCSPClusterByParam(connectionName, cspID)
+		case "register":
+			result, err = ccm.RegisterCluster(inData)
+		case "unregister":
+			result, err = ccm.UnregisterClusterByParam(connectionName, clusterName)
+		case "add-nodegroup":
+			result, err = ccm.AddNodeGroup(inData)
+		case "remove-nodegroup":
+			result, err = ccm.RemoveNodeGroupByParam(connectionName, clusterName, nodeGroupName, force)
+		case "set-autoscaling":
+			result, err = ccm.SetNodeGroupAutoScalingByParam(connectionName, clusterName, nodeGroupName, onAutoScaling)
+		case "change-scaling":
+			result, err = ccm.ChangeNodeGroupScaling(inData)
+		case "upgrade":
+			result, err = ccm.UpgradeClusterByParam(connectionName, clusterName, clusterVersion)
+		}
 	case "ssh":
 		switch cmd.Name() {
 		case "run":
 			result, err = ccm.SSHRun(inData)
 		}
+	case "spctl":
+		switch cmd.Name() {
+		case "apply":
+			if dryRun {
+				result, err = ccm.PlanInfra(inData, false)
+			} else {
+				result, err = ccm.ApplyInfra(inData)
+			}
+		case "destroy":
+			if dryRun {
+				result, err = ccm.PlanInfra(inData, true)
+			} else {
+				result, err = ccm.DestroyInfra(inData)
+			}
+		case "anycall":
+			result, err = ccm.AnyCall(inData)
+		case "cspname":
+			result, err = ccm.GetCSPResourceNameByParam(connectionName, resourceType, resourceName)
+		case "splock":
+			result, err = ccm.GetAllSPLockInfo()
+		}
 	}
 
 	if err != nil {
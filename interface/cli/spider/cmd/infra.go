@@ -0,0 +1,82 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cmd
+
+import (
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	"github.com/spf13/cobra"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewApplyCmd - Infra 문서의 자원 생성 기능을 수행하는 Cobra Command 생성
+func NewApplyCmd() *cobra.Command {
+
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "This is apply command for the infra document",
+		Long:  "This is apply command for the infra document(VPC, SecurityGroup, KeyPair, Disk, VM, NLB of a connection)",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+			logger.Debug("--dry-run parameter value : ", dryRun)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	applyCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	applyCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+	applyCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "", false, "show the plan only")
+
+	return applyCmd
+}
+
+// NewDestroyCmd - Infra 문서의 자원 삭제 기능을 수행하는 Cobra Command 생성
+func NewDestroyCmd() *cobra.Command {
+
+	destroyCmd := &cobra.Command{
+		Use:   "destroy",
+		Short: "This is destroy command for the infra document",
+		Long:  "This is destroy command for the infra document, the resources are deleted in reverse order",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+			logger.Debug("--dry-run parameter value : ", dryRun)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	destroyCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	destroyCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+	destroyCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "", false, "show the plan only")
+
+	return destroyCmd
+}
@@ -0,0 +1,309 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	"github.com/spf13/cobra"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewMyImageCmd - MyImage 관리 기능을 수행하는 Cobra Command 생성
+func NewMyImageCmd() *cobra.Command {
+
+	myImageCmd := &cobra.Command{
+		Use:   "myimage",
+		Short: "This is a manageable command for myimage",
+		Long:  "This is a manageable command for myimage",
+	}
+
+	//  Adds the commands for application.
+	myImageCmd.AddCommand(NewMyImageSnapshotCmd())
+	myImageCmd.AddCommand(NewMyImageListCmd())
+	myImageCmd.AddCommand(NewMyImageGetCmd())
+	myImageCmd.AddCommand(NewMyImageDeleteCmd())
+	myImageCmd.AddCommand(NewMyImageListAllCmd())
+	myImageCmd.AddCommand(NewMyImageDeleteCSPCmd())
+	myImageCmd.AddCommand(NewMyImageRegisterCmd())
+	myImageCmd.AddCommand(NewMyImageUnregisterCmd())
+	myImageCmd.AddCommand(ExMyImageCmd())
+
+	return myImageCmd
+}
+
+// NewMyImageSnapshotCmd - MyImage 생성 (VM 스냅샷) 기능을 수행하는 Cobra Command 생성
+func NewMyImageSnapshotCmd() *cobra.Command {
+
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "This is snapshot command for myimage",
+		Long:  "This is snapshot command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	snapshotCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	snapshotCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return snapshotCmd
+}
+
+// NewMyImageListCmd - MyImage 목록 기능을 수행하는 Cobra Command 생성
+func NewMyImageListCmd() *cobra.Command {
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "This is list command for myimage",
+		Long:  "This is list command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listCmd
+}
+
+// NewMyImageGetCmd - MyImage 조회 기능을 수행하는 Cobra Command 생성
+func NewMyImageGetCmd() *cobra.Command {
+
+	getCmd := &cobra.Command{
+		Use:   "get",
+		Short: "This is get command for myimage",
+		Long:  "This is get command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if myImageName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", myImageName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	getCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	getCmd.PersistentFlags().StringVarP(&myImageName, "name", "n", "", "myimage name")
+
+	return getCmd
+}
+
+// NewMyImageDeleteCmd - MyImage 삭제 기능을 수행하는 Cobra Command 생성
+func NewMyImageDeleteCmd() *cobra.Command {
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "This is delete command for myimage",
+		Long:  "This is delete command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if myImageName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", myImageName)
+			logger.Debug("--force parameter value : ", force)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCmd.PersistentFlags().StringVarP(&myImageName, "name", "n", "", "myimage name")
+	deleteCmd.PersistentFlags().StringVarP(&force, "force", "", "false", "force flg (true/false)")
+
+	return deleteCmd
+}
+
+// NewMyImageListAllCmd - 관리 MyImage 목록 기능을 수행하는 Cobra Command 생성
+func NewMyImageListAllCmd() *cobra.Command {
+
+	listAllCmd := &cobra.Command{
+		Use:   "listall",
+		Short: "This is list all command for myimage",
+		Long:  "This is list all command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listAllCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listAllCmd
+}
+
+// NewMyImageDeleteCSPCmd - CSP MyImage 삭제 기능을 수행하는 Cobra Command 생성
+func NewMyImageDeleteCSPCmd() *cobra.Command {
+
+	deleteCSPCmd := &cobra.Command{
+		Use:   "deletecsp",
+		Short: "This is delete csp command for myimage",
+		Long:  "This is delete csp command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if cspID == "" {
+				logger.Error("failed to validate --id parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--id parameter value : ", cspID)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCSPCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCSPCmd.PersistentFlags().StringVarP(&cspID, "id", "", "", "csp id")
+
+	return deleteCSPCmd
+}
+
+// NewMyImageRegisterCmd - MyImage Register 등록 기능을 수행하는 Cobra Command 생성
+func NewMyImageRegisterCmd() *cobra.Command {
+
+	registerCmd := &cobra.Command{
+		Use:   "register",
+		Short: "This is register command for myimage",
+		Long:  "This is register command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	registerCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	registerCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return registerCmd
+}
+
+// NewMyImageUnregisterCmd - MyImage Register 제거 기능을 수행하는 Cobra Command 생성
+func NewMyImageUnregisterCmd() *cobra.Command {
+
+	unregisterCmd := &cobra.Command{
+		Use:   "unregister",
+		Short: "This is unregister command for myimage",
+		Long:  "This is unregister command for myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if myImageName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", myImageName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	unregisterCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	unregisterCmd.PersistentFlags().StringVarP(&myImageName, "name", "n", "", "myimage name")
+
+	return unregisterCmd
+}
+
+// ExMyImageCmd - myimage 사용 예제를 출력하는 Cobra Command 생성
+func ExMyImageCmd() *cobra.Command {
+
+	exMyImageCmd := &cobra.Command{
+		Use:   "ex",
+		Short: "example to use myimage",
+		Long:  "example to use myimage",
+		Run: func(cmd *cobra.Command, args []string) {
+			excmd := `
+[Snapshot VM]
+spctl myimage snapshot -d \
+    '{
+      "ConnectionName":"aws-ohio-config",
+      "ReqInfo": {
+        "Name": "spider-myimage-01",
+        "SourceVM": "vm-01"
+      }
+    }'
+
+[List MyImage]
+spctl myimage list --cname aws-ohio-config
+
+[Get MyImage]
+spctl myimage get --cname aws-ohio-config -n spider-myimage-01
+
+[Delete MyImage]
+spctl myimage delete --cname aws-ohio-config -n spider-myimage-01
+
+`
+			fmt.Printf(excmd)
+		},
+	}
+
+	return exMyImageCmd
+}
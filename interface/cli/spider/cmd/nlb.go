@@ -0,0 +1,491 @@
+// gRPC Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2022.10.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cloud-barista/cb-spider/api-runtime/grpc-runtime/logger"
+	"github.com/spf13/cobra"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// ===== [ Types ] =====
+
+// ===== [ Implementations ] =====
+
+// ===== [ Private Functions ] =====
+
+// ===== [ Public Functions ] =====
+
+// NewNLBCmd - NLB 관리 기능을 수행하는 Cobra Command 생성
+func NewNLBCmd() *cobra.Command {
+
+	nlbCmd := &cobra.Command{
+		Use:   "nlb",
+		Short: "This is a manageable command for nlb",
+		Long:  "This is a manageable command for nlb",
+	}
+
+	//  Adds the commands for application.
+	nlbCmd.AddCommand(NewNLBCreateCmd())
+	nlbCmd.AddCommand(NewNLBListCmd())
+	nlbCmd.AddCommand(NewNLBGetCmd())
+	nlbCmd.AddCommand(NewNLBDeleteCmd())
+	nlbCmd.AddCommand(NewNLBListAllCmd())
+	nlbCmd.AddCommand(NewNLBDeleteCSPCmd())
+	nlbCmd.AddCommand(NewNLBRegisterCmd())
+	nlbCmd.AddCommand(NewNLBUnregisterCmd())
+	nlbCmd.AddCommand(NewNLBAddVMsCmd())
+	nlbCmd.AddCommand(NewNLBRemoveVMsCmd())
+	nlbCmd.AddCommand(NewNLBChangeListenerCmd())
+	nlbCmd.AddCommand(NewNLBChangeVMGroupCmd())
+	nlbCmd.AddCommand(NewNLBChangeHealthCheckerCmd())
+	nlbCmd.AddCommand(NewNLBGetHealthCmd())
+	nlbCmd.AddCommand(ExNLBCmd())
+
+	return nlbCmd
+}
+
+// NewNLBCreateCmd - NLB 생성 기능을 수행하는 Cobra Command 생성
+func NewNLBCreateCmd() *cobra.Command {
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "This is create command for nlb",
+		Long:  "This is create command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	createCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	createCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return createCmd
+}
+
+// NewNLBListCmd - NLB 목록 기능을 수행하는 Cobra Command 생성
+func NewNLBListCmd() *cobra.Command {
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "This is list command for nlb",
+		Long:  "This is list command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listCmd
+}
+
+// NewNLBGetCmd - NLB 조회 기능을 수행하는 Cobra Command 생성
+func NewNLBGetCmd() *cobra.Command {
+
+	getCmd := &cobra.Command{
+		Use:   "get",
+		Short: "This is get command for nlb",
+		Long:  "This is get command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if nlbName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", nlbName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	getCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	getCmd.PersistentFlags().StringVarP(&nlbName, "name", "n", "", "nlb name")
+
+	return getCmd
+}
+
+// NewNLBDeleteCmd - NLB 삭제 기능을 수행하는 Cobra Command 생성
+func NewNLBDeleteCmd() *cobra.Command {
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "This is delete command for nlb",
+		Long:  "This is delete command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if nlbName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", nlbName)
+			logger.Debug("--force parameter value : ", force)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCmd.PersistentFlags().StringVarP(&nlbName, "name", "n", "", "nlb name")
+	deleteCmd.PersistentFlags().StringVarP(&force, "force", "", "false", "force flg (true/false)")
+
+	return deleteCmd
+}
+
+// NewNLBListAllCmd - 관리 NLB 목록 기능을 수행하는 Cobra Command 생성
+func NewNLBListAllCmd() *cobra.Command {
+
+	listAllCmd := &cobra.Command{
+		Use:   "listall",
+		Short: "This is list all command for nlb",
+		Long:  "This is list all command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	listAllCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+
+	return listAllCmd
+}
+
+// NewNLBDeleteCSPCmd - CSP NLB 삭제 기능을 수행하는 Cobra Command 생성
+func NewNLBDeleteCSPCmd() *cobra.Command {
+
+	deleteCSPCmd := &cobra.Command{
+		Use:   "deletecsp",
+		Short: "This is delete csp command for nlb",
+		Long:  "This is delete csp command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if cspID == "" {
+				logger.Error("failed to validate --id parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--id parameter value : ", cspID)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	deleteCSPCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	deleteCSPCmd.PersistentFlags().StringVarP(&cspID, "id", "", "", "csp id")
+
+	return deleteCSPCmd
+}
+
+// NewNLBRegisterCmd - NLB Register 등록 기능을 수행하는 Cobra Command 생성
+func NewNLBRegisterCmd() *cobra.Command {
+
+	registerCmd := &cobra.Command{
+		Use:   "register",
+		Short: "This is register command for nlb",
+		Long:  "This is register command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	registerCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	registerCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return registerCmd
+}
+
+// NewNLBUnregisterCmd - NLB Register 제거 기능을 수행하는 Cobra Command 생성
+func NewNLBUnregisterCmd() *cobra.Command {
+
+	unregisterCmd := &cobra.Command{
+		Use:   "unregister",
+		Short: "This is unregister command for nlb",
+		Long:  "This is unregister command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if nlbName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", nlbName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	unregisterCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	unregisterCmd.PersistentFlags().StringVarP(&nlbName, "name", "n", "", "nlb name")
+
+	return unregisterCmd
+}
+
+// NewNLBAddVMsCmd - NLB VM 추가 기능을 수행하는 Cobra Command 생성
+func NewNLBAddVMsCmd() *cobra.Command {
+
+	addVMsCmd := &cobra.Command{
+		Use:   "add-vms",
+		Short: "This is add vms command for nlb",
+		Long:  "This is add vms command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	addVMsCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	addVMsCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return addVMsCmd
+}
+
+// NewNLBRemoveVMsCmd - NLB VM 제거 기능을 수행하는 Cobra Command 생성
+func NewNLBRemoveVMsCmd() *cobra.Command {
+
+	removeVMsCmd := &cobra.Command{
+		Use:   "remove-vms",
+		Short: "This is remove vms command for nlb",
+		Long:  "This is remove vms command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	removeVMsCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	removeVMsCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return removeVMsCmd
+}
+
+// NewNLBChangeListenerCmd - NLB Listener 변경 기능을 수행하는 Cobra Command 생성
+func NewNLBChangeListenerCmd() *cobra.Command {
+
+	changeListenerCmd := &cobra.Command{
+		Use:   "change-listener",
+		Short: "This is change listener command for nlb",
+		Long:  "This is change listener command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	changeListenerCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	changeListenerCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return changeListenerCmd
+}
+
+// NewNLBChangeVMGroupCmd - NLB VMGroup 변경 기능을 수행하는 Cobra Command 생성
+func NewNLBChangeVMGroupCmd() *cobra.Command {
+
+	changeVMGroupCmd := &cobra.Command{
+		Use:   "change-vmgroup",
+		Short: "This is change vmgroup command for nlb",
+		Long:  "This is change vmgroup command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	changeVMGroupCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	changeVMGroupCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return changeVMGroupCmd
+}
+
+// NewNLBChangeHealthCheckerCmd - NLB HealthChecker 변경 기능을 수행하는 Cobra Command 생성
+func NewNLBChangeHealthCheckerCmd() *cobra.Command {
+
+	changeHealthCheckerCmd := &cobra.Command{
+		Use:   "change-healthchecker",
+		Short: "This is change healthchecker command for nlb",
+		Long:  "This is change healthchecker command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			readInDataFromFile()
+			if inData == "" {
+				logger.Error("failed to validate --indata parameter")
+				return
+			}
+			logger.Debug("--indata parameter value : \n", inData)
+			logger.Debug("--infile parameter value : ", inFile)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	changeHealthCheckerCmd.PersistentFlags().StringVarP(&inData, "indata", "d", "", "input string data")
+	changeHealthCheckerCmd.PersistentFlags().StringVarP(&inFile, "infile", "f", "", "input file path")
+
+	return changeHealthCheckerCmd
+}
+
+// NewNLBGetHealthCmd - NLB VMGroup Health 조회 기능을 수행하는 Cobra Command 생성
+func NewNLBGetHealthCmd() *cobra.Command {
+
+	getHealthCmd := &cobra.Command{
+		Use:   "gethealth",
+		Short: "This is get health command for nlb",
+		Long:  "This is get health command for nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			logger := logger.NewLogger()
+			if connectionName == "" {
+				logger.Error("failed to validate --cname parameter")
+				return
+			}
+			if nlbName == "" {
+				logger.Error("failed to validate --name parameter")
+				return
+			}
+			logger.Debug("--cname parameter value : ", connectionName)
+			logger.Debug("--name parameter value : ", nlbName)
+
+			SetupAndRun(cmd, args)
+		},
+	}
+
+	getHealthCmd.PersistentFlags().StringVarP(&connectionName, "cname", "", "", "connection name")
+	getHealthCmd.PersistentFlags().StringVarP(&nlbName, "name", "n", "", "nlb name")
+
+	return getHealthCmd
+}
+
+// ExNLBCmd - nlb 사용 예제를 출력하는 Cobra Command 생성
+func ExNLBCmd() *cobra.Command {
+
+	exNLBCmd := &cobra.Command{
+		Use:   "ex",
+		Short: "example to use nlb",
+		Long:  "example to use nlb",
+		Run: func(cmd *cobra.Command, args []string) {
+			excmd := `
+[Create NLB]
+spctl nlb create -d \
+    '{
+      "ConnectionName":"aws-ohio-config",
+      "ReqInfo": {
+        "Name": "spider-nlb-01",
+        "VPCName": "vpc-01",
+        "Type": "PUBLIC",
+        "Scope": "REGION",
+        "Listener": { "Protocol": "TCP", "Port": "80" },
+        "VMGroup": { "Protocol": "TCP", "Port": "80", "VMs": ["vm-01", "vm-02"] },
+        "HealthChecker": { "Protocol": "TCP", "Port": "80", "Interval": "default", "Timeout": "default", "Threshold": "default" }
+      }
+    }'
+
+[List NLB]
+spctl nlb list --cname aws-ohio-config
+
+[Get NLB]
+spctl nlb get --cname aws-ohio-config -n spider-nlb-01
+
+[Add VMs]
+spctl nlb add-vms -d '{"ConnectionName":"aws-ohio-config", "Name":"spider-nlb-01", "ReqInfo": {"VMs": ["vm-03"]}}'
+
+[Get VMGroup Health]
+spctl nlb gethealth --cname aws-ohio-config -n spider-nlb-01
+
+[Delete NLB]
+spctl nlb delete --cname aws-ohio-config -n spider-nlb-01
+
+`
+			fmt.Printf(excmd)
+		},
+	}
+
+	return exNLBCmd
+}
@@ -45,9 +45,20 @@ var (
 	securityName   string
 	keypairName    string
 	vmName         string
+	nlbName        string
+	diskName       string
+	diskSize       string
+	myImageName    string
+	clusterName    string
+	clusterVersion string
+	nodeGroupName  string
+	onAutoScaling  string
+	resourceType   string
+	resourceName   string
 	action         string
 	cspID          string
 	force          string
+	dryRun         bool
 
 	parser config.Parser
 )
@@ -93,8 +104,19 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(NewSecurityCmd())
 	rootCmd.AddCommand(NewKeyPairCmd())
 	rootCmd.AddCommand(NewVMCmd())
+	rootCmd.AddCommand(NewNLBCmd())
+	rootCmd.AddCommand(NewDiskCmd())
+	rootCmd.AddCommand(NewMyImageCmd())
+	rootCmd.AddCommand(NewClusterCmd())
+
+	rootCmd.AddCommand(NewAnyCallCmd())
+	rootCmd.AddCommand(NewCSPNameCmd())
+	rootCmd.AddCommand(NewSPLockCmd())
 
 	rootCmd.AddCommand(NewSSHCmd())
 
+	rootCmd.AddCommand(NewApplyCmd())
+	rootCmd.AddCommand(NewDestroyCmd())
+
 	return rootCmd
 }
@@ -0,0 +1,49 @@
+// Re-encrypt all stored Credentials with the current key
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// usage) Stop the CB-Spider server, then run with the new and old keys.
+//   $ export SPIDER_KEY_FILE=/path/to/spider_key.yaml  # keys: old + new, current: new
+//   $ go run rotate-credential-key.go
+//
+// by CB-Spider Team, 2022.10.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloud-barista/cb-store/config"
+	"github.com/sirupsen/logrus"
+
+	cim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+)
+
+var cblog *logrus.Logger
+
+func init() {
+	cblog = config.Cblogger
+}
+
+func main() {
+
+	version, err := cim.GetCurrentKeyVersion()
+	if err != nil {
+		cblog.Error(err)
+		os.Exit(1)
+	}
+	cblog.Info("Start Rotation with the key version " + version + "....")
+
+	count, err := cim.RotateCredentialKey()
+	if err != nil {
+		cblog.Error(err)
+		fmt.Printf("%d Credentials are re-encrypted before the failure.\n", count)
+		os.Exit(1)
+	}
+
+	fmt.Println("===========================")
+	fmt.Printf("%d Credentials are re-encrypted with the key version %s.\n", count, version)
+
+	cblog.Info("Finish Rotation!!")
+}